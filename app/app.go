@@ -2,8 +2,11 @@ package app
 
 import (
 	"context"
+	"net"
 	"net/http"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/megadata-dev/go-snmp-olt-zte-c320/config"
 	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/exporter"
@@ -11,9 +14,12 @@ import (
 	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/repository"
 	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/usecase"
 	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/utils"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/pkg/connlimit"
 	"github.com/megadata-dev/go-snmp-olt-zte-c320/pkg/graceful"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/pkg/pagination"
 	"github.com/megadata-dev/go-snmp-olt-zte-c320/pkg/snmp"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/zerolog/log"
 )
 
@@ -31,8 +37,9 @@ func New() *App {
 }
 
 // Start initializes the application components, sets up connections to external services
-// (SNMP), and starts the HTTP server. It handles graceful shutdown on context
-// cancellation and ensures proper cleanup of resources.
+// (SNMP), and starts the HTTP server. Once the context is cancelled it stops accepting
+// connections, waits up to ServerCfg.shutdown_timeout for the in-flight requests, such as a
+// running scrape, then closes the SNMP connection.
 //
 // Parameters:
 //   - ctx: context.Context for cancellation and timeout propagation
@@ -50,6 +57,11 @@ func (a *App) Start(ctx context.Context) error {
 		log.Error().Err(err).Msg("Failed to load config")
 	}
 
+	// Apply the configured upper bound of the pagination page size
+	if maxPageSize := utils.ConvertStringToInteger(os.Getenv("PAGINATION_MAX_PAGE_SIZE")); maxPageSize > 0 {
+		pagination.MaxPageSize = maxPageSize
+	}
+
 	// Initialize SNMP connection
 	snmpConn, err := snmp.SetupSnmpConnection(cfg)
 	if err != nil {
@@ -73,39 +85,227 @@ func (a *App) Start(ctx context.Context) error {
 		log.Info().Msg("SNMP server successfully connected")
 	}
 
-	// Close SNMP connection after application shutdown
+	// Close SNMP connection after application shutdown, once the in-flight requests completed
 	defer func() {
+		if snmpConn.Conn == nil {
+			return // Never connected
+		}
 		if err := snmpConn.Conn.Close(); err != nil {
 			log.Error().Err(err).Msg("Failed to close SNMP connection")
 		}
 	}()
 
 	// Initialize repository
-	snmpRepo := repository.NewPonRepository(snmpConn.Target, snmpConn.Community, snmpConn.Port)
+	snmpVersion, snmpV3 := snmp.Version(cfg)
+	snmpRepo := repository.NewPonRepository(snmpConn.Target, snmpConn.Community, snmpConn.Port, snmpConn.ContextName, snmp.WalkMethod(cfg), snmpConn.LocalAddr, snmpVersion, snmpV3, snmp.Retry(cfg))
+	defer func() {
+		if err := snmpRepo.Close(); err != nil {
+			log.Error().Err(err).Msg("Failed to close SNMP sessions")
+		}
+	}()
 
-	// Initialize usecase
-	onuUsecase := usecase.NewOnuUsecase(snmpRepo, cfg)
+	// Initialize the ONU list cache of the paginated API, only when enabled
+	var cacheRepo repository.CacheRepository
+	if cfg.RedisCfg.Enabled {
+		redisClient := repository.NewRedisClient(
+			cfg.RedisCfg.Host, cfg.RedisCfg.Port, cfg.RedisCfg.Password, cfg.RedisCfg.DB,
+			cfg.RedisCfg.MinIdleConnections, cfg.RedisCfg.PoolSize, time.Duration(cfg.RedisCfg.PoolTimeout)*time.Second, cfg.RedisCfg.Timeout,
+		)
+		defer func() {
+			if err := redisClient.Close(); err != nil {
+				log.Error().Err(err).Msg("Failed to close Redis client")
+			}
+		}()
+		cacheRepo = repository.NewRedisRepository(redisClient)
+	}
+
+	// Initialize usecase, serving generated ONUs instead of the OLT in synthetic mode
+	onuUsecase := usecase.NewOnuUsecase(snmpRepo, cacheRepo, cfg)
+	if cfg.SyntheticCfg.Enabled {
+		log.Warn().Int("onu_count", cfg.SyntheticCfg.OnuCount).Msg("Synthetic mode enabled, serving generated ONUs instead of the OLT")
+		onuUsecase = usecase.NewSyntheticOnuUsecase(cfg)
+	}
 
 	// Initialize handler
 	onuHandler := handler.NewOnuHandler(onuUsecase)
 
 	// Initialize and register the Prometheus collector
-	onuCollector := exporter.NewOnuCollector(onuUsecase)
-	prometheus.MustRegister(onuCollector)
+	ponUsecase := usecase.NewPonUsecase(snmpRepo, cfg)
+	onuCollector := exporter.NewOnuCollector(onuUsecase, ponUsecase, exporter.ScanRangeFromEnv())
+	if err := exporter.Register(prometheus.DefaultRegisterer, nil, onuCollector); err != nil {
+		log.Error().Err(err).Msg("Failed to register the ONU collector")
+		return err
+	}
+
+	// Refuse to start when a board or PON of the scan range lacks OIDs, synthetic ONUs need none
+	if !cfg.SyntheticCfg.Enabled {
+		if err := cfg.ValidatePonOIDs(onuCollector.ScanRange()); err != nil {
+			log.Error().Err(err).Msg("Invalid PON configuration")
+			return err
+		}
+	}
+
+	// Publish the ONU details of every scrape to Kafka, only when enabled
+	if cfg.KafkaCfg.Enabled {
+		kafkaWriter := repository.NewKafkaWriter(cfg.KafkaCfg.Brokers, cfg.KafkaCfg.Topic, cfg.KafkaCfg.ClientID, cfg.KafkaCfg.Timeout)
+		defer func() {
+			if err := kafkaWriter.Close(); err != nil {
+				log.Error().Err(err).Msg("Failed to close Kafka writer")
+			}
+		}()
+		producer := repository.NewKafkaRepository(kafkaWriter, cfg.KafkaCfg.Timeout)
+		onuCollector.AddPublisher(usecase.NewPublishUsecase(producer))
+	}
+
+	// Keep the ONU inventory in PostgreSQL or MySQL, only when enabled
+	if cfg.InventoryCfg.Enabled {
+		db, err := repository.OpenInventoryDB(
+			cfg.InventoryCfg.Driver, cfg.InventoryCfg.Host, cfg.InventoryCfg.Port,
+			cfg.InventoryCfg.User, cfg.InventoryCfg.Password, cfg.InventoryCfg.Database,
+		)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to open the inventory database")
+			return err
+		}
+		defer func() {
+			if err := db.Close(); err != nil {
+				log.Error().Err(err).Msg("Failed to close the inventory database")
+			}
+		}()
+
+		inventory, err := repository.NewSQLInventoryRepository(
+			db, cfg.InventoryCfg.Driver, cfg.InventoryCfg.Table, cfg.InventoryCfg.BatchSize, cfg.InventoryCfg.Timeout,
+		)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to setup the inventory repository")
+			return err
+		}
+		onuCollector.AddPublisher(usecase.NewInventoryUsecase(inventory))
+	}
+
+	// Serve the exporter's own metrics on a dedicated registry when they are kept apart
+	var internalMetricsHandler http.Handler
+	if onuCollector.SeparateInternalMetrics() {
+		internalRegistry := prometheus.NewRegistry()
+		internalRegistry.MustRegister(onuCollector.InternalCollector())
+		internalMetricsHandler = promhttp.HandlerFor(internalRegistry, promhttp.HandlerOpts{})
+	}
+
+	// Initialize and register the Prometheus collector of the OLT chassis
+	oltCollector := exporter.NewOltCollector(
+		usecase.NewUplinkUsecase(snmpRepo, cfg),
+		ponUsecase,
+		usecase.NewChassisUsecase(snmpRepo, cfg),
+	)
+	if err := exporter.Register(prometheus.DefaultRegisterer, nil, oltCollector); err != nil {
+		log.Error().Err(err).Msg("Failed to register the OLT collector")
+		return err
+	}
+
+	// Push the metrics of both collectors to a remote-write endpoint, only when enabled. The pushes
+	// share the scrape cache with /metrics, without it every push walks the OLT once more.
+	if cfg.RemoteWriteCfg.Enabled {
+		if onuCollector.CacheTTL() <= 0 {
+			log.Warn().Dur("interval", cfg.RemoteWriteCfg.Interval).Msg("Remote write is enabled without PROMETHEUS_CACHE_TTL, every push walks the OLT in addition to the scrapes of /metrics")
+		} else {
+			log.Info().Dur("cache_ttl", onuCollector.CacheTTL()).Msg("Remote write pushes the cached metrics of the last scrape")
+		}
+		remoteWriteRegistry := prometheus.NewRegistry()
+		remoteWriteRegistry.MustRegister(onuCollector, oltCollector)
+		remoteWriter := exporter.NewRemoteWriter(remoteWriteRegistry, cfg.RemoteWriteCfg.URL, cfg.RemoteWriteCfg.Interval, cfg.RemoteWriteCfg.Timeout)
+		go remoteWriter.Run(ctx)
+	}
+
+	// Initialize the handler of the OLTs polled through /metrics?target=
+	targetPool := exporter.NewTargetPool(newTargetUsecases(cfg, snmpConn.LocalAddr))
+	defer func() {
+		if err := targetPool.Close(); err != nil {
+			log.Error().Err(err).Msg("Failed to close the SNMP sessions of the targets")
+		}
+	}()
+	targetHandler := handler.NewTargetHandler(targetPool)
+
+	// Initialize search handler, finding ONUs within the scan range of the collector
+	searchHandler := handler.NewSearchHandler(usecase.NewSearchUsecase(onuUsecase, onuCollector))
+
+	// Initialize alarm handler, serving the ONU details of the last scrape
+	alarmHandler := handler.NewAlarmHandler(usecase.NewAlarmUsecase(onuCollector, cfg))
+
+	// Initialize debug handler, only when enabled with a token
+	var debugHandler *handler.DebugHandler
+	if cfg.DebugCfg.Enabled {
+		if cfg.DebugCfg.Token == "" {
+			log.Warn().Msg("Debug endpoints are enabled without a token, not serving them")
+		} else {
+			debugHandler = handler.NewDebugHandler(usecase.NewDebugUsecase(snmpRepo, cfg, onuCollector), onuCollector)
+		}
+	}
 
 	// Initialize router
-	a.router = loadRoutes(onuHandler)
+	a.router = loadRoutes(onuHandler, searchHandler, alarmHandler, targetHandler, debugHandler, cfg.DebugCfg.Token, internalMetricsHandler)
 
 	// Start server
 	addr := "8081"
-	server := &http.Server{
-		Addr:    ":" + addr,
-		Handler: a.router,
+	server := newServer(":"+addr, a.router, cfg.ServerCfg)
+
+	// Listen at given address, bounding the open connections when configured
+	listener, err := net.Listen("tcp", server.Addr)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to listen at " + server.Addr)
+		return err
 	}
+	listener = connlimit.Listener(listener, cfg.ServerCfg.MaxConnections)
 
-	// Start server at given address
 	log.Info().Msgf("Application started at %s", addr)
 
-	// Graceful shutdown
-	return graceful.Shutdown(ctx, server)
+	// Serve until the context is done, then let the in-flight requests complete
+	return graceful.Shutdown(ctx, server, listener, cfg.ServerCfg.ShutdownTimeout)
+}
+
+// newServer creates the HTTP server of the application with the timeouts of serverCfg. HTTP/2 is
+// negotiated over TLS as usual, and additionally served without TLS (h2c) when enabled.
+func newServer(addr string, handler http.Handler, serverCfg config.ServerConfig) *http.Server {
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: serverCfg.ReadHeaderTimeout,
+		ReadTimeout:       serverCfg.ReadTimeout,
+		WriteTimeout:      serverCfg.WriteTimeout,
+		IdleTimeout:       serverCfg.IdleTimeout,
+	}
+
+	if serverCfg.HTTP2 {
+		server.Protocols = new(http.Protocols)
+		server.Protocols.SetHTTP1(true)
+		server.Protocols.SetHTTP2(true)
+		server.Protocols.SetUnencryptedHTTP2(true)
+	}
+
+	return server
+}
+
+// newTargetUsecases returns the factory of the usecases polling the OLTs of SnmpCfg.targets, by
+// "ip" or "ip:port". The ONU list cache is keyed by board and PON only, so targets do without it.
+func newTargetUsecases(cfg *config.Config, localAddr string) func(target string) (exporter.TargetUsecases, bool) {
+	return func(target string) (exporter.TargetUsecases, bool) {
+		for _, targetCfg := range cfg.SnmpCfg.Targets {
+			port := targetCfg.Port
+			if port == 0 {
+				port = cfg.SnmpCfg.Port
+			}
+			if target != targetCfg.IP && target != net.JoinHostPort(targetCfg.IP, strconv.Itoa(int(port))) {
+				continue
+			}
+
+			snmpRepo := repository.NewPonRepository(targetCfg.IP, targetCfg.Community, port, targetCfg.ContextName, snmp.WalkMethod(cfg), localAddr, targetCfg.Version, snmp.V3Params(targetCfg.V3), snmp.Retry(cfg))
+			return exporter.TargetUsecases{
+				Onu:     usecase.NewOnuUsecase(snmpRepo, nil, cfg),
+				Uplink:  usecase.NewUplinkUsecase(snmpRepo, cfg),
+				Pon:     usecase.NewPonUsecase(snmpRepo, cfg),
+				Chassis: usecase.NewChassisUsecase(snmpRepo, cfg),
+				Closer:  snmpRepo,
+			}, true
+		}
+		return exporter.TargetUsecases{}, false
+	}
 }
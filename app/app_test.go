@@ -0,0 +1,41 @@
+package app
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewServerAppliesConfig(t *testing.T) {
+	handler := http.NewServeMux()
+	server := newServer(":8081", handler, config.ServerConfig{
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      2 * time.Minute,
+		IdleTimeout:       90 * time.Second,
+		HTTP2:             true,
+	})
+
+	assert.Equal(t, ":8081", server.Addr)
+	assert.Same(t, handler, server.Handler)
+	assert.Equal(t, 5*time.Second, server.ReadHeaderTimeout)
+	assert.Equal(t, 30*time.Second, server.ReadTimeout)
+	assert.Equal(t, 2*time.Minute, server.WriteTimeout)
+	assert.Equal(t, 90*time.Second, server.IdleTimeout)
+
+	require.NotNil(t, server.Protocols)
+	assert.True(t, server.Protocols.HTTP1())
+	assert.True(t, server.Protocols.HTTP2())
+	assert.True(t, server.Protocols.UnencryptedHTTP2())
+}
+
+func TestNewServerDefaultProtocols(t *testing.T) {
+	server := newServer(":8081", http.NewServeMux(), config.ServerConfig{})
+
+	assert.Nil(t, server.Protocols, "net/http defaults are kept unless HTTP/2 is enabled")
+	assert.Zero(t, server.WriteTimeout)
+}
@@ -12,7 +12,11 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-func loadRoutes(onuHandler *handler.OnuHandler) http.Handler {
+// loadRoutes builds the HTTP router. /metrics serves the OLT of SnmpCfg unless a target is requested,
+// which targetHandler then serves. The debug routes are only registered when debugHandler is not
+// nil, and require debugToken as a Bearer token. /internal/metrics is only registered when
+// internalMetricsHandler is not nil.
+func loadRoutes(onuHandler *handler.OnuHandler, searchHandler *handler.SearchHandler, alarmHandler *handler.AlarmHandler, targetHandler *handler.TargetHandler, debugHandler *handler.DebugHandler, debugToken string, internalMetricsHandler http.Handler) http.Handler {
 
 	// Initialize logger
 	l := log.Output(zerolog.ConsoleWriter{
@@ -48,11 +52,42 @@ func loadRoutes(onuHandler *handler.OnuHandler) http.Handler {
 		r.Get("/board/{board_id}/pon/{pon_id}", onuHandler.GetByBoardIDAndPonIDWithPaginate)
 	})
 
+	// Define the short route of a single ONU for NOC tooling, served like /board/{board_id}/pon/{pon_id}/onu/{onu_id}
+	apiV1Group.Get("/onu/{board_id}/{pon_id}/{onu_id}", onuHandler.GetByBoardIDPonIDAndOnuID)
+
+	// Define the route finding an ONU of the scanned PONs by its serial number
+	apiV1Group.Get("/onu/search", searchHandler.FindBySerialNumber)
+
+	// Define routes for /api/v1/alarms
+	apiV1Group.Get("/alarms", alarmHandler.GetAlarms)
+
+	// Define routes for /api/v1/debug, protected by the debug token
+	if debugHandler != nil {
+		apiV1Group.Route("/debug", func(r chi.Router) {
+			r.Use(middleware.BearerToken(debugToken))
+			r.Get("/walk", debugHandler.GetWalk)
+			r.Get("/config", debugHandler.GetConfig)
+			r.Get("/metrics", debugHandler.GetMetrics)
+		})
+	}
+
 	// Mount /api/v1/ to root router
 	router.Mount("/api/v1", apiV1Group)
 
-	// Add Prometheus /metrics endpoint
-	router.Handle("/metrics", promhttp.Handler())
+	// Add Prometheus /metrics endpoint, polling another OLT when a target is requested
+	metricsHandler := promhttp.Handler()
+	router.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Has("target") {
+			targetHandler.GetMetrics(w, r)
+			return
+		}
+		metricsHandler.ServeHTTP(w, r)
+	})
+
+	// Add the /internal/metrics endpoint of the exporter's own metrics, when served apart
+	if internalMetricsHandler != nil {
+		router.Handle("/internal/metrics", internalMetricsHandler)
+	}
 
 	return router
 }
@@ -2,32 +2,25 @@ package main
 
 import (
 	"context"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/megadata-dev/go-snmp-olt-zte-c320/app"
 	"github.com/rs/zerolog/log"
 )
 
 func main() {
-	// Initialize application
-	server := app.New()                                     // Create a new instance of application
-	ctx, cancel := context.WithCancel(context.Background()) // Create a new context with cancel function
-	defer cancel()                                          // Cancel context when the main function is finished
+	// Stop the application on SIGINT or SIGTERM, e.g. when the container is stopped
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	// Start application server in a goroutine
-	go func() {
-		err := server.Start(ctx) // Start the application server
-		if err != nil {
-			log.Fatal().Err(err).Msg("Failed to start server") // Log error message
-			cancel()                                           // Cancel context if an error occurred
-		}
-	}()
+	// Start application server, it returns once the in-flight requests completed
+	server := app.New() // Create a new instance of application
+	if err := server.Start(ctx); err != nil {
+		log.Fatal().Err(err).Msg("Failed to run server") // Log error message
+	}
 
-	// Create a channel to wait for a signal to stop the application
-	stopSignal := make(chan struct{})
-
-	// You can replace the select statement with a simple channel receive
-	<-stopSignal
-
-	// Log that the application is stopping
-	log.Info().Msg("Application is stopping")
+	// Log that the application stopped
+	log.Info().Msg("Application stopped")
 }
@@ -2,48 +2,58 @@ package config
 
 import (
 	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
 	"github.com/spf13/viper"
 )
 
+// ponSectionPattern matches the BoardXPonY sections of the config file, viper lowercases the keys
+var ponSectionPattern = regexp.MustCompile(`^board(\d+)pon(\d+)$`)
+
+// DefaultMaxOnuPerPon is the OltCfg.max_onu_per_pon of a config that does not set it
+const DefaultMaxOnuPerPon = 128
+
+// maxOnuPerPonLimit is the largest accepted OltCfg.max_onu_per_pon, beyond any PON provisioning
+const maxOnuPerPonLimit = 1024
+
 // Config represents the main application configuration structure
 // that contains all sub-configurations for SNMP, Redis, OLT, and individual PON boards.
 type Config struct {
-	SnmpCfg     SnmpConfig
-	RedisCfg    RedisConfig
-	OltCfg      OltConfig
-	Board1Pon1  Board1Pon1
-	Board1Pon2  Board1Pon2
-	Board1Pon3  Board1Pon3
-	Board1Pon4  Board1Pon4
-	Board1Pon5  Board1Pon5
-	Board1Pon6  Board1Pon6
-	Board1Pon7  Board1Pon7
-	Board1Pon8  Board1Pon8
-	Board1Pon9  Board1Pon9
-	Board1Pon10 Board1Pon10
-	Board1Pon11 Board1Pon11
-	Board1Pon12 Board1Pon12
-	Board1Pon13 Board1Pon13
-	Board1Pon14 Board1Pon14
-	Board1Pon15 Board1Pon15
-	Board1Pon16 Board1Pon16
-	Board2Pon1  Board2Pon1
-	Board2Pon2  Board2Pon2
-	Board2Pon3  Board2Pon3
-	Board2Pon4  Board2Pon4
-	Board2Pon5  Board2Pon5
-	Board2Pon6  Board2Pon6
-	Board2Pon7  Board2Pon7
-	Board2Pon8  Board2Pon8
-	Board2Pon9  Board2Pon9
-	Board2Pon10 Board2Pon10
-	Board2Pon11 Board2Pon11
-	Board2Pon12 Board2Pon12
-	Board2Pon13 Board2Pon13
-	Board2Pon14 Board2Pon14
-	Board2Pon15 Board2Pon15
-	Board2Pon16 Board2Pon16
+	ServerCfg      ServerConfig
+	SnmpCfg        SnmpConfig
+	RedisCfg       RedisConfig
+	KafkaCfg       KafkaConfig
+	InventoryCfg   InventoryConfig
+	RemoteWriteCfg RemoteWriteConfig
+	AlarmCfg       AlarmConfig
+	UplinkCfg      UplinkConfig
+	ChassisCfg     ChassisConfig
+	SyntheticCfg   SyntheticConfig
+	DebugCfg       DebugConfig
+	OltCfg         OltConfig
+
+	// Pons holds the OIDs of every BoardXPonY section of the config file, by board and PON ID
+	Pons map[int]map[int]model.OltConfig `mapstructure:"-"`
+}
+
+// ServerConfig contains the limits of the HTTP server serving the API and the metrics, so slow
+// or numerous scrapers cannot exhaust its connections. A zero timeout or limit disables it.
+type ServerConfig struct {
+	ReadHeaderTimeout time.Duration `mapstructure:"read_header_timeout"`
+	ReadTimeout       time.Duration `mapstructure:"read_timeout"`
+	WriteTimeout      time.Duration `mapstructure:"write_timeout"` // Must exceed the longest scrape
+	IdleTimeout       time.Duration `mapstructure:"idle_timeout"`
+	MaxConnections    int           `mapstructure:"max_connections"`
+	HTTP2             bool          `mapstructure:"http2"` // Serve HTTP/2 without TLS (h2c) next to HTTP/1.1
+
+	// ShutdownTimeout bounds how long in-flight requests, such as a running scrape, may take to
+	// complete once the exporter is asked to stop
+	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
 }
 
 // SnmpConfig contains configuration parameters for SNMP connection
@@ -52,6 +62,49 @@ type SnmpConfig struct {
 	IP        string `mapstructure:"ip"` // Target IP address of the SNMP device
 	Port      uint16 `mapstructure:"port"`
 	Community string `mapstructure:"community"`
+
+	// ContextName selects the SNMPv3 context of OLTs partitioning their MIBs by context
+	ContextName string `mapstructure:"context_name"`
+
+	// WalkMethod selects GetNext ("next", default) or GetBulk ("bulk") based walks
+	WalkMethod string `mapstructure:"walk_method"`
+
+	// LocalAddr binds the SNMP requests to a source address ("ip" or "ip:port") on multi-homed hosts
+	LocalAddr string `mapstructure:"local_addr"`
+
+	// Version selects SNMPv2c ("2c", default) or SNMPv3 ("3") with the V3 credentials
+	Version string       `mapstructure:"version"`
+	V3      SnmpV3Config `mapstructure:"v3"`
+
+	// Retries retries the requests failing with a timeout or a network error, after RetryBackoff
+	// doubled on every retry. Shared by the Targets.
+	Retries      int           `mapstructure:"retries"`
+	RetryBackoff time.Duration `mapstructure:"retry_backoff"`
+
+	// Targets are the OLTs that can be polled through /metrics?target=, sharing the OIDs of this config
+	Targets []SnmpTargetConfig `mapstructure:"targets"`
+}
+
+// SnmpTargetConfig contains the SNMP settings of an OLT polled through /metrics?target=. A zero
+// port uses the port of SnmpConfig.
+type SnmpTargetConfig struct {
+	IP          string       `mapstructure:"ip"`
+	Port        uint16       `mapstructure:"port"`
+	Community   string       `mapstructure:"community"`
+	ContextName string       `mapstructure:"context_name"`
+	Version     string       `mapstructure:"version"` // "2c" (default) or "3", not inherited from SnmpConfig
+	V3          SnmpV3Config `mapstructure:"v3"`
+}
+
+// SnmpV3Config contains the user based security credentials of SNMPv3. An empty security level
+// follows the passphrases set, and empty protocols default to SHA and AES.
+type SnmpV3Config struct {
+	SecurityLevel  string `mapstructure:"security_level"` // "noAuthNoPriv", "authNoPriv" or "authPriv"
+	Username       string `mapstructure:"username"`
+	AuthProtocol   string `mapstructure:"auth_protocol"` // MD5, SHA, SHA224, SHA256, SHA384 or SHA512
+	AuthPassphrase string `mapstructure:"auth_passphrase"`
+	PrivProtocol   string `mapstructure:"priv_protocol"` // DES, AES, AES192, AES256, AES192C or AES256C
+	PrivPassphrase string `mapstructure:"priv_passphrase"`
 }
 
 // RedisConfig contains configuration parameters for Redis connection
@@ -64,7 +117,96 @@ type RedisConfig struct {
 	DefaultDB          int    `mapstructure:"default_db"`
 	MinIdleConnections int    `mapstructure:"min_idle_connections"`
 	PoolSize           int    `mapstructure:"pool_size"`
-	PoolTimeout        int    `mapstructure:"pool_timeout"`
+	PoolTimeout        int    `mapstructure:"pool_timeout"` // Seconds to wait for a free connection
+
+	// Enabled caches the ONU lists served by the paginated API, TTL bounds how stale they may get
+	Enabled bool          `mapstructure:"enabled"`
+	TTL     time.Duration `mapstructure:"ttl"`
+	Timeout time.Duration `mapstructure:"timeout"` // Bounds every Redis call
+}
+
+// KafkaConfig enables publishing the ONU details of every Prometheus scrape to a Kafka topic,
+// one JSON message per ONU keyed by its serial number.
+type KafkaConfig struct {
+	Enabled  bool          `mapstructure:"enabled"`
+	Brokers  []string      `mapstructure:"brokers"` // Bootstrap brokers, "host:port"
+	Topic    string        `mapstructure:"topic"`
+	ClientID string        `mapstructure:"client_id"`
+	Timeout  time.Duration `mapstructure:"timeout"` // Bounds publishing the ONUs of one scrape
+}
+
+// InventoryConfig enables keeping the inventory of the ONUs in a PostgreSQL or MySQL table, upserting
+// the details of every ONU of every Prometheus scrape keyed by its serial number.
+type InventoryConfig struct {
+	Enabled   bool          `mapstructure:"enabled"`
+	Driver    string        `mapstructure:"driver"` // "postgres" or "mysql"
+	Host      string        `mapstructure:"host"`
+	Port      string        `mapstructure:"port"`
+	User      string        `mapstructure:"user"`
+	Password  string        `mapstructure:"password"`
+	Database  string        `mapstructure:"database"`
+	Table     string        `mapstructure:"table"`      // Created on first use when missing
+	BatchSize int           `mapstructure:"batch_size"` // ONUs per INSERT statement
+	Timeout   time.Duration `mapstructure:"timeout"`    // Bounds writing the ONUs of one scrape
+}
+
+// RemoteWriteConfig enables pushing the exporter metrics to a Prometheus remote-write endpoint,
+// for setups without a Prometheus server scraping the exporter.
+type RemoteWriteConfig struct {
+	Enabled  bool          `mapstructure:"enabled"`
+	URL      string        `mapstructure:"url"`      // Endpoint, e.g. "http://prometheus:9090/api/v1/write"
+	Interval time.Duration `mapstructure:"interval"` // Time between two pushes
+	Timeout  time.Duration `mapstructure:"timeout"`  // Bounds every push
+}
+
+// AlarmConfig contains the thresholds used to derive ONU alarms and their severity.
+// Power levels are in dBm, distances in meters.
+type AlarmConfig struct {
+	RxPowerWarning     float64 `mapstructure:"rx_power_warning"`     // Received power below this raises a warning
+	RxPowerCritical    float64 `mapstructure:"rx_power_critical"`    // Received power below this raises a critical alarm
+	RxPowerMax         float64 `mapstructure:"rx_power_max"`         // Received power above this overloads the receiver
+	TxPowerMin         float64 `mapstructure:"tx_power_min"`         // Transmitted power below this raises a warning
+	TxPowerMax         float64 `mapstructure:"tx_power_max"`         // Transmitted power above this raises a warning
+	OpticalDistanceMax float64 `mapstructure:"optical_distance_max"` // Optical distance above this raises a warning
+}
+
+// UplinkConfig contains the IF-MIB OIDs used to read the OLT uplink (NNI) ports. Uplink ports
+// are the interfaces whose ifName matches NamePattern.
+type UplinkConfig struct {
+	NamePattern    string `mapstructure:"name_pattern"`
+	NameOID        string `mapstructure:"if_name"`
+	AdminStatusOID string `mapstructure:"if_admin_status"`
+	OperStatusOID  string `mapstructure:"if_oper_status"`
+	InOctetsOID    string `mapstructure:"if_hc_in_octets"`
+	OutOctetsOID   string `mapstructure:"if_hc_out_octets"`
+}
+
+// ChassisConfig contains the OIDs used to read the health of the OLT chassis. An empty OID
+// disables the matching metrics, since the private MIB differs between firmware versions.
+type ChassisConfig struct {
+	PsuStatusOID string `mapstructure:"psu_status"` // Table of PSU statuses, indexed by PSU
+
+	// The system readings are single instances read together in one Get, OIDs included
+	SysUptimeOID   string `mapstructure:"sys_uptime"`   // TimeTicks since the agent started, sysUpTime
+	CPUUsageOID    string `mapstructure:"cpu_usage"`    // Percent
+	MemoryUsageOID string `mapstructure:"memory_usage"` // Percent
+	TemperatureOID string `mapstructure:"temperature"`  // Degrees Celsius
+}
+
+// SyntheticConfig enables the synthetic ONU mode, which serves generated ONUs instead of
+// querying the OLT so dashboards can be built without access to real hardware.
+type SyntheticConfig struct {
+	Enabled  bool  `mapstructure:"enabled"`
+	OnuCount int   `mapstructure:"onu_count"`
+	Seed     int64 `mapstructure:"seed"`
+}
+
+// DebugConfig enables the debug endpoints, such as the raw SNMP walk. They are only served when
+// enabled and a token is set, and every request must carry it as a Bearer token.
+type DebugConfig struct {
+	Enabled        bool   `mapstructure:"enabled"`
+	Token          string `mapstructure:"token"`
+	WalkMaxResults int    `mapstructure:"walk_max_results"`
 }
 
 // OltConfig contains base OID configurations for OLT device management
@@ -74,519 +216,45 @@ type OltConfig struct {
 	BaseOID2        string `mapstructure:"base_oid_2"`
 	OnuIDNameAllPon string `mapstructure:"onu_id_name"`
 	OnuTypeAllPon   string `mapstructure:"onu_type"`
-}
-
-// Board1Pon1 contains OID configurations for Board 1 Port 1 ONU management
-// including identifiers, status, power levels, and diagnostic information.
-type Board1Pon1 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-// Board1Pon2 contains OID configurations for Board 1 Port 2 ONU management.
-type Board1Pon2 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-// Board1Pon3 contains OID configurations for Board 1 Port 3 ONU management.
-type Board1Pon3 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-// Board1Pon4 contains OID configurations for Board 1 Port 4 ONU management.
-type Board1Pon4 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-// Board1Pon5 contains OID configurations for Board 1 Port 5 ONU management.
-type Board1Pon5 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-// Board1Pon6 contains OID configurations for Board 1 Port 6 ONU management.
-type Board1Pon6 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-// Board1Pon7 contains OID configurations for Board 1 Port 7 ONU management.
-type Board1Pon7 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-// Board1Pon8 contains OID configurations for Board 1 Port 8 ONU management.
-type Board1Pon8 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-// Board1Pon9 contains OID configurations for Board 1 Port 9 ONU management.
-type Board1Pon9 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-// Board1Pon10 contains OID configurations for Board 1 Port 10 ONU management.
-type Board1Pon10 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-// Board1Pon11 contains OID configurations for Board 1 Port 11 ONU management.
-type Board1Pon11 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-// Board1Pon12 contains OID configurations for Board 1 Port 12 ONU management.
-type Board1Pon12 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-// Board1Pon13 contains OID configurations for Board 1 Port 13 ONU management.
-type Board1Pon13 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-// Board1Pon14 contains OID configurations for Board 1 Port 14 ONU management.
-type Board1Pon14 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-// Board1Pon15 contains OID configurations for Board 1 Port 15 ONU management.
-type Board1Pon15 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-// Board1Pon16 contains OID configurations for Board 1 Port 16 ONU management.
-type Board1Pon16 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-// Board2Pon1 contains OID configurations for Board 2 Port 1 ONU management.
-type Board2Pon1 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-// Board2Pon2 contains OID configurations for Board 2 Port 2 ONU management.
-type Board2Pon2 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-// Board2Pon3 contains OID configurations for Board 2 Port 3 ONU management.
-type Board2Pon3 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-// Board2Pon4 contains OID configurations for Board 2 Port 4 ONU management.
-type Board2Pon4 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
 
-// Board2Pon5 contains OID configurations for Board 2 Port 5 ONU management.
-type Board2Pon5 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
+	// SerialNumberConcurrency bounds the parallel per-ONU serial number reads of a PON
+	SerialNumberConcurrency int `mapstructure:"serial_number_concurrency"`
 
-// Board2Pon6 contains OID configurations for Board 2 Port 6 ONU management.
-type Board2Pon6 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
+	// MaxOnuPerPon is the number of ONU IDs a PON is provisioned for (64, 128 or 256 depending on
+	// the firmware), the range the empty ONU IDs are looked for in
+	MaxOnuPerPon int `mapstructure:"max_onu_per_pon"`
 
-// Board2Pon7 contains OID configurations for Board 2 Port 7 ONU management.
-type Board2Pon7 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
+	// CollectQosProfile enables reading the QoS profile of every ONU, one extra SNMP request per ONU
+	CollectQosProfile bool `mapstructure:"collect_qos_profile"`
 
-// Board2Pon8 contains OID configurations for Board 2 Port 8 ONU management.
-type Board2Pon8 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
+	// CollectMulticast enables reading the IGMP snooping state and joined group count of every ONU,
+	// two extra SNMP requests per ONU
+	CollectMulticast bool `mapstructure:"collect_multicast"`
 
-// Board2Pon9 contains OID configurations for Board 2 Port 9 ONU management.
-type Board2Pon9 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
+	// CollectCatv enables reading the CATV (RF video) port status and output level of every ONU,
+	// two extra SNMP requests per ONU
+	CollectCatv bool `mapstructure:"collect_catv"`
 
-// Board2Pon10 contains OID configurations for Board 2 Port 10 ONU management.
-type Board2Pon10 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
+	// CollectTcont enables reading the T-CONT allocation of every ONU, one extra SNMP request per ONU
+	CollectTcont bool `mapstructure:"collect_tcont"`
 
-// Board2Pon11 contains OID configurations for Board 2 Port 11 ONU management.
-type Board2Pon11 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
+	// CollectUniPorts enables reading the negotiated speed and duplex of the Ethernet (UNI) ports of
+	// every ONU, one or two extra SNMP walks per ONU
+	CollectUniPorts bool `mapstructure:"collect_uni_ports"`
 
-// Board2Pon12 contains OID configurations for Board 2 Port 12 ONU management.
-type Board2Pon12 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
+	// StatusWalk reads the status of all ONUs of a PON with a single walk during discovery,
+	// instead of one SNMP request per ONU
+	StatusWalk bool `mapstructure:"status_walk"`
 
-// Board2Pon13 contains OID configurations for Board 2 Port 13 ONU management.
-type Board2Pon13 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-// Board2Pon14 contains OID configurations for Board 2 Port 14 ONU management.
-type Board2Pon14 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
+	// AttributeTimeout bounds every per-ONU attribute Get, so an attribute the OLT answers slowly
+	// (e.g. an unsupported MIB) only loses its own value. Zero waits for the SNMP timeout.
+	AttributeTimeout time.Duration `mapstructure:"attribute_timeout"`
 
-// Board2Pon15 contains OID configurations for Board 2 Port 15 ONU management.
-type Board2Pon15 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-// Board2Pon16 contains OID configurations for Board 2 Port 16 ONU management.
-type Board2Pon16 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
+	// DiscoveryRetries retries the ONU walk of a PON during discovery when it fails with a timeout or
+	// a network error, after DiscoveryRetryBackoff doubled before every next retry, on top of the
+	// retries of every SNMP request. A failed walk would drop the whole PON from the scrape.
+	DiscoveryRetries      int           `mapstructure:"discovery_retries"`
+	DiscoveryRetryBackoff time.Duration `mapstructure:"discovery_retry_backoff"`
 }
 
 // LoadConfig file from given path using viper
@@ -604,6 +272,107 @@ func LoadConfig(filename string) (*Config, error) {
 	// Allow environment variables to override config
 	v.AutomaticEnv()
 
+	// Only bound the request headers by default, a scrape may take minutes on a large OLT
+	v.SetDefault("ServerCfg.read_header_timeout", "10s")
+	v.SetDefault("ServerCfg.read_timeout", "0s")
+	v.SetDefault("ServerCfg.write_timeout", "0s")
+	v.SetDefault("ServerCfg.idle_timeout", "2m")
+	v.SetDefault("ServerCfg.max_connections", 0)
+	v.SetDefault("ServerCfg.http2", false)
+	v.SetDefault("ServerCfg.shutdown_timeout", "10s")
+
+	// The ONU list cache is only used when explicitly enabled
+	v.SetDefault("RedisCfg.enabled", false)
+	v.SetDefault("RedisCfg.ttl", "1m")
+	v.SetDefault("RedisCfg.timeout", "2s")
+
+	// Scrapes are only published to Kafka when explicitly enabled
+	v.SetDefault("KafkaCfg.enabled", false)
+	v.SetDefault("KafkaCfg.brokers", []string{"localhost:9092"})
+	v.SetDefault("KafkaCfg.topic", "zte-onu")
+	v.SetDefault("KafkaCfg.client_id", "zte-olt-exporter")
+	v.SetDefault("KafkaCfg.timeout", "5s")
+
+	// The ONU inventory is only written to the database when explicitly enabled
+	v.SetDefault("InventoryCfg.enabled", false)
+	v.SetDefault("InventoryCfg.driver", "postgres")
+	v.SetDefault("InventoryCfg.host", "localhost")
+	v.SetDefault("InventoryCfg.port", "") // Default port of the driver
+	v.SetDefault("InventoryCfg.user", "postgres")
+	v.SetDefault("InventoryCfg.password", "")
+	v.SetDefault("InventoryCfg.database", "postgres")
+	v.SetDefault("InventoryCfg.table", "onu_inventory")
+	v.SetDefault("InventoryCfg.batch_size", 500)
+	v.SetDefault("InventoryCfg.timeout", "30s")
+
+	// Metrics are only pushed to a remote-write endpoint when explicitly enabled
+	v.SetDefault("RemoteWriteCfg.enabled", false)
+	v.SetDefault("RemoteWriteCfg.url", "")
+	v.SetDefault("RemoteWriteCfg.interval", "1m")
+	v.SetDefault("RemoteWriteCfg.timeout", "30s")
+
+	// GetNext based walks unless GetBulk is explicitly requested
+	v.SetDefault("SnmpCfg.walk_method", "next")
+
+	// SNMPv2c with the community unless SNMPv3 is explicitly requested
+	v.SetDefault("SnmpCfg.version", "2c")
+
+	// Let the OS pick the source address unless one is configured
+	v.SetDefault("SnmpCfg.local_addr", "")
+
+	// Transient failures are only retried when explicitly requested
+	v.SetDefault("SnmpCfg.retries", 0)
+	v.SetDefault("SnmpCfg.retry_backoff", "500ms")
+
+	// Default alarm thresholds, typical for a GPON class B+ link
+	v.SetDefault("AlarmCfg.rx_power_warning", -25.0)
+	v.SetDefault("AlarmCfg.rx_power_critical", -28.0)
+	v.SetDefault("AlarmCfg.rx_power_max", -8.0)
+	v.SetDefault("AlarmCfg.tx_power_min", 0.5)
+	v.SetDefault("AlarmCfg.tx_power_max", 5.0)
+	v.SetDefault("AlarmCfg.optical_distance_max", 20000.0)
+
+	// Default uplink ports and their standard IF-MIB columns
+	v.SetDefault("UplinkCfg.name_pattern", "^x?gei_")
+	v.SetDefault("UplinkCfg.if_name", ".1.3.6.1.2.1.31.1.1.1.1")
+	v.SetDefault("UplinkCfg.if_admin_status", ".1.3.6.1.2.1.2.2.1.7")
+	v.SetDefault("UplinkCfg.if_oper_status", ".1.3.6.1.2.1.2.2.1.8")
+	v.SetDefault("UplinkCfg.if_hc_in_octets", ".1.3.6.1.2.1.31.1.1.1.6")
+	v.SetDefault("UplinkCfg.if_hc_out_octets", ".1.3.6.1.2.1.31.1.1.1.10")
+	v.SetDefault("ChassisCfg.psu_status", "")
+	v.SetDefault("ChassisCfg.sys_uptime", ".1.3.6.1.2.1.1.3.0")
+	v.SetDefault("ChassisCfg.cpu_usage", "")
+	v.SetDefault("ChassisCfg.memory_usage", "")
+	v.SetDefault("ChassisCfg.temperature", "")
+
+	// Synthetic ONUs are only served when explicitly enabled
+	v.SetDefault("SyntheticCfg.enabled", false)
+	v.SetDefault("SyntheticCfg.onu_count", 64)
+	v.SetDefault("SyntheticCfg.seed", 1)
+
+	// Debug endpoints are only served when explicitly enabled
+	v.SetDefault("DebugCfg.enabled", false)
+	v.SetDefault("DebugCfg.token", "")
+	v.SetDefault("DebugCfg.walk_max_results", 1000)
+
+	// Default number of parallel per-ONU serial number reads
+	v.SetDefault("OltCfg.serial_number_concurrency", 4)
+	v.SetDefault("OltCfg.max_onu_per_pon", DefaultMaxOnuPerPon)
+	v.SetDefault("OltCfg.collect_qos_profile", false)
+	v.SetDefault("OltCfg.collect_multicast", false)
+	v.SetDefault("OltCfg.collect_catv", false)
+	v.SetDefault("OltCfg.collect_tcont", false)
+	v.SetDefault("OltCfg.collect_uni_ports", false)
+	v.SetDefault("OltCfg.status_walk", false)
+	v.SetDefault("OltCfg.attribute_timeout", "0s")
+	v.SetDefault("OltCfg.discovery_retries", 0)
+	v.SetDefault("OltCfg.discovery_retry_backoff", "1s")
+
+	// AutomaticEnv only matches the nested key, offer a plain variable as well
+	if err := v.BindEnv("OltCfg.max_onu_per_pon", "OLTCFG.MAX_ONU_PER_PON", "MAX_ONU_PER_PON"); err != nil {
+		return nil, err
+	}
+
 	// Read config file
 	if err := v.ReadInConfig(); err != nil {
 		var configFileNotFoundError viper.ConfigFileNotFoundError // Initialize config file not found error
@@ -620,5 +389,91 @@ func LoadConfig(filename string) (*Config, error) {
 		return nil, err
 	}
 
+	if cfg.OltCfg.MaxOnuPerPon < 1 || cfg.OltCfg.MaxOnuPerPon > maxOnuPerPonLimit {
+		return nil, fmt.Errorf("invalid OltCfg.max_onu_per_pon %d, it must be between 1 and %d", cfg.OltCfg.MaxOnuPerPon, maxOnuPerPonLimit)
+	}
+
+	pons, err := loadPonConfigs(v)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Pons = pons
+
 	return &cfg, nil
 }
+
+// loadPonConfigs unmarshals every BoardXPonY section into the OIDs of board X and PON Y
+func loadPonConfigs(v *viper.Viper) (map[int]map[int]model.OltConfig, error) {
+	pons := make(map[int]map[int]model.OltConfig)
+	for key := range v.AllSettings() {
+		match := ponSectionPattern.FindStringSubmatch(key)
+		if match == nil {
+			continue
+		}
+		boardID, _ := strconv.Atoi(match[1])
+		ponID, _ := strconv.Atoi(match[2])
+
+		var oltConfig model.OltConfig
+		if err := v.UnmarshalKey(key, &oltConfig); err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		if pons[boardID] == nil {
+			pons[boardID] = make(map[int]model.OltConfig)
+		}
+		pons[boardID][ponID] = oltConfig
+	}
+	return pons, nil
+}
+
+// requiredPonOIDs are the keys every BoardXPonY section of the scan range needs, for the ONUs of
+// the PON to be discovered and detailed. The other keys are optional.
+var requiredPonOIDs = []struct {
+	key string
+	oid func(model.OltConfig) string
+}{
+	{"onu_id_name", func(c model.OltConfig) string { return c.OnuIDNameOID }},
+	{"onu_type", func(c model.OltConfig) string { return c.OnuTypeOID }},
+	{"onu_serial_number", func(c model.OltConfig) string { return c.OnuSerialNumberOID }},
+	{"onu_rx_power", func(c model.OltConfig) string { return c.OnuRxPowerOID }},
+	{"onu_tx_power", func(c model.OltConfig) string { return c.OnuTxPowerOID }},
+	{"onu_status_id", func(c model.OltConfig) string { return c.OnuStatusOID }},
+	{"onu_ip_address", func(c model.OltConfig) string { return c.OnuIPAddressOID }},
+	{"onu_description", func(c model.OltConfig) string { return c.OnuDescriptionOID }},
+	{"onu_last_online_time", func(c model.OltConfig) string { return c.OnuLastOnlineOID }},
+	{"onu_last_offline_time", func(c model.OltConfig) string { return c.OnuLastOfflineOID }},
+	{"onu_last_offline_reason", func(c model.OltConfig) string { return c.OnuLastOfflineReasonOID }},
+	{"onu_gpon_optical_distance", func(c model.OltConfig) string { return c.OnuGponOpticalDistanceOID }},
+}
+
+// ValidatePonOIDs checks that every board and PON of scanRange has a BoardXPonY section with all
+// the required OIDs, so a scan range exceeding the config fails at startup rather than on every
+// scrape. The error lists every gap.
+func (cfg *Config) ValidatePonOIDs(scanRange model.ScanRange) error {
+	var gaps []string
+	for boardID := scanRange.BoardMin; boardID <= scanRange.BoardMax; boardID++ {
+		for ponID := scanRange.PonMin; ponID <= scanRange.PonMax; ponID++ {
+			section := fmt.Sprintf("Board%dPon%d", boardID, ponID)
+			oltConfig, ok := cfg.Pons[boardID][ponID]
+			if !ok {
+				gaps = append(gaps, section+" (missing section)")
+				continue
+			}
+
+			var missing []string
+			for _, required := range requiredPonOIDs {
+				if required.oid(oltConfig) == "" {
+					missing = append(missing, required.key)
+				}
+			}
+			if len(missing) > 0 {
+				gaps = append(gaps, fmt.Sprintf("%s (missing %s)", section, strings.Join(missing, ", ")))
+			}
+		}
+	}
+
+	if len(gaps) > 0 {
+		return fmt.Errorf("OIDs missing for boards %d-%d and PONs %d-%d of the scan range: %s",
+			scanRange.BoardMin, scanRange.BoardMax, scanRange.PonMin, scanRange.PonMax, strings.Join(gaps, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePonOIDs(t *testing.T) {
+	cfg, err := LoadConfig("cfg")
+	require.NoError(t, err)
+
+	assert.NoError(t, cfg.ValidatePonOIDs(model.ScanRange{BoardMin: 1, BoardMax: 2, PonMin: 1, PonMax: 16}))
+
+	err = cfg.ValidatePonOIDs(model.ScanRange{BoardMin: 2, BoardMax: 3, PonMin: 16, PonMax: 17})
+	assert.EqualError(t, err, "OIDs missing for boards 2-3 and PONs 16-17 of the scan range: "+
+		"Board2Pon17 (missing section); Board3Pon16 (missing section); Board3Pon17 (missing section)")
+}
+
+func TestValidatePonOIDsIncompleteSection(t *testing.T) {
+	complete := model.OltConfig{
+		OnuIDNameOID: ".1", OnuTypeOID: ".2", OnuSerialNumberOID: ".3", OnuRxPowerOID: ".4",
+		OnuTxPowerOID: ".5", OnuStatusOID: ".6", OnuIPAddressOID: ".7", OnuDescriptionOID: ".8",
+		OnuLastOnlineOID: ".9", OnuLastOfflineOID: ".10", OnuLastOfflineReasonOID: ".11", OnuGponOpticalDistanceOID: ".12",
+	}
+	incomplete := complete
+	incomplete.OnuTypeOID = ""
+	incomplete.OnuGponOpticalDistanceOID = ""
+	cfg := &Config{Pons: map[int]map[int]model.OltConfig{1: {1: complete, 2: incomplete}}}
+
+	err := cfg.ValidatePonOIDs(model.ScanRange{BoardMin: 1, BoardMax: 1, PonMin: 1, PonMax: 2})
+	assert.EqualError(t, err, "OIDs missing for boards 1-1 and PONs 1-2 of the scan range: "+
+		"Board1Pon2 (missing onu_type, onu_gpon_optical_distance)")
+}
+
+func TestLoadConfigMaxOnuPerPon(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected int
+		err      string
+	}{
+		{value: "", expected: DefaultMaxOnuPerPon},
+		{value: "1", expected: 1},
+		{value: "1024", expected: 1024},
+		{value: "0", err: "invalid OltCfg.max_onu_per_pon 0, it must be between 1 and 1024"},
+		{value: "1025", err: "invalid OltCfg.max_onu_per_pon 1025, it must be between 1 and 1024"},
+	}
+
+	for _, tt := range tests {
+		t.Run("MAX_ONU_PER_PON="+tt.value, func(t *testing.T) {
+			t.Setenv("MAX_ONU_PER_PON", tt.value)
+
+			cfg, err := LoadConfig("cfg")
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, cfg.OltCfg.MaxOnuPerPon)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package exporter
+
+import (
+	"sync"
+	"time"
+)
+
+// ponKey identifies a single PON port on the OLT.
+type ponKey struct {
+	board int
+	pon   int
+}
+
+// ponBreakerState tracks consecutive discovery failures of a single PON.
+type ponBreakerState struct {
+	failures  int
+	openUntil time.Time
+}
+
+// ponCircuitBreaker skips PONs whose discovery failed repeatedly, so a dead port does not
+// eat into the scrape budget on every collection. Once the open period has elapsed the PON
+// is tried again; a success closes the circuit, another failure reopens it.
+type ponCircuitBreaker struct {
+	mu           sync.Mutex
+	threshold    int           // Consecutive failures before the circuit opens, 0 disables the breaker
+	openDuration time.Duration // How long an open circuit skips the PON
+	now          func() time.Time
+	states       map[ponKey]*ponBreakerState
+	skippedTotal int // Number of PON discoveries skipped since startup
+}
+
+// newPonCircuitBreaker creates a breaker that opens after threshold consecutive failures.
+func newPonCircuitBreaker(threshold int, openDuration time.Duration) *ponCircuitBreaker {
+	return &ponCircuitBreaker{
+		threshold:    threshold,
+		openDuration: openDuration,
+		now:          time.Now,
+		states:       make(map[ponKey]*ponBreakerState),
+	}
+}
+
+// allow reports whether the PON should be scraped, counting it as skipped otherwise.
+func (b *ponCircuitBreaker) allow(key ponKey) bool {
+	if b.threshold <= 0 {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state, ok := b.states[key]
+	if !ok || state.failures < b.threshold || !b.now().Before(state.openUntil) {
+		return true
+	}
+	b.skippedTotal++
+	return false
+}
+
+// recordSuccess closes the circuit of the PON.
+func (b *ponCircuitBreaker) recordSuccess(key ponKey) {
+	if b.threshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.states, key)
+}
+
+// recordFailure counts a failed discovery and opens the circuit once the threshold is reached.
+func (b *ponCircuitBreaker) recordFailure(key ponKey) {
+	if b.threshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state, ok := b.states[key]
+	if !ok {
+		state = &ponBreakerState{}
+		b.states[key] = state
+	}
+	state.failures++
+	if state.failures >= b.threshold {
+		state.openUntil = b.now().Add(b.openDuration)
+	}
+}
+
+// stats returns the number of PONs currently skipped and the total skipped since startup.
+func (b *ponCircuitBreaker) stats() (openCircuits int, skippedTotal int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	for _, state := range b.states {
+		if state.failures >= b.threshold && now.Before(state.openUntil) {
+			openCircuits++
+		}
+	}
+	return openCircuits, b.skippedTotal
+}
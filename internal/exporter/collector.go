@@ -1,30 +1,162 @@
 package exporter
 
 import (
+	"cmp"
 	"context"
+	"maps"
 	"os"
 	"regexp"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
 	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/usecase"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/utils"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rs/zerolog/log"
 )
 
 // OnuCollector implements the prometheus.Collector interface.
 type OnuCollector struct {
-	onuUsecase usecase.OnuUseCaseInterface
-	boardMin   int
-	boardMax   int
-	ponMin     int
-	ponMax     int
+	onuUsecase            usecase.OnuUseCaseInterface
+	ponUsecase            usecase.PonUseCaseInterface // Reads the OLT side of every PON port
+	boardMin              int
+	boardMax              int
+	ponMin                int
+	ponMax                int
+	dedupKey              dedupKey // Identity used to deduplicate ONUs and to label zte_onu_status
+	trackMultiLocation    bool     // Report serials seen on more than one board/PON instead of hiding them
+	emitPonUp             bool     // Report the discovery outcome of every PON, so failed PONs differ from empty ones
+	emitDistanceFeet      bool     // Also report the optical distance in feet
+	skipEmptyTimestamps   bool     // Leave out the last online/offline time of ONUs never online/offline instead of reporting 0
+	emitEmptySlots        bool     // Report the free ONU IDs of every PON with the empty slot status
+	emitStatusCount       bool     // Report the number of ONUs in every status across the OLT
+	emitProcessedOrder    bool     // Report the order in which the ONUs were processed, for debugging
+	emitFetchDuration     bool     // Report how long the details of every ONU took to fetch, for debugging
+	ponDetails            bool     // Fetch the details of the ONUs of every PON in one pass instead of ONU by ONU
+	maintenance           *maintenanceScope
+	statusValues          statusValues // zte_onu_status value of every status string
+	powerRange            powerRange   // Valid optical power readings
+	invalidPower          *invalidPowerCounter
+	descriptions          descriptionFile     // Descriptions of the ONUs the OLT has none for
+	mappingInfoLimit      int                 // Maximum number of zte_onu_mapping_info series per scrape, 0 for no limit
+	guardTripped          atomic.Bool         // Whether the last scrape exceeded mappingInfoLimit and skipped zte_onu_mapping_info
+	workers               int                 // Number of ONUs whose details are fetched concurrently, the maximum with adaptive concurrency
+	concurrency           *concurrencyLimiter // Number of those workers allowed to fetch at the same time
+	jobsBuffer            int                 // Capacity of the queue between discovery and the workers
+	discoveryConcurrency  int                 // Number of PONs walked concurrently during discovery
+	retryEmptyScrape      bool                // Retry the discovery once when it finds no ONU although the previous scrape did
+	retryEmptyScrapeDelay time.Duration       // Delay before that retry
+	prioritizeFlapping    bool                // Fetch the details of recently flapping ONUs first
+	flaps                 *flapTracker        // Discovered status of every ONU across scrapes, to spot flaps
+	sampler               *detailSampler      // Which ONUs have their details fetched in a scrape
+	lastDiscovered        atomic.Int64        // Number of ONUs discovered by the previous scrape
+	descs                 *metricDescs
+	internalDescs         *internalMetricDescs
+	separateInternal      bool         // Serve the internal metrics only through InternalCollector
+	lastScrapeDuration    atomic.Int64 // Duration of the last completed scrape, in nanoseconds
+	lastScrapeOnus        atomic.Int64 // Number of ONUs processed by the last completed scrape
+	lastScrapeSuccess     atomic.Bool  // Whether the last completed scrape discovered a PON and fetched every ONU in time
+	ponErrors             *ponErrorCounter
+	breaker               *ponCircuitBreaker
+	throughput            *counterRateTracker // Traffic counters of the previous scrape, used to derive throughput
+	snapshot              *scrapeSnapshot     // ONU details of the last scrape, served by the HTTP API
+	cache                 *scrapeCache        // Metrics of the last scrape, served again until they are stale
+	smoke                 *smokeSelector      // The single PON of every scrape in smoke mode, nil to scrape every PON
+	labels                *labelCache         // Sanitized mapping labels per serial number
+	publishers            []*publisher        // Receive the ONU details of every scrape
 }
 
-// NewOnuCollector creates a new OnuCollector and configures the scan range.
-func NewOnuCollector(onuUsecase usecase.OnuUseCaseInterface) *OnuCollector {
+// NewOnuCollector creates a new OnuCollector scanning the boards and PONs of scanRange.
+func NewOnuCollector(onuUsecase usecase.OnuUseCaseInterface, ponUsecase usecase.PonUseCaseInterface, scanRange model.ScanRange) *OnuCollector {
+	workers := envInt("PROMETHEUS_WORKERS", 4)
+	if workers < 1 {
+		workers = 1
+	}
+	// A negative buffer is invalid, zero hands every ONU directly to a worker.
+	jobsBuffer := envInt("PROMETHEUS_JOBS_BUFFER", 1000)
+	if jobsBuffer < 0 {
+		jobsBuffer = 1000
+	}
+
+	// With adaptive concurrency PROMETHEUS_WORKERS is only the starting point, the limiter then
+	// follows the latency of the OLT between PROMETHEUS_WORKERS_MIN and PROMETHEUS_WORKERS_MAX.
+	concurrency := newConcurrencyLimiter(workers, workers, workers, 0)
+	if envBool("PROMETHEUS_ADAPTIVE_CONCURRENCY", false) {
+		concurrency = newConcurrencyLimiter(
+			workers,
+			envInt("PROMETHEUS_WORKERS_MIN", 1),
+			envInt("PROMETHEUS_WORKERS_MAX", 16),
+			envDuration("PROMETHEUS_LATENCY_TARGET", time.Second),
+		)
+		workers = concurrency.max
+	}
+
+	discoveryConcurrency := envInt("PROMETHEUS_DISCOVERY_CONCURRENCY", 4)
+	if discoveryConcurrency < 1 {
+		discoveryConcurrency = 1
+	}
+
+	dedup := parseDedupKey(os.Getenv("PROMETHEUS_DEDUP_KEY"))
+
+	return &OnuCollector{
+		onuUsecase:          onuUsecase,
+		ponUsecase:          ponUsecase,
+		dedupKey:            dedup,
+		boardMin:            scanRange.BoardMin,
+		boardMax:            scanRange.BoardMax,
+		ponMin:              scanRange.PonMin,
+		ponMax:              scanRange.PonMax,
+		trackMultiLocation:  envBool("PROMETHEUS_TRACK_MULTI_LOCATION", true),
+		emitPonUp:           envBool("PROMETHEUS_EMIT_PON_UP", true),
+		emitDistanceFeet:    envBool("PROMETHEUS_EMIT_DISTANCE_FEET", false),
+		skipEmptyTimestamps: envBool("PROMETHEUS_SKIP_EMPTY_TIMESTAMPS", false),
+		emitEmptySlots:      envBool("PROMETHEUS_EMIT_EMPTY_SLOTS", false),
+		emitStatusCount:     envBool("PROMETHEUS_EMIT_STATUS_COUNT", false),
+		emitProcessedOrder:  envBool("PROMETHEUS_EMIT_PROCESSED_ORDER", false),
+		emitFetchDuration:   envBool("PROMETHEUS_EMIT_DETAIL_FETCH_DURATION", false),
+		ponDetails:          envBool("PROMETHEUS_PON_DETAILS", true),
+		maintenance: parseMaintenanceScope(
+			os.Getenv("PROMETHEUS_MAINTENANCE_PONS"),
+			os.Getenv("PROMETHEUS_MAINTENANCE_SERIALS"),
+		),
+		statusValues:          parseStatusValues(os.Getenv("PROMETHEUS_STATUS_VALUES")),
+		powerRange:            parsePowerRange(envFloat("POWER_MIN_DBM", -50), envFloat("POWER_MAX_DBM", 20)),
+		invalidPower:          newInvalidPowerCounter(),
+		descriptions:          loadDescriptionFile(os.Getenv("PROMETHEUS_DESCRIPTION_FILE")),
+		mappingInfoLimit:      envInt("PROMETHEUS_MAPPING_INFO_LIMIT", 0),
+		workers:               workers,
+		concurrency:           concurrency,
+		jobsBuffer:            jobsBuffer,
+		discoveryConcurrency:  discoveryConcurrency,
+		retryEmptyScrape:      envBool("PROMETHEUS_RETRY_EMPTY_SCRAPE", false),
+		retryEmptyScrapeDelay: envDuration("PROMETHEUS_RETRY_EMPTY_SCRAPE_DELAY", 2*time.Second),
+		prioritizeFlapping:    envBool("PROMETHEUS_PRIORITIZE_FLAPPING", false),
+		flaps:                 newFlapTracker(envDuration("PROMETHEUS_FLAP_WINDOW", 15*time.Minute)),
+		sampler:               newDetailSampler(envFloat("PROMETHEUS_DETAIL_SAMPLE_FRACTION", 1)),
+		descs:                 newMetricDescs(vendorPrefixFromEnv(), dedup),
+		internalDescs:         newInternalMetricDescs(vendorPrefixFromEnv()),
+		separateInternal:      envBool("PROMETHEUS_SEPARATE_INTERNAL_METRICS", false),
+		breaker: newPonCircuitBreaker(
+			envInt("PROMETHEUS_PON_FAILURE_THRESHOLD", 0),
+			envDuration("PROMETHEUS_PON_OPEN_DURATION", 5*time.Minute),
+		),
+		ponErrors:  newPonErrorCounter(),
+		throughput: newCounterRateTracker(),
+		labels:     newLabelCache(),
+		snapshot:   &scrapeSnapshot{},
+		cache:      newScrapeCache(envDuration("PROMETHEUS_CACHE_TTL", 0)),
+		smoke:      parseSmokeSelector(os.Getenv("PROMETHEUS_SMOKE_PON"), ponKeys(scanRange)),
+	}
+}
+
+// ScanRangeFromEnv returns the scan range configured by the environment, or the default one.
+func ScanRangeFromEnv() model.ScanRange {
 	// Get scan range from environment variables or use defaults.
 	boardMin, _ := strconv.Atoi(os.Getenv("PROMETHEUS_BOARD_MIN"))
 	boardMax, _ := strconv.Atoi(os.Getenv("PROMETHEUS_BOARD_MAX"))
@@ -45,141 +177,920 @@ func NewOnuCollector(onuUsecase usecase.OnuUseCaseInterface) *OnuCollector {
 		ponMax = 16
 	}
 
-	return &OnuCollector{
-		onuUsecase: onuUsecase,
-		boardMin:   boardMin,
-		boardMax:   boardMax,
-		ponMin:     ponMin,
-		ponMax:     ponMax,
+	return model.ScanRange{BoardMin: boardMin, BoardMax: boardMax, PonMin: ponMin, PonMax: ponMax}
+}
+
+// LastScrape returns the detailed ONU information gathered by the last completed scrape and
+// when that scrape started. scrapedAt is the zero time if Prometheus has not scraped yet.
+func (c *OnuCollector) LastScrape() (onus []model.ONUCustomerInfo, scrapedAt time.Time) {
+	return c.snapshot.load()
+}
+
+// publisher is a destination of the ONU details of every scrape.
+type publisher struct {
+	usecase    usecase.PublishUseCaseInterface
+	publishing atomic.Bool // Whether the ONUs of a previous scrape are still being published
+}
+
+// AddPublisher makes every completed scrape publish its ONU details to publisher as well, in the
+// background so a slow publisher never holds the scrape up, nor the other publishers.
+func (c *OnuCollector) AddPublisher(publishUsecase usecase.PublishUseCaseInterface) {
+	c.publishers = append(c.publishers, &publisher{usecase: publishUsecase})
+}
+
+// publish hands the ONUs of a scrape to every publisher in the background. A scrape completing
+// while a publisher is still publishing the previous one is skipped by it rather than queued.
+func (c *OnuCollector) publish(onus []model.ONUCustomerInfo) {
+	for _, p := range c.publishers {
+		if !p.publishing.CompareAndSwap(false, true) {
+			log.Warn().Msg("Previous scrape is still being published, not publishing this one")
+			continue
+		}
+		go func() {
+			defer p.publishing.Store(false)
+			if err := p.usecase.PublishOnus(context.Background(), onus); err != nil {
+				log.Error().Err(err).Int("onus", len(onus)).Msg("Failed to publish the ONU details")
+			}
+		}()
+	}
+}
+
+// SerialCollector returns a collector of the per-ONU metrics of the ONU with the given serial
+// number, fetching its details from the OLT like a scrape does. ok is false when the last scrape
+// did not find that serial number.
+func (c *OnuCollector) SerialCollector(serialNumber string) (collector prometheus.Collector, ok bool) {
+	onus, _ := c.snapshot.load()
+	for _, onu := range onus {
+		if onu.SerialNumber == serialNumber {
+			return &serialCollector{parent: c, onu: model.ONUInfoPerBoard{
+				Board:        onu.Board,
+				PON:          onu.PON,
+				ID:           onu.ID,
+				Name:         onu.Name,
+				OnuType:      onu.OnuType,
+				SerialNumber: onu.SerialNumber,
+				RXPower:      onu.RXPower,
+				Status:       onu.Status,
+				MACAddress:   onu.MACAddress,
+			}}, true
+		}
 	}
+	return nil, false
+}
+
+// serialCollector collects the metrics of a single ONU. It describes no metrics, so it is an
+// unchecked collector that can be registered next to the OnuCollector it belongs to.
+type serialCollector struct {
+	parent *OnuCollector
+	onu    model.ONUInfoPerBoard
+}
+
+// Describe sends nothing, the metrics of the ONU are described by the parent collector.
+func (s *serialCollector) Describe(_ chan<- *prometheus.Desc) {}
+
+// Collect fetches the details of the ONU and sends its metrics.
+func (s *serialCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), scrapeTimeout)
+	defer cancel()
+	s.parent.collectOnu(ctx, ch, s.onu)
+}
+
+// ScanRange returns the boards and PONs scanned by the collector.
+func (c *OnuCollector) ScanRange() model.ScanRange {
+	return model.ScanRange{BoardMin: c.boardMin, BoardMax: c.boardMax, PonMin: c.ponMin, PonMax: c.ponMax}
 }
 
 // Describe sends the static descriptions of all metrics collected by the exporter.
 func (c *OnuCollector) Describe(ch chan<- *prometheus.Desc) {
-	ch <- OnuStatusGaugeDesc
-	ch <- OnuMappingInfoGaugeDesc
-	ch <- OnuRxPowerGaugeDesc
-	ch <- OnuTxPowerGaugeDesc
-	ch <- OnuUptimeGaugeDesc
-	ch <- OnuLastDownDurationGaugeDesc
-	ch <- OnuLastOnlineGaugeDesc
-	ch <- OnuLastOfflineGaugeDesc
-	ch <- OnuGponOpticalDistanceGaugeDesc
-}
-
-// Collect fetches the metrics from the OLT and delivers them to Prometheus.
+	c.descs.describe(ch)
+	if !c.separateInternal {
+		c.internalDescs.describe(ch)
+	}
+}
+
+// scrapeTimeout bounds the SNMP requests of a scrape, requests still in flight are then aborted.
+const scrapeTimeout = 30 * time.Second
+
+// Collect delivers the metrics of the OLT to Prometheus, fetching them from the OLT unless the
+// metrics of a recent scrape are cached. The exporter's own metrics are never cached.
 func (c *OnuCollector) Collect(ch chan<- prometheus.Metric) {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second) // Scrape timeout
+	c.cache.collect(ch, c.collect)
+
+	// Report the exporter's own health, unless it is served on its own registry.
+	if !c.separateInternal {
+		c.collectInternal(ch)
+	}
+}
+
+// CacheTTL returns how long the metrics of a scrape are served again, 0 when they are not cached.
+// The OLT collector caches its metrics for as long.
+func (c *OnuCollector) CacheTTL() time.Duration {
+	return c.cache.ttl
+}
+
+// collect fetches the metrics from the OLT and sends them to ch.
+func (c *OnuCollector) collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), scrapeTimeout)
 	defer cancel()
 
 	log.Info().Msg("Starting metric collection for Prometheus scrape")
 	startTime := time.Now()
 
-	// 1. Discover all ONUs from all configured boards and PONs.
-	var allDiscoveredOnus []model.ONUInfoPerBoard
-	for boardID := c.boardMin; boardID <= c.boardMax; boardID++ {
-		for ponID := c.ponMin; ponID <= c.ponMax; ponID++ {
-			discoveredOnus, err := c.onuUsecase.GetByBoardIDAndPonID(ctx, boardID, ponID)
-			if err != nil {
-				log.Warn().Err(err).Int("board", boardID).Int("pon", ponID).Msg("Failed to discover ONUs")
-				continue // Move to the next PON if discovery fails.
-			}
-			allDiscoveredOnus = append(allDiscoveredOnus, discoveredOnus...)
+	round := c.sampler.nextRound()
+
+	// 1. Discover all ONUs from all configured boards and PONs, or from a single one in smoke mode.
+	keys := c.smoke.pick(ponKeys(c.ScanRange()))
+	allDiscoveredOnus, ponOutcomes := c.discover(ctx, keys)
+
+	// A scrape finding nothing right after one that found ONUs is most likely a busy OLT rather
+	// than every ONU going away, so give it a second chance before reporting everything down. A
+	// smoke scrape of another PON than the previous one has nothing to compare with.
+	if c.retryEmptyScrape && !c.smoke.varies() && len(allDiscoveredOnus) == 0 && c.lastDiscovered.Load() > 0 {
+		log.Warn().Dur("delay", c.retryEmptyScrapeDelay).Msg("Discovery found no ONUs, retrying the scrape once")
+		select {
+		case <-time.After(c.retryEmptyScrapeDelay):
+			allDiscoveredOnus, ponOutcomes = c.discover(ctx, keys)
+		case <-ctx.Done():
 		}
 	}
+	c.lastDiscovered.Store(int64(len(allDiscoveredOnus)))
+
+	// Report the OLT side of every PON port, whether its ONUs could be discovered or not.
+	for _, key := range keys {
+		c.collectPonPort(ctx, ch, key)
+	}
 
-	// 2. Filter out duplicate serial numbers.
-	// If duplicates are found, prioritize the one that does not have an "Other/Unknown" status.
-	uniqueOnus := make(map[string]model.ONUInfoPerBoard)
-	for _, onu := range allDiscoveredOnus {
-		if onu.SerialNumber == "" {
-			continue // Cannot process ONUs without a serial number.
+	for _, outcome := range ponOutcomes {
+		c.sendPonUp(ch, outcome.key, outcome.up)
+		if !outcome.up {
+			continue
+		}
+		c.sendOnuSlots(ch, outcome)
+		if c.emitEmptySlots {
+			c.collectEmptySlots(ctx, ch, outcome.key)
 		}
+	}
 
-		existingOnu, exists := uniqueOnus[onu.SerialNumber]
-		if !exists || (mapStatusToNumeric(existingOnu.Status) == 0 && mapStatusToNumeric(onu.Status) != 0) {
-			uniqueOnus[onu.SerialNumber] = onu
+	// 2. Filter out duplicate ONUs by the configured identity (serial number by default).
+	uniqueOnus, onuLocations := c.dedupOnus(allDiscoveredOnus)
+	log.Debug().Int("discovered", len(allDiscoveredOnus)).Int("unique", len(uniqueOnus)).Str("dedup_key", string(c.dedupKey)).Msg("Filtered ONUs by identity")
+	if c.emitStatusCount {
+		for _, count := range countStatuses(uniqueOnus) {
+			ch <- prometheus.MustNewConstMetric(c.descs.onuStatusCount, prometheus.GaugeValue, float64(count.onus), count.status)
 		}
 	}
-	log.Debug().Int("discovered", len(allDiscoveredOnus)).Int("unique", len(uniqueOnus)).Msg("Filtered ONUs by serial number")
 
-	totalOnusProcessed := 0
-	// 3. Fetch detailed information for each unique ONU and create metrics.
-	for _, discoveredOnu := range uniqueOnus {
-		boardID := discoveredOnu.Board
-		ponID := discoveredOnu.PON
-		onuID := discoveredOnu.ID
-		detailedOnu, err := c.onuUsecase.GetByBoardIDPonIDAndOnuID(boardID, ponID, onuID)
-		if err != nil {
-			log.Warn().Err(err).Int("board", boardID).Int("pon", ponID).Int("onu_id", onuID).Msg("Failed to get detailed ONU info")
-			continue // Move to the next ONU.
+	// A scan of the wrong subtree can discover thousands of junk ONUs. Rather than flooding Prometheus
+	// with their mapping info, skip it for the whole scrape and report the guard as tripped.
+	series := c.mappingInfoSeries(uniqueOnus, onuLocations)
+	c.guardTripped.Store(c.mappingInfoLimit > 0 && series > c.mappingInfoLimit)
+	if c.guardTripped.Load() {
+		log.Error().Int("series", series).Int("limit", c.mappingInfoLimit).Msg("Too many mapping info series, skipping zte_onu_mapping_info for this scrape")
+	}
+
+	// 3. Fetch detailed information for each unique ONU and create metrics, using a pool of workers.
+	// The details of every PON are first fetched in one pass, unless detail sampling only reads a
+	// few ONUs per scrape; the ONUs those passes miss are then fetched on their own by the workers.
+	// The jobs buffer lets the ONUs be queued ahead of the workers; once it is full, queueing blocks
+	// until a worker is free, which only bounds memory. Queueing stops once the scrape timeout is
+	// reached, the SNMP requests of the remaining ONUs would be aborted anyway. With flap
+	// prioritization, recently flapping ONUs are queued first, so the time goes to the ONUs that
+	// matter most.
+	var (
+		mu          sync.Mutex
+		wg          sync.WaitGroup
+		scrapedOnus = make([]model.ONUCustomerInfo, 0, len(uniqueOnus))
+		processed   atomic.Int64
+	)
+	var prefetched map[ponKey]ponDetails
+	if c.ponDetails && c.sampler.rounds <= 1 {
+		prefetched = c.prefetchDetails(ctx, uniqueOnus)
+	}
+	jobs := make(chan model.ONUInfoPerBoard, c.jobsBuffer)
+	for i := 0; i < c.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for discoveredOnu := range jobs {
+				identity := c.dedupKey.identity(discoveredOnu)
+				detailedOnu, ok := c.sampler.details(identity, discoveredOnu, round, startTime)
+				if ok {
+					c.sendOnu(ch, discoveredOnu, detailedOnu)
+				} else {
+					if detailedOnu, ok = c.collectPrefetchedOnu(ch, discoveredOnu, prefetched); !ok {
+						generation := c.concurrency.acquire()
+						fetchStart := time.Now()
+						detailedOnu, ok = c.collectOnu(ctx, ch, discoveredOnu)
+						c.concurrency.release(generation, time.Since(fetchStart), !ok)
+						if !ok {
+							continue // Move to the next ONU.
+						}
+					}
+					c.sampler.store(identity, detailedOnu, startTime)
+				}
+				if c.emitProcessedOrder {
+					position := processed.Add(1)
+					ch <- prometheus.MustNewConstMetric(c.descs.exporterOnuProcessedOrder, prometheus.GaugeValue, float64(position), c.dedupKey.identity(discoveredOnu))
+				}
+				mu.Lock()
+				scrapedOnus = append(scrapedOnus, detailedOnu)
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, discoveredOnu := range c.queueOrder(uniqueOnus, startTime) {
+		if ctx.Err() == nil {
+			select {
+			case jobs <- discoveredOnu:
+				continue
+			case <-ctx.Done():
+			}
+		}
+		log.Warn().Msg("Scrape timeout reached, skipping the details of the remaining ONUs")
+		break
+	}
+	close(jobs)
+	wg.Wait()
+	totalOnusProcessed := len(scrapedOnus)
+
+	// 4. Report identities discovered on more than one board/PON (e.g. during a migration).
+	if c.trackMultiLocation {
+		c.collectMultiLocation(ctx, ch, uniqueOnus, onuLocations)
+	}
+
+	// Report the power readings out of range so far, which are not reported as power.
+	c.invalidPower.each(func(key invalidPowerKey, count int) {
+		ch <- prometheus.MustNewConstMetric(c.descs.onuPowerReadingInvalid, prometheus.CounterValue, float64(count), key.identity, key.direction)
+	})
+
+	// Forget traffic counters and labels of ONUs that have not been seen for a while.
+	c.throughput.prune(startTime.Add(-time.Hour))
+	c.invalidPower.prune(startTime.Add(-time.Hour))
+	c.labels.prune(startTime.Add(-time.Hour))
+	c.flaps.prune(startTime.Add(-time.Hour))
+	c.sampler.prune(startTime.Add(-time.Hour))
+
+	// Workers finish in any order; the API serves the ONUs by location, like the scrape queues them.
+	slices.SortFunc(scrapedOnus, func(a, b model.ONUCustomerInfo) int {
+		return cmp.Or(cmp.Compare(a.Board, b.Board), cmp.Compare(a.PON, b.PON), cmp.Compare(a.ID, b.ID))
+	})
+	c.snapshot.store(scrapedOnus, startTime)
+	c.publish(scrapedOnus)
+
+	duration := time.Since(startTime)
+	c.lastScrapeDuration.Store(int64(duration))
+	c.lastScrapeOnus.Store(int64(totalOnusProcessed))
+	// A scrape missing some PONs is still a success, the failed PONs are reported by zte_pon_up and
+	// zte_exporter_pon_scrape_errors_total. It fails when no PON answered or the scrape timed out.
+	c.lastScrapeSuccess.Store(ctx.Err() == nil && slices.ContainsFunc(ponOutcomes, func(outcome ponOutcome) bool { return outcome.up }))
+
+	log.Info().Int("processed_onus", totalOnusProcessed).Str("duration", duration.String()).Msg("Finished metric collection for scrape")
+}
+
+// collectOnu fetches the detailed information of a single ONU and sends its metrics. ok is false
+// when the details could not be fetched and nothing but the fetch duration, when enabled, was sent.
+func (c *OnuCollector) collectOnu(ctx context.Context, ch chan<- prometheus.Metric, discoveredOnu model.ONUInfoPerBoard) (model.ONUCustomerInfo, bool) {
+	boardID := discoveredOnu.Board
+	ponID := discoveredOnu.PON
+	onuID := discoveredOnu.ID
+	fetchStart := time.Now()
+	detailedOnu, err := c.onuUsecase.GetByBoardIDPonIDAndOnuID(ctx, boardID, ponID, onuID)
+	if c.emitFetchDuration {
+		// Failed fetches are reported too, an ONU timing out is the slowest of all
+		ch <- prometheus.MustNewConstMetric(c.descs.onuDetailFetchDuration, prometheus.GaugeValue, time.Since(fetchStart).Seconds(), c.dedupKey.identity(discoveredOnu))
+	}
+	if err != nil {
+		log.Warn().Err(err).Int("board", boardID).Int("pon", ponID).Int("onu_id", onuID).Msg("Failed to get detailed ONU info")
+		return model.ONUCustomerInfo{}, false
+	}
+
+	c.sendOnu(ch, discoveredOnu, detailedOnu)
+	return detailedOnu, true
+}
+
+// ponDetails are the details of the ONUs of a PON fetched in one pass, and how long it took.
+type ponDetails struct {
+	onus     map[int]model.ONUCustomerInfo // keyed by ONU ID
+	duration time.Duration
+}
+
+// prefetchDetails fetches the details of the PONs of the given ONUs in one pass per PON, up to
+// discoveryConcurrency PONs at the same time. PONs whose pass failed are left out.
+func (c *OnuCollector) prefetchDetails(ctx context.Context, onus map[string]model.ONUInfoPerBoard) map[ponKey]ponDetails {
+	var keys []ponKey
+	for _, onu := range onus {
+		if key := (ponKey{board: onu.Board, pon: onu.PON}); !slices.Contains(keys, key) {
+			keys = append(keys, key)
 		}
+	}
+	slices.SortFunc(keys, func(a, b ponKey) int {
+		return cmp.Or(cmp.Compare(a.board, b.board), cmp.Compare(a.pon, b.pon))
+	})
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		detailed = make(map[ponKey]ponDetails, len(keys))
+	)
+	pons := make(chan ponKey)
+	for range min(c.discoveryConcurrency, len(keys)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for key := range pons {
+				fetchStart := time.Now()
+				detailedOnus, err := c.onuUsecase.GetAllDetailsByBoardPon(ctx, key.board, key.pon)
+				if err != nil {
+					log.Warn().Err(err).Int("board", key.board).Int("pon", key.pon).Msg("Failed to get detailed ONU info of the PON, fetching its ONUs one by one")
+					continue
+				}
+				byID := make(map[int]model.ONUCustomerInfo, len(detailedOnus))
+				for _, detailedOnu := range detailedOnus {
+					byID[detailedOnu.ID] = detailedOnu
+				}
+				mu.Lock()
+				detailed[key] = ponDetails{onus: byID, duration: time.Since(fetchStart)}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, key := range keys {
+		pons <- key
+	}
+	close(pons)
+	wg.Wait()
 
-		totalOnusProcessed++
+	return detailed
+}
 
-		// --- Create and send Prometheus Metrics ---
+// collectPrefetchedOnu sends the metrics of an ONU from the details of its PON fetched in one pass,
+// reporting the duration of that pass as its fetch duration. ok is false when the pass did not
+// return the ONU, which must then be fetched on its own.
+func (c *OnuCollector) collectPrefetchedOnu(ch chan<- prometheus.Metric, discoveredOnu model.ONUInfoPerBoard, prefetched map[ponKey]ponDetails) (model.ONUCustomerInfo, bool) {
+	details, ok := prefetched[ponKey{board: discoveredOnu.Board, pon: discoveredOnu.PON}]
+	if !ok {
+		return model.ONUCustomerInfo{}, false
+	}
+	detailedOnu, ok := details.onus[discoveredOnu.ID]
+	if !ok {
+		return model.ONUCustomerInfo{}, false
+	}
 
-		// Set ONU Mapping Info
-		ch <- prometheus.MustNewConstMetric(
-			OnuMappingInfoGaugeDesc,
-			prometheus.GaugeValue,
-			1,
-			strconv.Itoa(detailedOnu.Board),
-			strconv.Itoa(detailedOnu.PON),
-			strconv.Itoa(detailedOnu.ID),
-			detailedOnu.Name,
-			detailedOnu.SerialNumber,
-			detailedOnu.OnuType,
-			detailedOnu.Description,
-			detailedOnu.LastOfflineReason,
-			detailedOnu.IPAddress,
-		)
+	if c.emitFetchDuration {
+		ch <- prometheus.MustNewConstMetric(c.descs.onuDetailFetchDuration, prometheus.GaugeValue, details.duration.Seconds(), c.dedupKey.identity(discoveredOnu))
+	}
+	c.sendOnu(ch, discoveredOnu, detailedOnu)
+	return detailedOnu, true
+}
+
+// sendOnu sends the metrics of a single ONU from its detailed information.
+func (c *OnuCollector) sendOnu(ch chan<- prometheus.Metric, discoveredOnu model.ONUInfoPerBoard, detailedOnu model.ONUCustomerInfo) {
+	// --- Create and send Prometheus Metrics ---
+
+	// Per-ONU metrics are anchored to the configured identity and tagged when the ONU is under
+	// planned maintenance.
+	identity := c.dedupKey.identity(discoveredOnu)
+	maintenance := c.maintenance.label(detailedOnu.Board, detailedOnu.PON, detailedOnu.SerialNumber)
 
-		// Set ONU Status
+	// Set ONU Mapping Info
+	c.sendMappingInfo(ch, detailedOnu)
+
+	// Set ONU Status, unless the OLT did not report it
+	if detailedOnu.Status != "" {
 		ch <- prometheus.MustNewConstMetric(
-			OnuStatusGaugeDesc,
+			c.descs.onuStatus,
 			prometheus.GaugeValue,
-			mapStatusToNumeric(detailedOnu.Status),
-			detailedOnu.SerialNumber,
+			c.statusValues.value(detailedOnu.Status),
+			identity,
+			maintenance,
 		)
+	}
 
-		// Set power metrics only if the device is Online.
-		if detailedOnu.Status == "Online" {
-			if rxPower, err := strconv.ParseFloat(detailedOnu.RXPower, 64); err == nil {
-				if rxPower < 100 { // Filter out invalid readings
-					ch <- prometheus.MustNewConstMetric(OnuRxPowerGaugeDesc, prometheus.GaugeValue, rxPower, detailedOnu.SerialNumber)
-					log.Debug().Str("serial_number", detailedOnu.SerialNumber).Float64("rx_power", rxPower).Msg("Successfully parsed and set RxPower")
-				}
+	// Set power metrics only if the device is Online.
+	if detailedOnu.Status == "Online" {
+		if rxPower, err := strconv.ParseFloat(detailedOnu.RXPower, 64); err == nil {
+			if c.powerRange.contains(rxPower) {
+				ch <- prometheus.MustNewConstMetric(c.descs.onuRxPower, prometheus.GaugeValue, rxPower, identity, maintenance)
+				log.Debug().Str("serial_number", detailedOnu.SerialNumber).Float64("rx_power", rxPower).Msg("Successfully parsed and set RxPower")
 			} else {
-				log.Warn().Err(err).Str("serial_number", detailedOnu.SerialNumber).Str("rx_power_str", detailedOnu.RXPower).Msg("Could not parse RxPower")
+				c.invalidPower.inc(identity, powerDirectionRx, time.Now())
 			}
+		} else if detailedOnu.RXPower != "" { // An absent reading is not a parse failure
+			log.Warn().Err(err).Str("serial_number", detailedOnu.SerialNumber).Str("rx_power_str", detailedOnu.RXPower).Msg("Could not parse RxPower")
+		}
 
-			if txPower, err := strconv.ParseFloat(detailedOnu.TXPower, 64); err == nil {
-				if txPower < 100 { // Filter out invalid readings
-					ch <- prometheus.MustNewConstMetric(OnuTxPowerGaugeDesc, prometheus.GaugeValue, txPower, detailedOnu.SerialNumber)
-				}
+		if txPower, err := strconv.ParseFloat(detailedOnu.TXPower, 64); err == nil {
+			if c.powerRange.contains(txPower) {
+				ch <- prometheus.MustNewConstMetric(c.descs.onuTxPower, prometheus.GaugeValue, txPower, identity, maintenance)
 			} else {
-				log.Warn().Err(err).Str("serial_number", detailedOnu.SerialNumber).Str("tx_power_str", detailedOnu.TXPower).Msg("Could not parse TxPower")
+				c.invalidPower.inc(identity, powerDirectionTx, time.Now())
+			}
+		} else if detailedOnu.TXPower != "" {
+			log.Warn().Err(err).Str("serial_number", detailedOnu.SerialNumber).Str("tx_power_str", detailedOnu.TXPower).Msg("Could not parse TxPower")
+		}
+
+		// The signal quality index is only available when its OID is configured.
+		if quality, err := strconv.ParseFloat(detailedOnu.SignalQuality, 64); err == nil {
+			ch <- prometheus.MustNewConstMetric(c.descs.onuSignalQuality, prometheus.GaugeValue, quality, identity, maintenance)
+		}
+
+		// Transceiver diagnostics are only available when their OIDs are configured.
+		if temperature, err := strconv.ParseFloat(detailedOnu.Temperature, 64); err == nil {
+			ch <- prometheus.MustNewConstMetric(c.descs.onuTemperature, prometheus.GaugeValue, temperature, identity, maintenance)
+		}
+		if voltage, err := strconv.ParseFloat(detailedOnu.Voltage, 64); err == nil {
+			ch <- prometheus.MustNewConstMetric(c.descs.onuVoltage, prometheus.GaugeValue, voltage, identity, maintenance)
+		}
+		if bias, err := strconv.ParseFloat(detailedOnu.BiasCurrent, 64); err == nil {
+			ch <- prometheus.MustNewConstMetric(c.descs.onuBiasCurrent, prometheus.GaugeValue, bias, identity, maintenance)
+		}
+
+		// Joined multicast groups are only available when the multicast collection is enabled.
+		if groups, err := strconv.ParseFloat(detailedOnu.MulticastGroups, 64); err == nil {
+			ch <- prometheus.MustNewConstMetric(c.descs.onuMulticastGroups, prometheus.GaugeValue, groups, identity, maintenance)
+		}
+
+		// The RF output level is only available when the CATV collection is enabled.
+		if level, err := strconv.ParseFloat(detailedOnu.CatvLevel, 64); err == nil {
+			ch <- prometheus.MustNewConstMetric(c.descs.onuCatvLevel, prometheus.GaugeValue, level, identity, maintenance)
+		}
+
+		// Negotiated line rates are only available when their OIDs are configured.
+		if rate, err := strconv.ParseFloat(detailedOnu.NegotiatedDownstreamRate, 64); err == nil {
+			ch <- prometheus.MustNewConstMetric(c.descs.onuNegotiatedDownstreamRate, prometheus.GaugeValue, rate, identity, maintenance)
+		}
+		if rate, err := strconv.ParseFloat(detailedOnu.NegotiatedUpstreamRate, 64); err == nil {
+			ch <- prometheus.MustNewConstMetric(c.descs.onuNegotiatedUpstreamRate, prometheus.GaugeValue, rate, identity, maintenance)
+		}
+
+		// Throughput is only available when the traffic counter OIDs are configured.
+		c.sendThroughput(ch, c.descs.onuDownstreamThroughput, identity, maintenance, "downstream", detailedOnu.DownstreamOctets)
+		c.sendThroughput(ch, c.descs.onuUpstreamThroughput, identity, maintenance, "upstream", detailedOnu.UpstreamOctets)
+
+		// Dropped frames are only available when the discard counter OIDs are configured.
+		c.sendCounter(ch, c.descs.onuRxDropped, identity, maintenance, detailedOnu.RxDropped)
+		c.sendCounter(ch, c.descs.onuTxDropped, identity, maintenance, detailedOnu.TxDropped)
+	}
+
+	// Set other metrics
+	ch <- prometheus.MustNewConstMetric(c.descs.onuUptime, prometheus.GaugeValue, parseDurationStringToSeconds(detailedOnu.Uptime), identity, maintenance)
+	ch <- prometheus.MustNewConstMetric(c.descs.onuLastDownDuration, prometheus.GaugeValue, parseDurationStringToSeconds(detailedOnu.LastDownTimeDuration), identity, maintenance)
+	c.sendTimestamp(ch, c.descs.onuLastOnline, identity, maintenance, detailedOnu.LastOnline)
+	c.sendTimestamp(ch, c.descs.onuLastOffline, identity, maintenance, detailedOnu.LastOffline)
+	if detailedOnu.RegisteredAt != "" { // Only available when the registration time OID is configured
+		ch <- prometheus.MustNewConstMetric(c.descs.onuRegistered, prometheus.GaugeValue, parseTimestampStringToEpoch(detailedOnu.RegisteredAt), identity, maintenance)
+	}
+	if count, err := strconv.ParseFloat(detailedOnu.AlarmCount, 64); err == nil { // Only available when the alarm count OID is configured
+		ch <- prometheus.MustNewConstMetric(c.descs.onuAlarmCount, prometheus.GaugeValue, count, identity, maintenance)
+	}
+	if detected, err := strconv.ParseBool(detailedOnu.LoopDetected); err == nil { // Only available when the loop detection OID is configured
+		value := 0.0
+		if detected {
+			value = 1
+		}
+		ch <- prometheus.MustNewConstMetric(c.descs.onuLoopDetected, prometheus.GaugeValue, value, identity, maintenance)
+	}
+	if enabled, err := strconv.ParseBool(detailedOnu.EncryptionEnabled); err == nil { // Only available when the encryption OID is configured
+		value := 0.0
+		if enabled {
+			value = 1
+		}
+		ch <- prometheus.MustNewConstMetric(c.descs.onuEncryptionEnabled, prometheus.GaugeValue, value, identity, maintenance)
+	}
+	if detailedOnu.UpgradeState != "" { // Only available when the upgrade state OID is configured
+		ch <- prometheus.MustNewConstMetric(c.descs.onuUpgradeState, prometheus.GaugeValue, 1, identity, detailedOnu.UpgradeState, maintenance)
+	}
+	if detailedOnu.BatteryStatus != "" { // Only available when the battery status OID is configured
+		ch <- prometheus.MustNewConstMetric(c.descs.onuBatteryStatus, prometheus.GaugeValue, 1, identity, detailedOnu.BatteryStatus, maintenance)
+		if detailedOnu.BatteryStatus != "unknown" {
+			value := 0.0
+			if detailedOnu.BatteryStatus == "on_battery" || detailedOnu.BatteryStatus == "low" {
+				value = 1
 			}
+			ch <- prometheus.MustNewConstMetric(c.descs.onuOnBattery, prometheus.GaugeValue, value, identity, maintenance)
+		}
+	}
+	if code, err := strconv.ParseFloat(detailedOnu.RegistrationFailCode, 64); err == nil { // Only available when the registration failure OID is configured
+		ch <- prometheus.MustNewConstMetric(c.descs.onuRegistrationFailReason, prometheus.GaugeValue, code, identity, detailedOnu.RegistrationFailReason, maintenance)
+	}
+	if code, ok := utils.ExtractOfflineReasonCode(detailedOnu.LastOfflineReason); ok { // Only available when the offline reason OID is configured
+		ch <- prometheus.MustNewConstMetric(c.descs.onuLastOfflineReason, prometheus.GaugeValue, float64(code), identity, maintenance)
+	}
+	if detailedOnu.Tconts != nil { // Only available when the T-CONT collection is enabled
+		ch <- prometheus.MustNewConstMetric(c.descs.onuTcontCount, prometheus.GaugeValue, float64(len(detailedOnu.Tconts)), identity, maintenance)
+		for _, tcont := range detailedOnu.Tconts {
+			gemPorts := ""
+			if tcont.GemPorts > 0 {
+				gemPorts = strconv.Itoa(tcont.GemPorts)
+			}
+			ch <- prometheus.MustNewConstMetric(c.descs.onuTcontInfo, prometheus.GaugeValue, 1, identity, strconv.Itoa(tcont.Index), strconv.Itoa(tcont.AllocID), sanitizeLabelValue(tcont.Profile), gemPorts, maintenance)
+		}
+	}
+	for _, uniPort := range detailedOnu.UniPorts { // Only available when the UNI port collection is enabled
+		port := strconv.Itoa(uniPort.Port)
+		if uniPort.SpeedMbps > 0 {
+			ch <- prometheus.MustNewConstMetric(c.descs.onuUniSpeed, prometheus.GaugeValue, uniPort.SpeedMbps, identity, port, maintenance)
+		}
+		if uniPort.Duplex != "" {
+			value := 0.0
+			if uniPort.Duplex == "full" {
+				value = 1
+			}
+			ch <- prometheus.MustNewConstMetric(c.descs.onuUniDuplex, prometheus.GaugeValue, value, identity, port, maintenance)
+		}
+	}
+	if detailedOnu.QosProfile != "" { // Only available when the QoS profile collection is enabled
+		ch <- prometheus.MustNewConstMetric(c.descs.onuQosProfileInfo, prometheus.GaugeValue, 1, identity, sanitizeLabelValue(detailedOnu.QosProfile), maintenance)
+	}
+	if enabled, err := strconv.ParseBool(detailedOnu.MulticastEnabled); err == nil { // Only available when the multicast collection is enabled
+		value := 0.0
+		if enabled {
+			value = 1
+		}
+		ch <- prometheus.MustNewConstMetric(c.descs.onuMulticastEnabled, prometheus.GaugeValue, value, identity, maintenance)
+	}
+	if enabled, err := strconv.ParseBool(detailedOnu.CatvEnabled); err == nil { // Only available when the CATV collection is enabled
+		value := 0.0
+		if enabled {
+			value = 1
+		}
+		ch <- prometheus.MustNewConstMetric(c.descs.onuCatvStatus, prometheus.GaugeValue, value, identity, maintenance)
+	}
+	if distance, err := strconv.ParseFloat(detailedOnu.GponOpticalDistance, 64); err == nil {
+		ch <- prometheus.MustNewConstMetric(c.descs.onuGponOpticalDistance, prometheus.GaugeValue, distance, identity, maintenance)
+		if c.emitDistanceFeet && distance >= 0 { // A negative distance is not a measurement
+			ch <- prometheus.MustNewConstMetric(c.descs.onuGponOpticalDistanceFeet, prometheus.GaugeValue, metersToFeet(distance), identity, maintenance)
+		}
+	} else if detailedOnu.GponOpticalDistance != "" {
+		log.Warn().Err(err).Str("serial_number", detailedOnu.SerialNumber).Str("distance_str", detailedOnu.GponOpticalDistance).Msg("Could not parse GponOpticalDistance")
+	}
+}
+
+// ponOutcome records whether the discovery of a PON succeeded, and how many ONU IDs it found.
+type ponOutcome struct {
+	key  ponKey
+	up   bool
+	used int
+}
+
+// discover walks the given PONs and returns the discovered ONUs together with the outcome of each
+// PON, both in the order of keys. Up to discoveryConcurrency PONs are walked at the same time.
+// PONs with an open circuit are skipped and reported as down.
+func (c *OnuCollector) discover(ctx context.Context, keys []ponKey) ([]model.ONUInfoPerBoard, []ponOutcome) {
+	// Every PON gets its own slot, so the outcomes and ONUs keep the board/PON order whatever the
+	// order the walks complete in.
+	type ponResult struct {
+		outcome ponOutcome
+		onus    []model.ONUInfoPerBoard
+	}
+	results := make([]ponResult, len(keys))
+
+	var wg sync.WaitGroup
+	indexes := make(chan int)
+	for range min(c.discoveryConcurrency, len(keys)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				key := keys[i]
+				if !c.breaker.allow(key) {
+					log.Debug().Int("board", key.board).Int("pon", key.pon).Msg("Skipping PON with open circuit")
+					results[i] = ponResult{outcome: ponOutcome{key: key, up: false}}
+					continue
+				}
+
+				discoveredOnus, err := c.onuUsecase.GetByBoardIDAndPonID(ctx, key.board, key.pon)
+				if err != nil {
+					c.breaker.recordFailure(key)
+					c.ponErrors.inc(key)
+					results[i] = ponResult{outcome: ponOutcome{key: key, up: false}}
+					log.Warn().Err(err).Int("board", key.board).Int("pon", key.pon).Msg("Failed to discover ONUs")
+					continue // Move to the next PON if discovery fails.
+				}
+				c.breaker.recordSuccess(key)
+				results[i] = ponResult{outcome: ponOutcome{key: key, up: true, used: len(discoveredOnus)}, onus: discoveredOnus}
+			}
+		}()
+	}
+	for i := range keys {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	var allDiscoveredOnus []model.ONUInfoPerBoard
+	outcomes := make([]ponOutcome, 0, len(results))
+	for _, result := range results {
+		outcomes = append(outcomes, result.outcome)
+		allDiscoveredOnus = append(allDiscoveredOnus, result.onus...)
+	}
+	return allDiscoveredOnus, outcomes
+}
+
+// ponKeys returns the PONs of the scan range, by board and PON.
+func ponKeys(scanRange model.ScanRange) []ponKey {
+	var keys []ponKey
+	for boardID := scanRange.BoardMin; boardID <= scanRange.BoardMax; boardID++ {
+		for ponID := scanRange.PonMin; ponID <= scanRange.PonMax; ponID++ {
+			keys = append(keys, ponKey{board: boardID, pon: ponID})
 		}
+	}
+	return keys
+}
 
-		// Set other metrics
-		ch <- prometheus.MustNewConstMetric(OnuUptimeGaugeDesc, prometheus.GaugeValue, parseDurationStringToSeconds(detailedOnu.Uptime), detailedOnu.SerialNumber)
-		ch <- prometheus.MustNewConstMetric(OnuLastDownDurationGaugeDesc, prometheus.GaugeValue, parseDurationStringToSeconds(detailedOnu.LastDownTimeDuration), detailedOnu.SerialNumber)
-		ch <- prometheus.MustNewConstMetric(OnuLastOnlineGaugeDesc, prometheus.GaugeValue, parseTimestampStringToEpoch(detailedOnu.LastOnline), detailedOnu.SerialNumber)
-		ch <- prometheus.MustNewConstMetric(OnuLastOfflineGaugeDesc, prometheus.GaugeValue, parseTimestampStringToEpoch(detailedOnu.LastOffline), detailedOnu.SerialNumber)
-		if distance, err := strconv.ParseFloat(detailedOnu.GponOpticalDistance, 64); err == nil {
-			ch <- prometheus.MustNewConstMetric(OnuGponOpticalDistanceGaugeDesc, prometheus.GaugeValue, distance, detailedOnu.SerialNumber)
+// dedupOnus keys the discovered ONUs by the configured identity. If duplicates are found, the one
+// that does not have an "Other/Unknown" status is kept. Every location an identity was seen on is
+// remembered so migrations stay visible.
+func (c *OnuCollector) dedupOnus(discoveredOnus []model.ONUInfoPerBoard) (map[string]model.ONUInfoPerBoard, map[string][]model.ONUInfoPerBoard) {
+	// The maps are sized for the common case of unique identities, and the first location of every
+	// identity is a one-element window on discoveredOnus, so only duplicates allocate.
+	uniqueOnus := make(map[string]model.ONUInfoPerBoard, len(discoveredOnus))
+	onuLocations := make(map[string][]model.ONUInfoPerBoard, len(discoveredOnus))
+	for i, onu := range discoveredOnus {
+		identity := c.dedupKey.identity(onu)
+		if identity == "" {
+			continue // Cannot process ONUs without an identity.
+		}
+		if locations, seen := onuLocations[identity]; seen {
+			onuLocations[identity] = append(locations, onu)
 		} else {
-			log.Warn().Err(err).Str("serial_number", detailedOnu.SerialNumber).Str("distance_str", detailedOnu.GponOpticalDistance).Msg("Could not parse GponOpticalDistance")
+			onuLocations[identity] = discoveredOnus[i : i+1 : i+1]
+		}
+
+		existingOnu, exists := uniqueOnus[identity]
+		if !exists || (c.statusValues.value(existingOnu.Status) == 0 && c.statusValues.value(onu.Status) != 0) {
+			uniqueOnus[identity] = onu
 		}
 	}
-	duration := time.Since(startTime)
-	log.Info().Int("processed_onus", totalOnusProcessed).Str("duration", duration.String()).Msg("Finished metric collection for scrape")
+	return uniqueOnus, onuLocations
+}
+
+// mappingInfoSeries returns the number of zte_onu_mapping_info series a scrape emits: one per unique
+// ONU, plus one per additional location when multi-location tracking is enabled. Every series has
+// its own location labels, so this is also the number of unique label combinations.
+func (c *OnuCollector) mappingInfoSeries(uniqueOnus map[string]model.ONUInfoPerBoard, onuLocations map[string][]model.ONUInfoPerBoard) int {
+	series := len(uniqueOnus)
+	if c.trackMultiLocation {
+		for _, locations := range onuLocations {
+			series += len(locations) - 1
+		}
+	}
+	return series
+}
+
+// queueOrder returns the unique ONUs in the order their details are fetched: by board, PON and
+// ONU ID, so the output of a scrape is stable across runs. With flap prioritization, ONUs that
+// flapped recently come first, most recent flap first, so they get fresh data even when the
+// scrape times out before reaching every ONU.
+func (c *OnuCollector) queueOrder(uniqueOnus map[string]model.ONUInfoPerBoard, at time.Time) []model.ONUInfoPerBoard {
+	queue := make([]model.ONUInfoPerBoard, 0, len(uniqueOnus))
+	for _, onu := range uniqueOnus {
+		queue = append(queue, onu)
+	}
+	slices.SortFunc(queue, func(a, b model.ONUInfoPerBoard) int {
+		return cmp.Or(cmp.Compare(a.Board, b.Board), cmp.Compare(a.PON, b.PON), cmp.Compare(a.ID, b.ID))
+	})
+	if !c.prioritizeFlapping {
+		return queue
+	}
+
+	lastFlaps := make(map[string]time.Time, len(queue))
+	for _, onu := range queue {
+		if lastFlap, ok := c.flaps.observe(c.dedupKey.identity(onu), onu.Status, at); ok {
+			lastFlaps[c.dedupKey.identity(onu)] = lastFlap
+		}
+	}
+	// Stable ONUs have a zero last flap and keep their location order after the flapping ones.
+	sort.SliceStable(queue, func(i, j int) bool {
+		return lastFlaps[c.dedupKey.identity(queue[i])].After(lastFlaps[c.dedupKey.identity(queue[j])])
+	})
+	return queue
+}
+
+// collectMultiLocation emits zte_onu_multi_location for every identity seen on more than one
+// location and a mapping-info series for each extra location. Per-ONU metrics are only
+// emitted once, from the location kept by the dedup, to avoid duplicate series.
+func (c *OnuCollector) collectMultiLocation(ctx context.Context, ch chan<- prometheus.Metric, uniqueOnus map[string]model.ONUInfoPerBoard, onuLocations map[string][]model.ONUInfoPerBoard) {
+	// Identities are sorted so the output of a scrape is stable across runs.
+	for _, identity := range slices.Sorted(maps.Keys(onuLocations)) {
+		locations := onuLocations[identity]
+		if len(locations) < 2 {
+			continue
+		}
+
+		log.Warn().Str(c.dedupKey.labelName(), identity).Int("locations", len(locations)).Msg("ONU discovered on multiple locations")
+		primary := uniqueOnus[identity]
+		ch <- prometheus.MustNewConstMetric(c.descs.onuMultiLocation, prometheus.GaugeValue, 1, identity, c.maintenance.label(primary.Board, primary.PON, primary.SerialNumber))
+
+		for _, location := range locations {
+			if location.Board == primary.Board && location.PON == primary.PON && location.ID == primary.ID {
+				continue // Already reported by the main loop.
+			}
+			detailedOnu, err := c.onuUsecase.GetByBoardIDPonIDAndOnuID(ctx, location.Board, location.PON, location.ID)
+			if err != nil {
+				log.Warn().Err(err).Int("board", location.Board).Int("pon", location.PON).Int("onu_id", location.ID).Msg("Failed to get detailed ONU info")
+				continue
+			}
+			c.sendMappingInfo(ch, detailedOnu)
+		}
+	}
+}
+
+// collectEmptySlots emits zte_onu_status with the empty slot status for every free ONU ID of a PON,
+// so free slots show up next to the active ONUs.
+func (c *OnuCollector) collectEmptySlots(ctx context.Context, ch chan<- prometheus.Metric, key ponKey) {
+	emptyOnuIDs, err := c.onuUsecase.GetEmptyOnuID(ctx, key.board, key.pon)
+	if err != nil {
+		log.Warn().Err(err).Int("board", key.board).Int("pon", key.pon).Msg("Failed to get empty ONU IDs")
+		return
+	}
+
+	maintenance := c.maintenance.label(key.board, key.pon, "")
+	for _, slot := range emptyOnuIDs {
+		identity := c.dedupKey.emptySlotIdentity(slot.Board, slot.PON, slot.ID)
+		ch <- prometheus.MustNewConstMetric(c.descs.onuStatus, prometheus.GaugeValue, emptySlotStatus, identity, maintenance)
+	}
+}
+
+// sendPonUp emits zte_pon_up for a PON, unless disabled.
+func (c *OnuCollector) sendPonUp(ch chan<- prometheus.Metric, key ponKey, up bool) {
+	if !c.emitPonUp {
+		return
+	}
+
+	value := 0.0
+	if up {
+		value = 1
+	}
+	ch <- prometheus.MustNewConstMetric(c.descs.ponUp, prometheus.GaugeValue, value, strconv.Itoa(key.board), strconv.Itoa(key.pon))
+}
+
+// collectPonPort emits the transmit power and the operational status of a PON port, each only when
+// its OID is configured and the OLT reports it.
+func (c *OnuCollector) collectPonPort(ctx context.Context, ch chan<- prometheus.Metric, key ponKey) {
+	ponPort, err := c.ponUsecase.GetPonPort(ctx, key.board, key.pon)
+	if err != nil {
+		log.Warn().Err(err).Int("board", key.board).Int("pon", key.pon).Msg("Failed to get PON port")
+		return
+	}
+
+	board, pon := strconv.Itoa(key.board), strconv.Itoa(key.pon)
+	if ponPort.TxPower != "" {
+		txPower, err := strconv.ParseFloat(ponPort.TxPower, 64)
+		if err != nil {
+			log.Warn().Err(err).Int("board", key.board).Int("pon", key.pon).Str("tx_power_str", ponPort.TxPower).Msg("Could not parse PON port tx power")
+		} else {
+			ch <- prometheus.MustNewConstMetric(c.descs.ponPortTxPower, prometheus.GaugeValue, txPower, board, pon)
+		}
+	}
+	if ponPort.Status != 0 {
+		ch <- prometheus.MustNewConstMetric(c.descs.ponPortStatus, prometheus.GaugeValue, statusToNumeric(ponPort.Status), board, pon)
+	}
+}
+
+// sendOnuSlots emits the number of used and empty ONU IDs of a discovered PON, out of the ONU IDs
+// it is provisioned for.
+func (c *OnuCollector) sendOnuSlots(ch chan<- prometheus.Metric, outcome ponOutcome) {
+	board, pon := strconv.Itoa(outcome.key.board), strconv.Itoa(outcome.key.pon)
+	empty := max(c.onuUsecase.MaxOnuPerPon()-outcome.used, 0)
+	ch <- prometheus.MustNewConstMetric(c.descs.ponUsedOnuSlots, prometheus.GaugeValue, float64(outcome.used), board, pon)
+	ch <- prometheus.MustNewConstMetric(c.descs.ponEmptyOnuSlots, prometheus.GaugeValue, float64(empty), board, pon)
+}
+
+// sendThroughput derives the throughput of one direction from the raw octet counter and the
+// sample of the previous scrape. Nothing is emitted for the first scrape or after a counter reset.
+func (c *OnuCollector) sendThroughput(ch chan<- prometheus.Metric, desc *prometheus.Desc, identity, maintenance, direction, octets string) {
+	if octets == "" {
+		return
+	}
+
+	value, err := strconv.ParseUint(octets, 10, 64)
+	if err != nil {
+		log.Warn().Err(err).Str(c.dedupKey.labelName(), identity).Str("octets_str", octets).Msg("Could not parse traffic counter")
+		return
+	}
+
+	if perSecond, ok := c.throughput.rate(identity+"/"+direction, value, time.Now()); ok {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, octetsRateToKbps(perSecond), identity, maintenance)
+	}
+}
+
+// sendCounter emits a raw SNMP counter as a Prometheus counter. A wrapped Counter32 or an ONU reboot
+// shows up as a counter reset, which rate() and increase() already handle.
+func (c *OnuCollector) sendCounter(ch chan<- prometheus.Metric, desc *prometheus.Desc, identity, maintenance, counter string) {
+	if counter == "" {
+		return
+	}
+
+	value, err := strconv.ParseUint(counter, 10, 64)
+	if err != nil {
+		log.Warn().Err(err).Str(c.dedupKey.labelName(), identity).Str("counter_str", counter).Msg("Could not parse counter")
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, float64(value), identity, maintenance)
+}
+
+// sendTimestamp emits a timestamp of the ONU as a Unix epoch. A missing or unparsable timestamp, e.g.
+// of an ONU never online, is reported as 0 unless empty timestamps are skipped.
+func (c *OnuCollector) sendTimestamp(ch chan<- prometheus.Metric, desc *prometheus.Desc, identity, maintenance, timestamp string) {
+	epoch := parseTimestampStringToEpoch(timestamp)
+	if epoch == 0 && c.skipEmptyTimestamps {
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, epoch, identity, maintenance)
 }
 
 // --- Helper functions ---
 
+// sendMappingInfo emits the zte_onu_mapping_info series for the given ONU, unless the cardinality
+// guard tripped for this scrape. The description is the one of the OLT, or of the description file
+// when the OLT has none.
+func (c *OnuCollector) sendMappingInfo(ch chan<- prometheus.Metric, onu model.ONUCustomerInfo) {
+	if c.guardTripped.Load() {
+		return
+	}
+
+	description, descriptionSource := c.descriptions.resolve(onu)
+	labels := c.labels.get(c.dedupKey.mappingSerial(onu), mappingLabels{
+		name:            onu.Name,
+		onuType:         onu.OnuType,
+		description:     description,
+		offlineReason:   onu.LastOfflineReason,
+		equipmentID:     onu.EquipmentID,
+		template:        onu.ProfileTemplate,
+		transceiverType: onu.TransceiverType,
+	}, time.Now())
+
+	ch <- prometheus.MustNewConstMetric(
+		c.descs.onuMappingInfo,
+		prometheus.GaugeValue,
+		1,
+		strconv.Itoa(onu.Board),
+		strconv.Itoa(onu.PON),
+		strconv.Itoa(onu.ID),
+		labels.name,
+		c.dedupKey.mappingSerial(onu),
+		labels.onuType,
+		labels.description,
+		descriptionSource,
+		labels.offlineReason,
+		onu.IPAddress,
+		onu.IPGateway,
+		onu.IPMask,
+		onu.MACAddress,
+		labels.equipmentID,
+		labels.template,
+		labels.transceiverType,
+		c.maintenance.label(onu.Board, onu.PON, onu.SerialNumber),
+	)
+}
+
+// vendorPrefixFromEnv returns the configured metric vendor prefix. It may be explicitly set to an
+// empty string to drop the prefix entirely.
+func vendorPrefixFromEnv() string {
+	vendorPrefix, ok := os.LookupEnv("PROMETHEUS_METRIC_VENDOR_PREFIX")
+	if !ok {
+		return DefaultVendorPrefix
+	}
+	return vendorPrefix
+}
+
+// envInt reads an integer environment variable, falling back to def when unset or invalid.
+func envInt(key string, def int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return def
+	}
+	return value
+}
+
+// envDuration reads a duration environment variable (e.g. "30s"), falling back to def when unset or invalid.
+func envDuration(key string, def time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return def
+	}
+	return value
+}
+
+// envFloat reads a floating-point environment variable, falling back to def when unset or invalid.
+func envFloat(key string, def float64) float64 {
+	value, err := strconv.ParseFloat(os.Getenv(key), 64)
+	if err != nil {
+		return def
+	}
+	return value
+}
+
+// envBool reads a boolean environment variable, falling back to def when unset or invalid.
+func envBool(key string, def bool) bool {
+	value, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return def
+	}
+	return value
+}
+
 // parseDurationStringToSeconds converts a duration string like "X days Y hours Z minutes W seconds" to total seconds.
 func parseDurationStringToSeconds(durationStr string) float64 {
 	var totalSeconds int64
@@ -220,18 +1131,10 @@ func parseTimestampStringToEpoch(timestampStr string) float64 {
 	return float64(t.Unix())
 }
 
-// mapStatusToNumeric maps the ONU status string to a numeric value.
-func mapStatusToNumeric(status string) float64 {
-	switch status {
-	case "Online":
-		return 1
-	case "Dying Gasp":
-		return 2
-	case "LOS":
-		return 3
-	case "Power-Off":
-		return 4
-	default:
-		return 0
-	}
-}
\ No newline at end of file
+// feetPerMeter is the number of international feet in one meter.
+const feetPerMeter = 3.28084
+
+// metersToFeet converts a distance in meters to feet.
+func metersToFeet(meters float64) float64 {
+	return meters * feetPerMeter
+}
@@ -0,0 +1,1611 @@
+package exporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeOnuUsecase is an in-memory OnuUseCaseInterface used to drive the collector in tests.
+type fakeOnuUsecase struct {
+	discovered map[string][]model.ONUInfoPerBoard // keyed by "board/pon"
+	details    map[string]model.ONUCustomerInfo   // keyed by "board/pon/onu"
+	ponErrors  map[string]error                   // keyed by "board/pon"
+	emptyIDs   map[string][]int                   // keyed by "board/pon"
+	failNext   atomic.Int64                       // Number of upcoming discoveries that fail, whatever the PON
+	walkDelay  time.Duration                      // Duration of every discovery, like a PON walk on a real OLT
+	fetchDelay time.Duration                      // Duration of every detail fetch, of an ONU or of a whole PON
+	mu         sync.Mutex
+	fetches    map[string]int   // Number of detail fetches of a single ONU, keyed by "board/pon/onu"
+	ponFetches map[string]int   // Number of detail fetches of a whole PON, keyed by "board/pon"
+	ponFailing map[string]error // Errors of the detail fetches of a whole PON, keyed by "board/pon"
+	maxOnus    int              // ONU IDs per PON, 128 when unset
+}
+
+func newFakeOnuUsecase() *fakeOnuUsecase {
+	return &fakeOnuUsecase{
+		discovered: make(map[string][]model.ONUInfoPerBoard),
+		details:    make(map[string]model.ONUCustomerInfo),
+		ponErrors:  make(map[string]error),
+		emptyIDs:   make(map[string][]int),
+		fetches:    make(map[string]int),
+		ponFetches: make(map[string]int),
+		ponFailing: make(map[string]error),
+	}
+}
+
+// addOnu registers an ONU on the given location, both for discovery and detail lookups.
+func (f *fakeOnuUsecase) addOnu(onu model.ONUCustomerInfo) {
+	ponKey := fmt.Sprintf("%d/%d", onu.Board, onu.PON)
+	f.discovered[ponKey] = append(f.discovered[ponKey], model.ONUInfoPerBoard{
+		Board:        onu.Board,
+		PON:          onu.PON,
+		ID:           onu.ID,
+		Name:         onu.Name,
+		OnuType:      onu.OnuType,
+		SerialNumber: onu.SerialNumber,
+		RXPower:      onu.RXPower,
+		Status:       onu.Status,
+		MACAddress:   onu.MACAddress,
+	})
+	f.details[fmt.Sprintf("%d/%d/%d", onu.Board, onu.PON, onu.ID)] = onu
+}
+
+func (f *fakeOnuUsecase) GetByBoardIDAndPonID(_ context.Context, boardID, ponID int) ([]model.ONUInfoPerBoard, error) {
+	time.Sleep(f.walkDelay)
+	if f.failNext.Add(-1) >= 0 {
+		return nil, errors.New("olt busy")
+	}
+	f.failNext.Store(0)
+	ponKey := fmt.Sprintf("%d/%d", boardID, ponID)
+	if err := f.ponErrors[ponKey]; err != nil {
+		return nil, err
+	}
+	return f.discovered[ponKey], nil
+}
+
+func (f *fakeOnuUsecase) GetByBoardIDPonIDAndOnuID(_ context.Context, boardID, ponID, onuID int) (model.ONUCustomerInfo, error) {
+	time.Sleep(f.fetchDelay)
+	key := fmt.Sprintf("%d/%d/%d", boardID, ponID, onuID)
+	f.mu.Lock()
+	f.fetches[key]++
+	f.mu.Unlock()
+	onu, ok := f.details[key]
+	if !ok {
+		return model.ONUCustomerInfo{}, errors.New("onu not found")
+	}
+	return onu, nil
+}
+
+func (f *fakeOnuUsecase) GetAllDetailsByBoardPon(_ context.Context, boardID, ponID int) ([]model.ONUCustomerInfo, error) {
+	time.Sleep(f.fetchDelay)
+	ponKey := fmt.Sprintf("%d/%d", boardID, ponID)
+	f.mu.Lock()
+	f.ponFetches[ponKey]++
+	f.mu.Unlock()
+	if err := f.ponFailing[ponKey]; err != nil {
+		return nil, err
+	}
+	var onus []model.ONUCustomerInfo
+	for _, discovered := range f.discovered[ponKey] {
+		if onu, ok := f.details[fmt.Sprintf("%s/%d", ponKey, discovered.ID)]; ok {
+			onus = append(onus, onu)
+		}
+	}
+	return onus, nil
+}
+
+func (f *fakeOnuUsecase) GetStatusesByBoardPon(_ context.Context, boardID, ponID int) (map[int]string, error) {
+	statuses := make(map[int]string)
+	for _, onu := range f.discovered[fmt.Sprintf("%d/%d", boardID, ponID)] {
+		statuses[onu.ID] = onu.Status
+	}
+	return statuses, nil
+}
+
+func (f *fakeOnuUsecase) GetEmptyOnuID(_ context.Context, boardID, ponID int) ([]model.OnuID, error) {
+	var emptyOnuIDs []model.OnuID
+	for _, id := range f.emptyIDs[fmt.Sprintf("%d/%d", boardID, ponID)] {
+		emptyOnuIDs = append(emptyOnuIDs, model.OnuID{Board: boardID, PON: ponID, ID: id})
+	}
+	return emptyOnuIDs, nil
+}
+
+func (f *fakeOnuUsecase) GetOnuIDAndSerialNumber(_, _ int) ([]model.OnuSerialNumber, error) {
+	return nil, nil
+}
+
+func (f *fakeOnuUsecase) UpdateEmptyOnuID(_ context.Context, _, _ int) error {
+	return nil
+}
+
+func (f *fakeOnuUsecase) MaxOnuPerPon() int {
+	if f.maxOnus == 0 {
+		return 128
+	}
+	return f.maxOnus
+}
+
+func (f *fakeOnuUsecase) GetByBoardIDAndPonIDWithPagination(_, _, _, _ int) ([]model.ONUInfoPerBoard, int) {
+	return nil, 0
+}
+
+// newTestCollector returns a collector scanning boards 1-2 and PONs 1-16 with default options.
+func newTestCollector(t *testing.T, usecase *fakeOnuUsecase) *OnuCollector {
+	t.Helper()
+	t.Setenv("PROMETHEUS_BOARD_MIN", "1")
+	t.Setenv("PROMETHEUS_BOARD_MAX", "2")
+	t.Setenv("PROMETHEUS_PON_MIN", "1")
+	t.Setenv("PROMETHEUS_PON_MAX", "16")
+	return NewOnuCollector(usecase, &fakePonUsecase{}, ScanRangeFromEnv())
+}
+
+// gatheredMetric is a flattened view of a single collected sample.
+type gatheredMetric struct {
+	labels map[string]string
+	value  float64
+}
+
+// gatherMetrics registers the collector on a fresh registry and returns the samples grouped by metric name.
+func gatherMetrics(t *testing.T, collector prometheus.Collector) map[string][]gatheredMetric {
+	t.Helper()
+	registry := prometheus.NewRegistry()
+	require.NoError(t, registry.Register(collector))
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+
+	result := make(map[string][]gatheredMetric)
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			labels := make(map[string]string)
+			for _, pair := range metric.GetLabel() {
+				labels[pair.GetName()] = pair.GetValue()
+			}
+			value := metric.GetGauge().GetValue()
+			if metric.GetCounter() != nil {
+				value = metric.GetCounter().GetValue()
+			}
+			result[family.GetName()] = append(result[family.GetName()], gatheredMetric{labels: labels, value: value})
+		}
+	}
+	return result
+}
+
+func TestCollectReportsSerialOnMultipleLocations(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 3, ID: 7, Name: "old-port", SerialNumber: "ZTEGC0000001", Status: "Online"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 2, PON: 5, ID: 1, Name: "new-port", SerialNumber: "ZTEGC0000001", Status: "Online"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, Name: "single", SerialNumber: "ZTEGC0000002", Status: "Online"})
+
+	metrics := gatherMetrics(t, newTestCollector(t, usecase))
+
+	multiLocation := metrics["zte_onu_multi_location"]
+	require.Len(t, multiLocation, 1)
+	assert.Equal(t, "ZTEGC0000001", multiLocation[0].labels["serial_number"])
+	assert.Equal(t, float64(1), multiLocation[0].value)
+
+	var migratedLocations []string
+	for _, m := range metrics["zte_onu_mapping_info"] {
+		if m.labels["serial_number"] == "ZTEGC0000001" {
+			migratedLocations = append(migratedLocations, m.labels["board"]+"/"+m.labels["pon"]+"/"+m.labels["onu_id"])
+		}
+	}
+	assert.ElementsMatch(t, []string{"1/3/7", "2/5/1"}, migratedLocations)
+
+	// Per-serial metrics are still emitted exactly once.
+	assert.Len(t, metrics["zte_onu_status"], 2)
+}
+
+func TestCollectDedupKey(t *testing.T) {
+	testCases := []struct {
+		name       string
+		dedupKey   string
+		labelName  string
+		identities []string
+	}{
+		{
+			name:       "serial number by default",
+			labelName:  "serial_number",
+			identities: []string{"ZTEGC0000001", "ZTEGC0000002"},
+		},
+		{
+			name:       "serial number",
+			dedupKey:   "serial",
+			labelName:  "serial_number",
+			identities: []string{"ZTEGC0000001", "ZTEGC0000002"},
+		},
+		{
+			name:       "MAC address",
+			dedupKey:   "mac",
+			labelName:  "mac_address",
+			identities: []string{"aa:bb:cc:00:00:01", "aa:bb:cc:00:00:03"},
+		},
+		{
+			name:       "location",
+			dedupKey:   "location",
+			labelName:  "location",
+			identities: []string{"1/3/7", "2/5/1", "1/1/1", "1/1/2"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			usecase := newFakeOnuUsecase()
+			// ZTEGC0000001 is seen twice while being migrated to another PON.
+			usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 3, ID: 7, SerialNumber: "ZTEGC0000001", MACAddress: "aa:bb:cc:00:00:01", Status: "Online"})
+			usecase.addOnu(model.ONUCustomerInfo{Board: 2, PON: 5, ID: 1, SerialNumber: "ZTEGC0000001", MACAddress: "aa:bb:cc:00:00:01", Status: "Online"})
+			// ZTEGC0000002 is listed twice on the same PON, once with a stale LOS entry.
+			usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000002", MACAddress: "aa:bb:cc:00:00:03", Status: "LOS"})
+			usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 2, SerialNumber: "ZTEGC0000002", MACAddress: "aa:bb:cc:00:00:03", Status: "Online"})
+
+			if tc.dedupKey != "" {
+				t.Setenv("PROMETHEUS_DEDUP_KEY", tc.dedupKey)
+			}
+			metrics := gatherMetrics(t, newTestCollector(t, usecase))
+
+			var identities []string
+			for _, m := range metrics["zte_onu_status"] {
+				if tc.labelName != "serial_number" {
+					assert.NotContains(t, m.labels, "serial_number")
+				}
+				identities = append(identities, m.labels[tc.labelName])
+			}
+			assert.ElementsMatch(t, tc.identities, identities)
+			assert.Len(t, metrics["zte_onu_uptime_seconds"], len(tc.identities), "per-ONU metrics use the same identity")
+		})
+	}
+}
+
+func TestCollectDedupDisabled(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	// Lab ONUs sharing the same configuration, serial number included.
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, Name: "lab-1", SerialNumber: "ZTEGC0000001", Status: "Online"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 2, Name: "lab-2", SerialNumber: "ZTEGC0000001", Status: "LOS"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 2, PON: 4, ID: 9, Name: "lab-3", SerialNumber: "ZTEGC0000001", Status: "Online"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 2, ID: 1, Name: "other", SerialNumber: "ZTEGC0000002", Status: "Online"})
+
+	t.Setenv("PROMETHEUS_DEDUP_KEY", "none")
+	metrics := gatherMetrics(t, newTestCollector(t, usecase))
+
+	expected := []string{"ZTEGC0000001@1/1/1", "ZTEGC0000001@1/1/2", "ZTEGC0000001@2/4/9", "ZTEGC0000002@1/2/1"}
+	for _, name := range []string{"zte_onu_status", "zte_onu_mapping_info", "zte_onu_uptime_seconds"} {
+		var serials []string
+		for _, m := range metrics[name] {
+			serials = append(serials, m.labels["serial_number"])
+		}
+		assert.ElementsMatch(t, expected, serials, name)
+	}
+	assert.Empty(t, metrics["zte_onu_multi_location"], "every location is reported on its own")
+}
+
+func TestParseDedupKey(t *testing.T) {
+	assert.Equal(t, dedupBySerial, parseDedupKey(""))
+	assert.Equal(t, dedupByMAC, parseDedupKey(" MAC "))
+	assert.Equal(t, dedupByLocation, parseDedupKey("location"))
+	assert.Equal(t, dedupDisabled, parseDedupKey("none"))
+	assert.Equal(t, dedupBySerial, parseDedupKey("imei"), "invalid keys fall back to the serial number")
+}
+
+func TestCollectMultiLocationDisabled(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 3, ID: 7, SerialNumber: "ZTEGC0000001", Status: "Online"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 2, PON: 5, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online"})
+
+	t.Setenv("PROMETHEUS_TRACK_MULTI_LOCATION", "false")
+	metrics := gatherMetrics(t, newTestCollector(t, usecase))
+
+	assert.Empty(t, metrics["zte_onu_multi_location"])
+	assert.Len(t, metrics["zte_onu_mapping_info"], 1)
+}
+
+func TestCollectOnusWithoutSerial(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 3, Name: "no-serial-1", Status: "Online"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 2, ID: 3, Name: "no-serial-2", Status: "LOS"})
+
+	metrics := gatherMetrics(t, newTestCollector(t, usecase))
+
+	require.Len(t, metrics["zte_onu_status"], 2, "serial-less ONUs are neither skipped nor merged")
+	var serials []string
+	for _, m := range metrics["zte_onu_status"] {
+		serials = append(serials, m.labels["serial_number"])
+	}
+	assert.ElementsMatch(t, []string{"1/1/3", "1/2/3"}, serials)
+	for _, m := range metrics["zte_onu_mapping_info"] {
+		assert.Equal(t, m.labels["board"]+"/"+m.labels["pon"]+"/"+m.labels["onu_id"], m.labels["serial_number"], "the mapping still joins on serial_number")
+	}
+}
+
+func TestCollectVendorPrefix(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online"})
+
+	testCases := []struct {
+		name         string
+		setPrefix    bool
+		vendorPrefix string
+		expected     string
+		unexpected   string
+	}{
+		{name: "default prefix", expected: "zte_onu_status", unexpected: "onu_status"},
+		{name: "empty prefix", setPrefix: true, vendorPrefix: "", expected: "onu_status", unexpected: "zte_onu_status"},
+		{name: "custom prefix", setPrefix: true, vendorPrefix: "olt", expected: "olt_onu_status", unexpected: "zte_onu_status"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setPrefix {
+				t.Setenv("PROMETHEUS_METRIC_VENDOR_PREFIX", tc.vendorPrefix)
+			}
+			metrics := gatherMetrics(t, newTestCollector(t, usecase))
+
+			assert.Len(t, metrics[tc.expected], 1)
+			assert.Empty(t, metrics[tc.unexpected])
+		})
+	}
+}
+
+func TestCollectCircuitBreakerSkipsFailingPons(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online"})
+	for _, ponKey := range []string{"1/2", "1/3", "2/7"} {
+		usecase.ponErrors[ponKey] = errors.New("request timeout")
+	}
+
+	t.Setenv("PROMETHEUS_PON_FAILURE_THRESHOLD", "2")
+	t.Setenv("PROMETHEUS_PON_OPEN_DURATION", "1h")
+	collector := newTestCollector(t, usecase)
+
+	// The first two scrapes fail the PONs until the threshold opens their circuits.
+	for i := 0; i < 2; i++ {
+		metrics := gatherMetrics(t, collector)
+		assert.Equal(t, float64(0), metrics["zte_exporter_pons_skipped_total"][0].value)
+	}
+
+	metrics := gatherMetrics(t, collector)
+	assert.Equal(t, float64(3), metrics["zte_exporter_pons_open_circuit"][0].value)
+	assert.Equal(t, float64(3), metrics["zte_exporter_pons_skipped_total"][0].value)
+	assert.Len(t, metrics["zte_onu_status"], 1, "healthy PONs are still scraped")
+
+	metrics = gatherMetrics(t, collector)
+	assert.Equal(t, float64(6), metrics["zte_exporter_pons_skipped_total"][0].value)
+
+	// Once the open period has elapsed the PONs are retried and recover.
+	collector.breaker.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	usecase.ponErrors = make(map[string]error)
+	metrics = gatherMetrics(t, collector)
+	assert.Equal(t, float64(0), metrics["zte_exporter_pons_open_circuit"][0].value)
+	assert.Equal(t, float64(6), metrics["zte_exporter_pons_skipped_total"][0].value)
+}
+
+func TestCollectRegisteredTimestamp(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online", RegisteredAt: "2022-03-15 08:30:45"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 2, SerialNumber: "ZTEGC0000002", Status: "Online"})
+
+	metrics := gatherMetrics(t, newTestCollector(t, usecase))
+
+	registered := metrics["zte_onu_registered_timestamp_seconds"]
+	require.Len(t, registered, 1, "ONUs without a registration time are not reported")
+	assert.Equal(t, "ZTEGC0000001", registered[0].labels["serial_number"])
+	assert.Equal(t, float64(time.Date(2022, 3, 15, 8, 30, 45, 0, time.UTC).Unix()), registered[0].value)
+}
+
+func TestCollectQosProfile(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online", QosProfile: "QOS-100M\x00"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 2, SerialNumber: "ZTEGC0000002", Status: "LOS"})
+
+	metrics := gatherMetrics(t, newTestCollector(t, usecase))
+
+	profiles := metrics["zte_onu_qos_profile_info"]
+	require.Len(t, profiles, 1, "ONUs without a QoS profile are not reported")
+	assert.Equal(t, "ZTEGC0000001", profiles[0].labels["serial_number"])
+	assert.Equal(t, "QOS-100M", profiles[0].labels["qos_profile"])
+	assert.Equal(t, float64(1), profiles[0].value)
+}
+
+func TestCollectDistanceFeet(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online", GponOpticalDistance: "1524"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 2, SerialNumber: "ZTEGC0000002", Status: "LOS", GponOpticalDistance: "Unknown"})
+
+	metrics := gatherMetrics(t, newTestCollector(t, usecase))
+	assert.Empty(t, metrics["zte_onu_gpon_optical_distance_feet"], "feet are not reported by default")
+
+	t.Setenv("PROMETHEUS_EMIT_DISTANCE_FEET", "true")
+	metrics = gatherMetrics(t, newTestCollector(t, usecase))
+
+	meters := metrics["zte_onu_gpon_optical_distance_meters"]
+	feet := metrics["zte_onu_gpon_optical_distance_feet"]
+	require.Len(t, meters, 1)
+	require.Len(t, feet, 1, "ONUs with an unknown distance are not reported")
+	assert.Equal(t, "ZTEGC0000001", feet[0].labels["serial_number"])
+	assert.InDelta(t, meters[0].value*3.28084, feet[0].value, 1e-9)
+	assert.InDelta(t, 5000.0, feet[0].value, 0.1)
+}
+
+func TestCollectSignalQuality(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online", SignalQuality: "87"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 2, SerialNumber: "ZTEGC0000002", Status: "LOS", SignalQuality: "12"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 3, SerialNumber: "ZTEGC0000003", Status: "Online"})
+
+	metrics := gatherMetrics(t, newTestCollector(t, usecase))
+
+	quality := metrics["zte_onu_signal_quality"]
+	require.Len(t, quality, 1, "only Online ONUs reporting an index are exported")
+	assert.Equal(t, "ZTEGC0000001", quality[0].labels["serial_number"])
+	assert.Equal(t, float64(87), quality[0].value)
+}
+
+func TestCollectCardinalityGuard(t *testing.T) {
+	t.Setenv("PROMETHEUS_MAPPING_INFO_LIMIT", "3")
+
+	usecase := newFakeOnuUsecase()
+	for id := 1; id <= 3; id++ {
+		usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: id, SerialNumber: fmt.Sprintf("ZTEGC%07d", id), Status: "Online"})
+	}
+	collector := newTestCollector(t, usecase)
+
+	metrics := gatherMetrics(t, collector)
+	assert.Len(t, metrics["zte_onu_mapping_info"], 3, "the mapping info is emitted up to the limit")
+	assert.Equal(t, float64(0), metrics["zte_exporter_cardinality_guard_tripped"][0].value)
+
+	// The same serial number on another PON adds a mapping info series of its own.
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 2, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online"})
+	metrics = gatherMetrics(t, collector)
+	assert.NotContains(t, metrics, "zte_onu_mapping_info", "no mapping info is emitted once the limit is exceeded")
+	assert.Equal(t, float64(1), metrics["zte_exporter_cardinality_guard_tripped"][0].value)
+	assert.Len(t, metrics["zte_onu_status"], 3, "the other ONU metrics are still emitted")
+
+	// The guard is evaluated again on every scrape.
+	usecase.discovered["1/2"] = nil
+	metrics = gatherMetrics(t, collector)
+	assert.Len(t, metrics["zte_onu_mapping_info"], 3)
+	assert.Equal(t, float64(0), metrics["zte_exporter_cardinality_guard_tripped"][0].value)
+}
+
+func TestCollectInternalMetrics(t *testing.T) {
+	internalMetrics := []string{
+		"zte_exporter_pons_skipped_total",
+		"zte_exporter_pons_open_circuit",
+		"zte_exporter_last_scrape_duration_seconds",
+		"zte_exporter_last_scrape_onus",
+		"zte_exporter_scrape_duration_seconds",
+		"zte_exporter_scrape_success",
+		"zte_exporter_cardinality_guard_tripped",
+		"zte_exporter_effective_concurrency",
+	}
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 2, ID: 1, SerialNumber: "ZTEGC0000002", Status: "Online"})
+
+	t.Run("mixed with the ONU metrics by default", func(t *testing.T) {
+		metrics := gatherMetrics(t, newTestCollector(t, usecase))
+		for _, name := range internalMetrics {
+			assert.Contains(t, metrics, name)
+		}
+		assert.Equal(t, float64(2), metrics["zte_exporter_last_scrape_onus"][0].value)
+	})
+
+	t.Run("served only by the internal collector", func(t *testing.T) {
+		t.Setenv("PROMETHEUS_SEPARATE_INTERNAL_METRICS", "true")
+		collector := newTestCollector(t, usecase)
+		require.True(t, collector.SeparateInternalMetrics())
+
+		metrics := gatherMetrics(t, collector)
+		assert.Contains(t, metrics, "zte_onu_status")
+		for _, name := range internalMetrics {
+			assert.NotContains(t, metrics, name)
+		}
+
+		internal := gatherMetrics(t, collector.InternalCollector())
+		assert.Len(t, internal, len(internalMetrics), "no ONU metric is served by the internal collector")
+		for _, name := range internalMetrics {
+			assert.Contains(t, internal, name)
+		}
+		assert.Equal(t, float64(2), internal["zte_exporter_last_scrape_onus"][0].value)
+		assert.Greater(t, internal["zte_exporter_last_scrape_duration_seconds"][0].value, float64(0))
+	})
+}
+
+func TestCollectScrapeSuccessAndPonErrors(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 2, ID: 1, SerialNumber: "ZTEGC0000002", Status: "Online"})
+	usecase.ponErrors["1/2"] = errors.New("walk timeout")
+	collector := newTestCollector(t, usecase)
+
+	// A partial scrape is still a success, the failed PON is counted on every scrape.
+	for scrape := 1; scrape <= 2; scrape++ {
+		metrics := gatherMetrics(t, collector)
+		assert.Len(t, metrics["zte_onu_status"], 1)
+		require.Len(t, metrics["zte_exporter_scrape_success"], 1)
+		assert.Equal(t, float64(1), metrics["zte_exporter_scrape_success"][0].value)
+		assert.Greater(t, metrics["zte_exporter_scrape_duration_seconds"][0].value, float64(0))
+
+		require.Len(t, metrics["zte_exporter_pon_scrape_errors_total"], 1, "only failed PONs are reported")
+		errorsTotal := metrics["zte_exporter_pon_scrape_errors_total"][0]
+		assert.Equal(t, map[string]string{"board": "1", "pon": "2"}, errorsTotal.labels)
+		assert.Equal(t, float64(scrape), errorsTotal.value)
+	}
+
+	// A scrape where every PON fails is not.
+	usecase.failNext.Store(32)
+	metrics := gatherMetrics(t, collector)
+	assert.Equal(t, float64(0), metrics["zte_exporter_scrape_success"][0].value)
+	assert.Len(t, metrics["zte_exporter_pon_scrape_errors_total"], 32)
+}
+
+func TestCollectMulticast(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online", MulticastEnabled: "true", MulticastGroups: "3"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 2, SerialNumber: "ZTEGC0000002", Status: "LOS", MulticastEnabled: "false", MulticastGroups: "0"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 3, SerialNumber: "ZTEGC0000003", Status: "Online"})
+
+	metrics := gatherMetrics(t, newTestCollector(t, usecase))
+
+	enabled := metrics["zte_onu_multicast_enabled"]
+	require.Len(t, enabled, 2, "ONUs without a multicast state are not reported")
+	states := map[string]float64{}
+	for _, m := range enabled {
+		states[m.labels["serial_number"]] = m.value
+	}
+	assert.Equal(t, map[string]float64{"ZTEGC0000001": 1, "ZTEGC0000002": 0}, states)
+
+	groups := metrics["zte_onu_multicast_groups"]
+	require.Len(t, groups, 1, "only Online ONUs reporting a group count are exported")
+	assert.Equal(t, "ZTEGC0000001", groups[0].labels["serial_number"])
+	assert.Equal(t, float64(3), groups[0].value)
+}
+
+func TestCollectAlarmCount(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online", AlarmCount: "3"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 2, SerialNumber: "ZTEGC0000002", Status: "LOS", AlarmCount: "58"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 3, SerialNumber: "ZTEGC0000003", Status: "Online"})
+
+	metrics := gatherMetrics(t, newTestCollector(t, usecase))
+
+	counts := make(map[string]float64)
+	for _, m := range metrics["zte_onu_alarm_count"] {
+		counts[m.labels["serial_number"]] = m.value
+	}
+	assert.Equal(t, map[string]float64{"ZTEGC0000001": 3, "ZTEGC0000002": 58}, counts, "reported whatever the status, only when available")
+}
+
+func TestCollectSkipEmptyTimestamps(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online", LastOnline: "2024-05-01 10:00:00", LastOffline: "2024-04-30 22:15:00"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 2, SerialNumber: "ZTEGC0000002", Status: "Online", LastOnline: "2024-05-01 10:00:00"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 3, SerialNumber: "ZTEGC0000003", Status: "LOS"})
+
+	timestamps := func(metrics map[string][]gatheredMetric, name string) map[string]float64 {
+		result := make(map[string]float64)
+		for _, m := range metrics[name] {
+			result[m.labels["serial_number"]] = m.value
+		}
+		return result
+	}
+
+	metrics := gatherMetrics(t, newTestCollector(t, usecase))
+	assert.Equal(t, map[string]float64{"ZTEGC0000001": 1714557600, "ZTEGC0000002": 1714557600, "ZTEGC0000003": 0}, timestamps(metrics, "zte_onu_last_online_timestamp_seconds"), "empty timestamps are reported as 0 by default")
+
+	t.Setenv("PROMETHEUS_SKIP_EMPTY_TIMESTAMPS", "true")
+	metrics = gatherMetrics(t, newTestCollector(t, usecase))
+	assert.Equal(t, map[string]float64{"ZTEGC0000001": 1714557600, "ZTEGC0000002": 1714557600}, timestamps(metrics, "zte_onu_last_online_timestamp_seconds"))
+	assert.Equal(t, map[string]float64{"ZTEGC0000001": 1714515300}, timestamps(metrics, "zte_onu_last_offline_timestamp_seconds"), "ONUs never offline have no series")
+}
+
+func TestCollectLastOfflineReason(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online", LastOfflineReason: "PowerOff"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 2, SerialNumber: "ZTEGC0000002", Status: "LOS", LastOfflineReason: "LOS"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 3, SerialNumber: "ZTEGC0000003", Status: "Online", LastOfflineReason: "Battery exhausted"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 4, SerialNumber: "ZTEGC0000004", Status: "Online"})
+
+	metrics := gatherMetrics(t, newTestCollector(t, usecase))
+
+	codes := make(map[string]float64)
+	for _, m := range metrics["zte_onu_last_offline_reason"] {
+		codes[m.labels["serial_number"]] = m.value
+	}
+	assert.Equal(t, map[string]float64{"ZTEGC0000001": 9, "ZTEGC0000002": 2, "ZTEGC0000003": 0}, codes, "only reported when a reason is available")
+
+	reasons := make(map[string]string)
+	for _, m := range metrics["zte_onu_mapping_info"] {
+		reasons[m.labels["serial_number"]] = m.labels["offline_reason"]
+	}
+	assert.Equal(t, "PowerOff", reasons["ZTEGC0000001"], "the text stays on the mapping info")
+}
+
+func TestCollectLoopDetected(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online", LoopDetected: "true"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 2, SerialNumber: "ZTEGC0000002", Status: "Online", LoopDetected: "false"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 3, SerialNumber: "ZTEGC0000003", Status: "Online"})
+
+	metrics := gatherMetrics(t, newTestCollector(t, usecase))
+
+	loops := make(map[string]float64)
+	for _, m := range metrics["zte_onu_loop_detected"] {
+		loops[m.labels["serial_number"]] = m.value
+	}
+	assert.Equal(t, map[string]float64{"ZTEGC0000001": 1, "ZTEGC0000002": 0}, loops, "ONUs without a loop detection alarm are not reported")
+}
+
+func TestCollectEncryptionEnabled(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online", EncryptionEnabled: "true"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 2, SerialNumber: "ZTEGC0000002", Status: "Online", EncryptionEnabled: "false"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 3, SerialNumber: "ZTEGC0000003", Status: "Online"})
+
+	metrics := gatherMetrics(t, newTestCollector(t, usecase))
+
+	encrypted := make(map[string]float64)
+	for _, m := range metrics["zte_onu_encryption_enabled"] {
+		encrypted[m.labels["serial_number"]] = m.value
+	}
+	assert.Equal(t, map[string]float64{"ZTEGC0000001": 1, "ZTEGC0000002": 0}, encrypted, "ONUs without an encryption state are not reported")
+}
+
+func TestCollectUpgradeState(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online", UpgradeState: "downloading"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 2, SerialNumber: "ZTEGC0000002", Status: "LOS", UpgradeState: "committed"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 3, SerialNumber: "ZTEGC0000003", Status: "Online"})
+
+	metrics := gatherMetrics(t, newTestCollector(t, usecase))
+
+	states := make(map[string]string)
+	for _, m := range metrics["zte_onu_upgrade_state"] {
+		assert.Equal(t, float64(1), m.value)
+		states[m.labels["serial_number"]] = m.labels["state"]
+	}
+	assert.Equal(t, map[string]string{"ZTEGC0000001": "downloading", "ZTEGC0000002": "committed"}, states, "ONUs without an upgrade state are not reported")
+}
+
+func TestCollectBatteryStatus(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online", BatteryStatus: "on_battery"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 2, SerialNumber: "ZTEGC0000002", Status: "Online", BatteryStatus: "low"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 3, SerialNumber: "ZTEGC0000003", Status: "Online", BatteryStatus: "charged"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 4, SerialNumber: "ZTEGC0000004", Status: "Online", BatteryStatus: "unknown"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 5, SerialNumber: "ZTEGC0000005", Status: "Online"})
+
+	metrics := gatherMetrics(t, newTestCollector(t, usecase))
+
+	states := make(map[string]string)
+	for _, m := range metrics["zte_onu_battery_status"] {
+		assert.Equal(t, float64(1), m.value)
+		states[m.labels["serial_number"]] = m.labels["state"]
+	}
+	assert.Equal(t, map[string]string{
+		"ZTEGC0000001": "on_battery",
+		"ZTEGC0000002": "low",
+		"ZTEGC0000003": "charged",
+		"ZTEGC0000004": "unknown",
+	}, states, "ONUs without a battery state are not reported")
+
+	onBattery := make(map[string]float64)
+	for _, m := range metrics["zte_onu_on_battery"] {
+		onBattery[m.labels["serial_number"]] = m.value
+	}
+	assert.Equal(t, map[string]float64{"ZTEGC0000001": 1, "ZTEGC0000002": 1, "ZTEGC0000003": 0}, onBattery, "an unknown battery state is not reported as on or off battery")
+}
+
+func TestCollectRegistrationFailReason(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Auth Failed", RegistrationFailCode: "2", RegistrationFailReason: "auth_failed"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 2, SerialNumber: "ZTEGC0000002", Status: "Online", RegistrationFailCode: "1", RegistrationFailReason: "none"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 3, SerialNumber: "ZTEGC0000003", Status: "Online"})
+
+	metrics := gatherMetrics(t, newTestCollector(t, usecase))
+
+	reasons := make(map[string]string)
+	codes := make(map[string]float64)
+	for _, m := range metrics["zte_onu_last_registration_fail_reason"] {
+		reasons[m.labels["serial_number"]] = m.labels["reason"]
+		codes[m.labels["serial_number"]] = m.value
+	}
+	assert.Equal(t, map[string]string{"ZTEGC0000001": "auth_failed", "ZTEGC0000002": "none"}, reasons, "ONUs without a registration failure reason are not reported")
+	assert.Equal(t, map[string]float64{"ZTEGC0000001": 2, "ZTEGC0000002": 1}, codes)
+}
+
+func TestCollectEmptySlots(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online"})
+	usecase.emptyIDs["1/1"] = []int{2, 3}
+	usecase.emptyIDs["1/2"] = []int{1}
+	usecase.ponErrors["1/2"] = errors.New("request timeout")
+
+	metrics := gatherMetrics(t, newTestCollector(t, usecase))
+	assert.Len(t, metrics["zte_onu_status"], 1, "empty slots are not reported by default")
+
+	t.Setenv("PROMETHEUS_EMIT_EMPTY_SLOTS", "true")
+	metrics = gatherMetrics(t, newTestCollector(t, usecase))
+
+	statuses := make(map[string]float64)
+	for _, m := range metrics["zte_onu_status"] {
+		statuses[m.labels["serial_number"]] = m.value
+	}
+	assert.Equal(t, map[string]float64{
+		"ZTEGC0000001": 1,
+		"empty@1/1/2":  emptySlotStatus,
+		"empty@1/1/3":  emptySlotStatus,
+	}, statuses, "the free ONU IDs of a failed PON are not reported")
+}
+
+func TestCollectPonUp(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online"})
+	usecase.ponErrors["2/4"] = errors.New("request timeout")
+
+	metrics := gatherMetrics(t, newTestCollector(t, usecase))
+
+	ponUp := make(map[string]float64)
+	for _, m := range metrics["zte_pon_up"] {
+		ponUp[m.labels["board"]+"/"+m.labels["pon"]] = m.value
+	}
+	assert.Len(t, ponUp, 32, "every scanned PON is reported")
+	assert.Equal(t, float64(1), ponUp["1/1"], "PON with ONUs")
+	assert.Equal(t, float64(1), ponUp["1/2"], "empty PON")
+	assert.Equal(t, float64(0), ponUp["2/4"], "failed PON")
+}
+
+func TestCollectPonPorts(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online"})
+	usecase.ponErrors["1/2"] = errors.New("request timeout")
+	collector := newTestCollector(t, usecase)
+	collector.ponUsecase = &fakePonUsecase{ports: map[string]model.PonPort{
+		"1/1":  {Board: 1, PON: 1, TxPower: "2.12", Status: 1},
+		"1/2":  {Board: 1, PON: 2, TxPower: "1.50", Status: 2},
+		"2/7":  {Board: 2, PON: 7, Status: 4},
+		"2/16": {Board: 2, PON: 16, TxPower: "n/a"},
+	}}
+
+	metrics := gatherMetrics(t, collector)
+
+	statuses := make(map[string]float64)
+	for _, m := range metrics["zte_pon_port_status"] {
+		statuses[m.labels["board"]+"/"+m.labels["pon"]] = m.value
+	}
+	assert.Equal(t, map[string]float64{"1/1": 1, "1/2": 0, "2/7": 0}, statuses, "up, down even when its ONUs could not be discovered, and unknown")
+
+	txPowers := make(map[string]float64)
+	for _, m := range metrics["zte_pon_port_tx_power_dbm"] {
+		txPowers[m.labels["board"]+"/"+m.labels["pon"]] = m.value
+	}
+	assert.Equal(t, map[string]float64{"1/1": 2.12, "1/2": 1.5}, txPowers, "unreadable and unparsable readings are left out")
+}
+
+func TestCollectPonUpDisabled(t *testing.T) {
+	t.Setenv("PROMETHEUS_EMIT_PON_UP", "false")
+	metrics := gatherMetrics(t, newTestCollector(t, newFakeOnuUsecase()))
+
+	assert.Empty(t, metrics["zte_pon_up"])
+}
+
+func TestCollectDescriptionSource(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Description: "Live", Status: "Online"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 2, SerialNumber: "ZTEGC0000002", Status: "Online"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 3, SerialNumber: "ZTEGC0000003", Status: "Online"})
+	t.Setenv("PROMETHEUS_DESCRIPTION_FILE", writeDescriptionFile(t, "ZTEGC0000001=Stale\nZTEGC0000002=Customer B\n"))
+
+	metrics := gatherMetrics(t, newTestCollector(t, usecase))
+
+	descriptions := make(map[string][2]string)
+	for _, m := range metrics["zte_onu_mapping_info"] {
+		descriptions[m.labels["serial_number"]] = [2]string{m.labels["description"], m.labels["description_source"]}
+	}
+	assert.Equal(t, map[string][2]string{
+		"ZTEGC0000001": {"Live", "olt"},
+		"ZTEGC0000002": {"Customer B", "file"},
+		"ZTEGC0000003": {"", "none"},
+	}, descriptions)
+}
+
+func TestCollectStatusCount(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	for id, status := range []string{"Online", "Online", "Online", "LOS", "Dying Gasp", "Offline", "Unknown"} {
+		usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: id + 1, SerialNumber: fmt.Sprintf("ZTEGC000000%d", id+1), Status: status})
+	}
+	// The same ONU seen on a second PON is counted once
+	usecase.addOnu(model.ONUCustomerInfo{Board: 2, PON: 3, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online"})
+
+	metrics := gatherMetrics(t, newTestCollector(t, usecase))
+	assert.Empty(t, metrics["zte_onu_status_count"], "status counts are not reported by default")
+
+	t.Setenv("PROMETHEUS_EMIT_STATUS_COUNT", "true")
+	metrics = gatherMetrics(t, newTestCollector(t, usecase))
+
+	counts := make(map[string]float64)
+	for _, m := range metrics["zte_onu_status_count"] {
+		counts[m.labels["status"]] = m.value
+	}
+	assert.Equal(t, map[string]float64{
+		"Online":     3,
+		"LOS":        1,
+		"Dying Gasp": 1,
+		"Power-Off":  0,
+		"Other":      2,
+	}, counts)
+}
+
+func TestCollectPowerRange(t *testing.T) {
+	tests := []struct {
+		name            string
+		rxPower         string
+		txPower         string
+		expectedRx      []float64
+		expectedTx      []float64
+		expectedInvalid map[string]float64
+	}{
+		{name: "in range", rxPower: "-40", txPower: "10", expectedRx: []float64{-40}, expectedTx: []float64{10}, expectedInvalid: map[string]float64{}},
+		{name: "below min", rxPower: "-40.01", txPower: "2.5", expectedTx: []float64{2.5}, expectedInvalid: map[string]float64{"rx": 1}},
+		{name: "above max", rxPower: "-20", txPower: "655.35", expectedRx: []float64{-20}, expectedInvalid: map[string]float64{"tx": 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usecase := newFakeOnuUsecase()
+			usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online", RXPower: tt.rxPower, TXPower: tt.txPower})
+
+			t.Setenv("POWER_MIN_DBM", "-40")
+			t.Setenv("POWER_MAX_DBM", "10")
+			metrics := gatherMetrics(t, newTestCollector(t, usecase))
+
+			values := func(name string) []float64 {
+				var result []float64
+				for _, m := range metrics[name] {
+					result = append(result, m.value)
+				}
+				return result
+			}
+			assert.Equal(t, tt.expectedRx, values("zte_onu_rx_power_dbm"))
+			assert.Equal(t, tt.expectedTx, values("zte_onu_tx_power_dbm"))
+
+			invalid := make(map[string]float64)
+			for _, m := range metrics["zte_onu_power_reading_invalid_total"] {
+				assert.Equal(t, "ZTEGC0000001", m.labels["serial_number"])
+				invalid[m.labels["direction"]] = m.value
+			}
+			assert.Equal(t, tt.expectedInvalid, invalid)
+		})
+	}
+}
+
+func TestCollectPowerReadingInvalidCounts(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online", RXPower: "-80"})
+	collector := newTestCollector(t, usecase)
+
+	gatherMetrics(t, collector)
+	metrics := gatherMetrics(t, collector)
+
+	require.Len(t, metrics["zte_onu_power_reading_invalid_total"], 1)
+	assert.Equal(t, 2.0, metrics["zte_onu_power_reading_invalid_total"][0].value, "every scrape with an invalid reading counts")
+	assert.Empty(t, metrics["zte_onu_rx_power_dbm"], "-80 dBm is below the default minimum")
+}
+
+func TestParsePowerRange(t *testing.T) {
+	assert.Equal(t, powerRange{min: -30, max: 5}, parsePowerRange(-30, 5))
+	assert.Equal(t, powerRange{min: -50, max: 20}, parsePowerRange(10, -10), "an empty range falls back to the default")
+}
+
+func TestCollectOnuSlots(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.maxOnus = 64
+	for id := 1; id <= 3; id++ {
+		usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: id, SerialNumber: fmt.Sprintf("ZTEGC000000%d", id), Status: "Online"})
+	}
+	usecase.ponErrors["2/4"] = errors.New("request timeout")
+
+	metrics := gatherMetrics(t, newTestCollector(t, usecase))
+
+	used := make(map[string]float64)
+	for _, m := range metrics["zte_pon_used_onu_slots"] {
+		used[m.labels["board"]+"/"+m.labels["pon"]] = m.value
+	}
+	empty := make(map[string]float64)
+	for _, m := range metrics["zte_pon_empty_onu_slots"] {
+		empty[m.labels["board"]+"/"+m.labels["pon"]] = m.value
+	}
+	assert.Len(t, used, 31, "every discovered PON is reported")
+	assert.Len(t, empty, 31, "every discovered PON is reported")
+	assert.NotContains(t, used, "2/4", "failed PON")
+	assert.Equal(t, float64(3), used["1/1"])
+	assert.Equal(t, float64(0), used["1/2"])
+	for pon, value := range used {
+		assert.Equal(t, float64(64), value+empty[pon], "used and empty slots of PON %s add up to the configured maximum", pon)
+	}
+}
+
+func TestCollectMaintenanceLabel(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 3, ID: 1, SerialNumber: "INPON00001", Status: "Online", RXPower: "-20"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 2, PON: 9, ID: 1, SerialNumber: "INBOARD001", Status: "Online", RXPower: "-20"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 4, SerialNumber: "BYSERIAL01", Status: "LOS"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 5, SerialNumber: "REGULAR001", Status: "Online", RXPower: "-20"})
+
+	t.Setenv("PROMETHEUS_MAINTENANCE_PONS", "1/3, 2/*")
+	t.Setenv("PROMETHEUS_MAINTENANCE_SERIALS", "BYSERIAL01")
+	metrics := gatherMetrics(t, newTestCollector(t, usecase))
+
+	expected := map[string]string{
+		"INPON00001": "true",
+		"INBOARD001": "true",
+		"BYSERIAL01": "true",
+		"REGULAR001": "",
+	}
+	for _, name := range []string{"zte_onu_status", "zte_onu_mapping_info", "zte_onu_uptime_seconds"} {
+		require.Len(t, metrics[name], len(expected), name)
+		for _, m := range metrics[name] {
+			assert.Equal(t, expected[m.labels["serial_number"]], m.labels["maintenance"], "%s of %s", name, m.labels["serial_number"])
+		}
+	}
+	for _, m := range metrics["zte_onu_rx_power_dbm"] {
+		assert.Equal(t, expected[m.labels["serial_number"]], m.labels["maintenance"])
+	}
+}
+
+func TestCollectDroppedFrames(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online", RxDropped: "42", TxDropped: "18446744073709551615"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 2, SerialNumber: "ZTEGC0000002", Status: "LOS", RxDropped: "7", TxDropped: "7"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 3, SerialNumber: "ZTEGC0000003", Status: "Online"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 4, SerialNumber: "ZTEGC0000004", Status: "Online", RxDropped: "invalid"})
+
+	metrics := gatherMetrics(t, newTestCollector(t, usecase))
+
+	rxDropped := metrics["zte_onu_rx_dropped_total"]
+	require.Len(t, rxDropped, 1, "only Online ONUs with a valid counter are reported")
+	assert.Equal(t, "ZTEGC0000001", rxDropped[0].labels["serial_number"])
+	assert.Equal(t, float64(42), rxDropped[0].value)
+
+	txDropped := metrics["zte_onu_tx_dropped_total"]
+	require.Len(t, txDropped, 1)
+	assert.Equal(t, float64(18446744073709551615), txDropped[0].value)
+}
+
+func TestCollectRetriesEmptyScrape(t *testing.T) {
+	const ponCount = 32 // boards 1-2, PONs 1-16
+
+	testCases := []struct {
+		name          string
+		retry         string
+		expectedOnus  int
+		expectedPonUp float64
+	}{
+		{name: "retry enabled", retry: "true", expectedOnus: 2, expectedPonUp: 1},
+		{name: "retry disabled", retry: "false", expectedOnus: 0, expectedPonUp: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			usecase := newFakeOnuUsecase()
+			usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online"})
+			usecase.addOnu(model.ONUCustomerInfo{Board: 2, PON: 3, ID: 1, SerialNumber: "ZTEGC0000002", Status: "Online"})
+
+			t.Setenv("PROMETHEUS_RETRY_EMPTY_SCRAPE", tc.retry)
+			t.Setenv("PROMETHEUS_RETRY_EMPTY_SCRAPE_DELAY", "1ms")
+			collector := newTestCollector(t, usecase)
+
+			metrics := gatherMetrics(t, collector)
+			require.Len(t, metrics["zte_onu_status"], 2)
+
+			// The OLT is busy for one full discovery pass, then recovers.
+			usecase.failNext.Store(int64(ponCount))
+			metrics = gatherMetrics(t, collector)
+			assert.Len(t, metrics["zte_onu_status"], tc.expectedOnus)
+
+			require.Len(t, metrics["zte_pon_up"], ponCount, "zte_pon_up is reported once per PON")
+			for _, m := range metrics["zte_pon_up"] {
+				assert.Equal(t, tc.expectedPonUp, m.value)
+			}
+		})
+	}
+}
+
+func TestCollectDoesNotRetryWithoutPreviousOnus(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online"})
+	usecase.failNext.Store(32)
+
+	t.Setenv("PROMETHEUS_RETRY_EMPTY_SCRAPE", "true")
+	t.Setenv("PROMETHEUS_RETRY_EMPTY_SCRAPE_DELAY", "1ms")
+	metrics := gatherMetrics(t, newTestCollector(t, usecase))
+
+	assert.Empty(t, metrics["zte_onu_status"], "the first scrape has nothing to compare with")
+}
+
+func TestCollectWorkerPoolOptions(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	for id := 1; id <= 20; id++ {
+		usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 2, ID: id, SerialNumber: fmt.Sprintf("ZTEGC%07d", id), Status: "Online"})
+	}
+
+	testCases := []struct {
+		name               string
+		workers            string
+		jobsBuffer         string
+		expectedWorkers    int
+		expectedJobsBuffer int
+	}{
+		{name: "defaults", expectedWorkers: 4, expectedJobsBuffer: 1000},
+		{name: "custom buffer", workers: "3", jobsBuffer: "2", expectedWorkers: 3, expectedJobsBuffer: 2},
+		{name: "unbuffered", workers: "2", jobsBuffer: "0", expectedWorkers: 2, expectedJobsBuffer: 0},
+		{name: "invalid values", workers: "0", jobsBuffer: "-1", expectedWorkers: 1, expectedJobsBuffer: 1000},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.workers != "" {
+				t.Setenv("PROMETHEUS_WORKERS", tc.workers)
+			}
+			if tc.jobsBuffer != "" {
+				t.Setenv("PROMETHEUS_JOBS_BUFFER", tc.jobsBuffer)
+			}
+			collector := newTestCollector(t, usecase)
+			assert.Equal(t, tc.expectedWorkers, collector.workers)
+			assert.Equal(t, tc.expectedJobsBuffer, collector.jobsBuffer)
+
+			// Every ONU is collected exactly once, whatever the buffer size.
+			metrics := gatherMetrics(t, collector)
+			assert.Len(t, metrics["zte_onu_status"], 20)
+			onus, _ := collector.LastScrape()
+			assert.Len(t, onus, 20)
+		})
+	}
+}
+
+func TestCollectDiscoversPonsConcurrently(t *testing.T) {
+	// 32 PONs whose walk takes 10ms each: a sequential discovery needs at least 320ms, with 8 PONs
+	// walked at a time it needs about 40ms.
+	usecase := newFakeOnuUsecase()
+	usecase.walkDelay = 10 * time.Millisecond
+	for pon := 1; pon <= 16; pon++ {
+		usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: pon, ID: 1, SerialNumber: fmt.Sprintf("ZTEGC1%06d", pon), Status: "Online"})
+		usecase.addOnu(model.ONUCustomerInfo{Board: 2, PON: pon, ID: 1, SerialNumber: fmt.Sprintf("ZTEGC2%06d", pon), Status: "Online"})
+	}
+	usecase.ponErrors["2/5"] = errors.New("walk timeout")
+
+	scrape := func(concurrency string) (map[string][]gatheredMetric, time.Duration) {
+		t.Setenv("PROMETHEUS_DISCOVERY_CONCURRENCY", concurrency)
+		collector := newTestCollector(t, usecase)
+		start := time.Now()
+		metrics := gatherMetrics(t, collector)
+		return metrics, time.Since(start)
+	}
+
+	sequential, sequentialDuration := scrape("1")
+	concurrent, concurrentDuration := scrape("8")
+	t.Logf("discovery of 32 PONs: %s sequential, %s with 8 concurrent walks", sequentialDuration, concurrentDuration)
+
+	assert.GreaterOrEqual(t, sequentialDuration, 320*time.Millisecond)
+	assert.Less(t, concurrentDuration, sequentialDuration/2)
+
+	// The outcome does not depend on the concurrency.
+	assert.Equal(t, sequential["zte_onu_status"], concurrent["zte_onu_status"])
+	assert.Equal(t, sequential["zte_pon_up"], concurrent["zte_pon_up"])
+	assert.Len(t, concurrent["zte_onu_status"], 31)
+}
+
+func TestCollectCatv(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online", CatvEnabled: "true", CatvLevel: "17.5"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 2, SerialNumber: "ZTEGC0000002", Status: "LOS", CatvEnabled: "false", CatvLevel: "0.0"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 3, SerialNumber: "ZTEGC0000003", Status: "Online"})
+
+	metrics := gatherMetrics(t, newTestCollector(t, usecase))
+
+	states := map[string]float64{}
+	for _, m := range metrics["zte_onu_catv_status"] {
+		states[m.labels["serial_number"]] = m.value
+	}
+	assert.Equal(t, map[string]float64{"ZTEGC0000001": 1, "ZTEGC0000002": 0}, states, "ONUs without a CATV port state are not reported")
+
+	levels := metrics["zte_onu_catv_level_dbmv"]
+	require.Len(t, levels, 1, "only Online ONUs reporting an RF level are exported")
+	assert.Equal(t, "ZTEGC0000001", levels[0].labels["serial_number"])
+	assert.Equal(t, 17.5, levels[0].value)
+}
+
+func TestCollectNegotiatedLineRates(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online", NegotiatedDownstreamRate: "2488.32", NegotiatedUpstreamRate: "1244.16"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 2, SerialNumber: "ZTEGC0000002", Status: "LOS", NegotiatedDownstreamRate: "2488.32", NegotiatedUpstreamRate: "1244.16"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 3, SerialNumber: "ZTEGC0000003", Status: "Online"})
+
+	metrics := gatherMetrics(t, newTestCollector(t, usecase))
+
+	downstream := metrics["zte_onu_negotiated_downstream_mbps"]
+	require.Len(t, downstream, 1, "only Online ONUs reporting a line rate are exported")
+	assert.Equal(t, "ZTEGC0000001", downstream[0].labels["serial_number"])
+	assert.Equal(t, 2488.32, downstream[0].value)
+
+	upstream := metrics["zte_onu_negotiated_upstream_mbps"]
+	require.Len(t, upstream, 1)
+	assert.Equal(t, 1244.16, upstream[0].value)
+}
+
+func TestCollectTransceiverDiagnostics(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online", Temperature: "45.00", Voltage: "3.30", BiasCurrent: "15.000"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 2, SerialNumber: "ZTEGC0000002", Status: "LOS", Temperature: "40.00", Voltage: "3.28", BiasCurrent: "12.000"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 3, SerialNumber: "ZTEGC0000003", Status: "Online"})
+
+	metrics := gatherMetrics(t, newTestCollector(t, usecase))
+
+	for name, expected := range map[string]float64{
+		"zte_onu_temperature_celsius":       45,
+		"zte_onu_voltage_volts":             3.3,
+		"zte_onu_bias_current_milliamperes": 15,
+	} {
+		require.Len(t, metrics[name], 1, "only Online ONUs reporting %s are exported", name)
+		assert.Equal(t, "ZTEGC0000001", metrics[name][0].labels["serial_number"])
+		assert.Equal(t, expected, metrics[name][0].value)
+	}
+}
+
+func TestCollectDetailSampling(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	for id := 1; id <= 40; id++ {
+		usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: id, SerialNumber: fmt.Sprintf("ZTEGC%07d", id), Status: "Online", RXPower: "-20", Temperature: "45.00"})
+	}
+	t.Setenv("PROMETHEUS_DETAIL_SAMPLE_FRACTION", "0.25")
+	collector := newTestCollector(t, usecase)
+
+	// Four scrapes fetch the details of every ONU exactly once, a subset per scrape, while the
+	// status of every ONU is reported on every scrape.
+	for scrape := 1; scrape <= 4; scrape++ {
+		before := len(usecase.fetches)
+		metrics := gatherMetrics(t, collector)
+		assert.Len(t, metrics["zte_onu_status"], 40, "scrape %d reports the status of every ONU", scrape)
+		assert.Less(t, len(usecase.fetches)-before, 40, "scrape %d only fetches a subset", scrape)
+	}
+	require.Len(t, usecase.fetches, 40, "every ONU was fetched within 4 scrapes")
+	for key, fetches := range usecase.fetches {
+		assert.Equal(t, 1, fetches, "ONU %s was fetched once", key)
+	}
+
+	// Once fetched, the details of an ONU are reported on every scrape.
+	metrics := gatherMetrics(t, collector)
+	assert.Len(t, metrics["zte_onu_temperature_celsius"], 40)
+}
+
+// channelPublisher hands the ONUs of every publish to a channel.
+type channelPublisher chan []model.ONUCustomerInfo
+
+func (p channelPublisher) PublishOnus(_ context.Context, onus []model.ONUCustomerInfo) error {
+	p <- onus
+	return nil
+}
+
+func TestCollectPublishesOnus(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 2, PON: 3, ID: 4, SerialNumber: "ZTEGC0000002", Status: "LOS"})
+	collector := newTestCollector(t, usecase)
+	published := make(channelPublisher, 1)
+	collector.AddPublisher(published)
+
+	gatherMetrics(t, collector)
+
+	select {
+	case onus := <-published:
+		require.Len(t, onus, 2)
+		assert.Equal(t, "ZTEGC0000001", onus[0].SerialNumber)
+		assert.Equal(t, "ZTEGC0000002", onus[1].SerialNumber)
+	case <-time.After(time.Second):
+		t.Fatal("the scrape was not published")
+	}
+}
+
+func TestCollectSkipsAbsentValues(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online", RXPower: "-20.50"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 2, SerialNumber: "ZTEGC0000002", Status: "Online"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 3, SerialNumber: "ZTEGC0000003"})
+
+	metrics := gatherMetrics(t, newTestCollector(t, usecase))
+
+	statuses := make(map[string]float64)
+	for _, m := range metrics["zte_onu_status"] {
+		statuses[m.labels["serial_number"]] = m.value
+	}
+	assert.Equal(t, map[string]float64{"ZTEGC0000001": 1, "ZTEGC0000002": 1}, statuses, "an absent status is not reported as 0")
+
+	rxPower := metrics["zte_onu_rx_power_dbm"]
+	require.Len(t, rxPower, 1, "an absent RX power is not reported")
+	assert.Equal(t, "ZTEGC0000001", rxPower[0].labels["serial_number"])
+	assert.Empty(t, metrics["zte_onu_tx_power_dbm"])
+	assert.Empty(t, metrics["zte_onu_gpon_optical_distance_meters"])
+}
+
+func TestCollectTconts(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online", Tconts: []model.TcontAllocation{
+		{Index: 1, AllocID: 1024, Profile: "UP-100M", GemPorts: 2},
+		{Index: 2, AllocID: 1025, Profile: "VOIP"},
+	}})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 2, SerialNumber: "ZTEGC0000002", Status: "Online", Tconts: []model.TcontAllocation{}})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 3, SerialNumber: "ZTEGC0000003", Status: "Online"})
+
+	metrics := gatherMetrics(t, newTestCollector(t, usecase))
+
+	counts := make(map[string]float64)
+	for _, m := range metrics["zte_onu_tcont_count"] {
+		counts[m.labels["serial_number"]] = m.value
+	}
+	assert.Equal(t, map[string]float64{"ZTEGC0000001": 2, "ZTEGC0000002": 0}, counts, "ONUs without a T-CONT allocation are not reported")
+
+	info := metrics["zte_onu_tcont_info"]
+	require.Len(t, info, 2)
+	tconts := make(map[string]map[string]string)
+	for _, m := range info {
+		assert.Equal(t, "ZTEGC0000001", m.labels["serial_number"])
+		tconts[m.labels["tcont"]] = m.labels
+	}
+	assert.Equal(t, "1024", tconts["1"]["alloc_id"])
+	assert.Equal(t, "UP-100M", tconts["1"]["profile"])
+	assert.Equal(t, "2", tconts["1"]["gem_ports"])
+	assert.Equal(t, "VOIP", tconts["2"]["profile"])
+	assert.Empty(t, tconts["2"]["gem_ports"], "an unreported GEM port count leaves the label empty")
+}
+
+func TestCollectUniPorts(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online", UniPorts: []model.UniPort{
+		{Port: 1, SpeedMbps: 1000, Duplex: "full"},
+		{Port: 2, SpeedMbps: 100, Duplex: "half"},
+		{Port: 3}, // Link down
+	}})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 2, SerialNumber: "ZTEGC0000002", Status: "Online"})
+
+	metrics := gatherMetrics(t, newTestCollector(t, usecase))
+
+	speeds := make(map[string]float64)
+	for _, m := range metrics["zte_onu_uni_speed_mbps"] {
+		assert.Equal(t, "ZTEGC0000001", m.labels["serial_number"])
+		speeds[m.labels["port"]] = m.value
+	}
+	assert.Equal(t, map[string]float64{"1": 1000, "2": 100}, speeds, "ports without a negotiated speed are not reported")
+
+	duplex := make(map[string]float64)
+	for _, m := range metrics["zte_onu_uni_duplex"] {
+		duplex[m.labels["port"]] = m.value
+	}
+	assert.Equal(t, map[string]float64{"1": 1, "2": 0}, duplex)
+}
+
+func TestCollectMappingInfoIPLabels(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online", IPAddress: "10.0.0.2", IPGateway: "10.0.0.1", IPMask: "255.255.255.0"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 2, SerialNumber: "ZTEGC0000002", Status: "Online"})
+
+	metrics := gatherMetrics(t, newTestCollector(t, usecase))
+
+	mappingInfo := map[string]map[string]string{}
+	for _, m := range metrics["zte_onu_mapping_info"] {
+		mappingInfo[m.labels["serial_number"]] = m.labels
+	}
+	require.Len(t, mappingInfo, 2)
+	assert.Equal(t, "10.0.0.1", mappingInfo["ZTEGC0000001"]["ip_gateway"])
+	assert.Equal(t, "255.255.255.0", mappingInfo["ZTEGC0000001"]["ip_mask"])
+	assert.Empty(t, mappingInfo["ZTEGC0000002"]["ip_gateway"])
+	assert.Empty(t, mappingInfo["ZTEGC0000002"]["ip_mask"])
+}
+
+func BenchmarkDedupOnus(b *testing.B) {
+	// A fully loaded C320: 2 boards, 16 PONs and 128 ONUs per PON, with a few ONUs seen twice.
+	var discovered []model.ONUInfoPerBoard
+	for board := 1; board <= 2; board++ {
+		for pon := 1; pon <= 16; pon++ {
+			for id := 1; id <= 128; id++ {
+				serial := fmt.Sprintf("ZTEGC%02d%02d%03d", board, pon, id)
+				if id%64 == 0 {
+					serial = fmt.Sprintf("ZTEGC0101%03d", id) // Migrated from board 1 PON 1
+				}
+				discovered = append(discovered, model.ONUInfoPerBoard{Board: board, PON: pon, ID: id, SerialNumber: serial, Status: "Online"})
+			}
+		}
+	}
+	collector := NewOnuCollector(newFakeOnuUsecase(), &fakePonUsecase{}, ScanRangeFromEnv())
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		collector.dedupOnus(discovered)
+	}
+}
+
+func TestCollectDeterministicOrder(t *testing.T) {
+	t.Setenv("PROMETHEUS_WORKERS", "1")
+	t.Setenv("PROMETHEUS_EMIT_PROCESSED_ORDER", "true")
+
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 2, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 10, ID: 2, SerialNumber: "ZTEGC0000002", Status: "Online"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 2, ID: 7, SerialNumber: "ZTEGC0000003", Status: "Online"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 2, ID: 3, SerialNumber: "ZTEGC0000004", Status: "LOS"})
+
+	processedOrder := func() map[string]float64 {
+		order := map[string]float64{}
+		for _, m := range gatherMetrics(t, newTestCollector(t, usecase))["zte_exporter_onu_processed_order"] {
+			order[m.labels["serial_number"]] = m.value
+		}
+		return order
+	}
+
+	expected := map[string]float64{"ZTEGC0000004": 1, "ZTEGC0000003": 2, "ZTEGC0000002": 3, "ZTEGC0000001": 4}
+	for run := 0; run < 5; run++ {
+		assert.Equal(t, expected, processedOrder(), "ONUs are processed by board, PON and ONU ID on every run")
+	}
+}
+
+func TestCollectProcessedOrderDisabledByDefault(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online"})
+
+	assert.Empty(t, gatherMetrics(t, newTestCollector(t, usecase))["zte_exporter_onu_processed_order"])
+}
+
+func TestCollectDetailFetchDuration(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.fetchDelay = 5 * time.Millisecond
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 2, ID: 4, SerialNumber: "ZTEGC0000002", Status: "LOS"})
+
+	assert.Empty(t, gatherMetrics(t, newTestCollector(t, usecase))["zte_onu_detail_fetch_duration_seconds"], "disabled by default")
+
+	t.Setenv("PROMETHEUS_EMIT_DETAIL_FETCH_DURATION", "true")
+	durations := map[string]float64{}
+	for _, m := range gatherMetrics(t, newTestCollector(t, usecase))["zte_onu_detail_fetch_duration_seconds"] {
+		durations[m.labels["serial_number"]] = m.value
+	}
+	require.Len(t, durations, 2)
+	for serial, duration := range durations {
+		assert.GreaterOrEqual(t, duration, usecase.fetchDelay.Seconds(), serial)
+	}
+}
+
+// collectStream runs a single scrape and returns its samples in the order they were emitted.
+func collectStream(t *testing.T, collector prometheus.Collector) []string {
+	t.Helper()
+	ch := make(chan prometheus.Metric)
+	go func() {
+		collector.Collect(ch)
+		close(ch)
+	}()
+
+	var stream []string
+	for metric := range ch {
+		var sample dto.Metric
+		require.NoError(t, metric.Write(&sample))
+		labels := make([]string, 0, len(sample.GetLabel()))
+		for _, pair := range sample.GetLabel() {
+			labels = append(labels, pair.GetName()+"="+pair.GetValue())
+		}
+		stream = append(stream, fmt.Sprintf("%s%v", metric.Desc(), labels))
+	}
+	return stream
+}
+
+func TestCollectIdenticalOutputAcrossScrapes(t *testing.T) {
+	t.Setenv("PROMETHEUS_WORKERS", "1")
+	usecase := newFakeOnuUsecase()
+	for id := 1; id <= 10; id++ {
+		usecase.addOnu(model.ONUCustomerInfo{Board: 1 + id%2, PON: 1 + id%3, ID: id, SerialNumber: fmt.Sprintf("ZTEGC%07d", id), Status: "Online", RXPower: "-20.5"})
+	}
+	usecase.addOnu(model.ONUCustomerInfo{Board: 2, PON: 9, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online"}) // Migrated
+	collector := newTestCollector(t, usecase)
+
+	first := collectStream(t, collector)
+	require.NotEmpty(t, first)
+	for run := 0; run < 3; run++ {
+		assert.Equal(t, first, collectStream(t, collector), "two scrapes of the same data emit the same series in the same order")
+	}
+}
+
+func TestLastScrapeSortedByLocation(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	for id := 1; id <= 20; id++ {
+		usecase.addOnu(model.ONUCustomerInfo{Board: 1 + id%2, PON: 1 + id%5, ID: id, SerialNumber: fmt.Sprintf("ZTEGC%07d", id), Status: "Online"})
+	}
+	collector := newTestCollector(t, usecase)
+	gatherMetrics(t, collector)
+
+	onus, _ := collector.LastScrape()
+	require.Len(t, onus, 20)
+	for i := 1; i < len(onus); i++ {
+		previous, current := onus[i-1], onus[i]
+		assert.Less(t, fmt.Sprintf("%02d/%02d/%03d", previous.Board, previous.PON, previous.ID), fmt.Sprintf("%02d/%02d/%03d", current.Board, current.PON, current.ID))
+	}
+}
+
+func TestSerialCollector(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online", RXPower: "-21.5"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 2, ID: 1, SerialNumber: "ZTEGC0000002", Status: "Online", RXPower: "-19.0"})
+	collector := newTestCollector(t, usecase)
+
+	_, ok := collector.SerialCollector("ZTEGC0000001")
+	assert.False(t, ok, "unknown before the first scrape")
+
+	gatherMetrics(t, collector)
+	_, ok = collector.SerialCollector("ZTEGC9999999")
+	assert.False(t, ok)
+
+	serialCollector, ok := collector.SerialCollector("ZTEGC0000001")
+	require.True(t, ok)
+	metrics := gatherMetrics(t, serialCollector)
+	require.Len(t, metrics["zte_onu_rx_power_dbm"], 1)
+	assert.Equal(t, "ZTEGC0000001", metrics["zte_onu_rx_power_dbm"][0].labels["serial_number"])
+	assert.Equal(t, -21.5, metrics["zte_onu_rx_power_dbm"][0].value)
+	assert.NotContains(t, metrics, "zte_exporter_last_scrape_duration_seconds", "only the metrics of the ONU are returned")
+}
+
+func TestCollectMappingInfoTransceiverType(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online", TransceiverType: "GPON Class B+"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 2, SerialNumber: "ZTEGC0000002", Status: "Online"})
+
+	metrics := gatherMetrics(t, newTestCollector(t, usecase))
+
+	mappingInfo := map[string]map[string]string{}
+	for _, m := range metrics["zte_onu_mapping_info"] {
+		mappingInfo[m.labels["serial_number"]] = m.labels
+	}
+	require.Len(t, mappingInfo, 2)
+	assert.Equal(t, "GPON Class B+", mappingInfo["ZTEGC0000001"]["transceiver_type"])
+	assert.Empty(t, mappingInfo["ZTEGC0000002"]["transceiver_type"], "empty, i.e. absent once ingested, when not reported")
+}
+
+func TestCollectCacheTTL(t *testing.T) {
+	t.Setenv("PROMETHEUS_CACHE_TTL", "1m")
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online"})
+	collector := newTestCollector(t, usecase)
+
+	for scrape := 0; scrape < 3; scrape++ {
+		assert.Len(t, gatherMetrics(t, collector)["zte_onu_status"], 1)
+	}
+	assert.Equal(t, map[string]int{"1/1": 1}, usecase.ponFetches, "scrapes within the TTL do not read the OLT")
+}
+
+func TestCollectCacheServesInternalMetricsLive(t *testing.T) {
+	t.Setenv("PROMETHEUS_CACHE_TTL", "1m")
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online"})
+	collector := newTestCollector(t, usecase)
+	start := time.Now()
+	var elapsed time.Duration
+	collector.cache.now = func() time.Time { return start.Add(elapsed) }
+
+	metrics := gatherMetrics(t, collector)
+	require.Len(t, metrics["zte_scrape_cache_age_seconds"], 1)
+	assert.Zero(t, metrics["zte_scrape_cache_age_seconds"][0].value)
+	require.Len(t, metrics["zte_exporter_pons_skipped_total"], 1)
+
+	elapsed = 20 * time.Second
+	collector.breaker.mu.Lock()
+	collector.breaker.skippedTotal = 3
+	collector.breaker.mu.Unlock()
+	metrics = gatherMetrics(t, collector)
+	require.Len(t, metrics["zte_onu_status"], 1, "the ONU metrics are served from the cache")
+	require.Len(t, metrics["zte_scrape_cache_age_seconds"], 1)
+	assert.Equal(t, 20.0, metrics["zte_scrape_cache_age_seconds"][0].value)
+	require.Len(t, metrics["zte_exporter_pons_skipped_total"], 1, "the internal metrics are not cached along")
+	assert.Equal(t, 3.0, metrics["zte_exporter_pons_skipped_total"][0].value)
+	assert.Equal(t, map[string]int{"1/1": 1}, usecase.ponFetches)
+}
+
+func TestCollectWithoutCacheLeavesCacheAgeOut(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online"})
+	metrics := gatherMetrics(t, newTestCollector(t, usecase))
+
+	assert.Empty(t, metrics["zte_scrape_cache_age_seconds"])
+	assert.Len(t, metrics["zte_exporter_scrape_success"], 1)
+}
+
+func TestCollectSmokePon(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 2, ID: 1, SerialNumber: "ZTEGC0000002", Status: "Online"})
+
+	// scrape returns the PON scraped by a collection and the serial numbers of its ONUs.
+	scrape := func(collector *OnuCollector) (string, []string) {
+		metrics := gatherMetrics(t, collector)
+		require.Len(t, metrics["zte_pon_up"], 1, "a smoke scrape walks a single PON")
+		pon := metrics["zte_pon_up"][0].labels["board"] + "/" + metrics["zte_pon_up"][0].labels["pon"]
+		var serials []string
+		for _, m := range metrics["zte_onu_status"] {
+			serials = append(serials, m.labels["serial_number"])
+		}
+		return pon, serials
+	}
+
+	t.Setenv("PROMETHEUS_BOARD_MAX", "1")
+	t.Setenv("PROMETHEUS_PON_MAX", "2")
+	t.Setenv("PROMETHEUS_SMOKE_PON", "rotate")
+	collector := NewOnuCollector(usecase, &fakePonUsecase{}, ScanRangeFromEnv())
+	for _, expected := range []struct {
+		pon     string
+		serials []string
+	}{{"1/1", []string{"ZTEGC0000001"}}, {"1/2", []string{"ZTEGC0000002"}}, {"1/1", []string{"ZTEGC0000001"}}} {
+		pon, serials := scrape(collector)
+		assert.Equal(t, expected.pon, pon)
+		assert.Equal(t, expected.serials, serials)
+	}
+
+	t.Setenv("PROMETHEUS_SMOKE_PON", "1/2")
+	collector = NewOnuCollector(usecase, &fakePonUsecase{}, ScanRangeFromEnv())
+	for range 2 {
+		pon, serials := scrape(collector)
+		assert.Equal(t, "1/2", pon)
+		assert.Equal(t, []string{"ZTEGC0000002"}, serials)
+	}
+}
+
+func TestCollectPonDetails(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	for id := 1; id <= 3; id++ {
+		usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: id, SerialNumber: fmt.Sprintf("ZTEGC000000%d", id), Status: "Online", RXPower: "-20"})
+		usecase.addOnu(model.ONUCustomerInfo{Board: 2, PON: 9, ID: id, SerialNumber: fmt.Sprintf("ZTEGC000001%d", id), Status: "Online", RXPower: "-20"})
+	}
+	// ONU 4 is discovered but missed by the pass over its PON, e.g. when it deregisters in between.
+	usecase.addOnu(model.ONUCustomerInfo{Board: 2, PON: 9, ID: 4, SerialNumber: "ZTEGC0000014", Status: "Online", RXPower: "-20"})
+	missed := usecase.details["2/9/4"]
+	delete(usecase.details, "2/9/4")
+
+	t.Run("One pass per PON", func(t *testing.T) {
+		metrics := gatherMetrics(t, newTestCollector(t, usecase))
+
+		assert.Len(t, metrics["zte_onu_rx_power_dbm"], 6)
+		assert.Equal(t, map[string]int{"1/1": 1, "2/9": 1}, usecase.ponFetches)
+		assert.Equal(t, map[string]int{"2/9/4": 1}, usecase.fetches, "an ONU missed by the pass is fetched on its own")
+	})
+
+	usecase.details["2/9/4"] = missed
+	clear(usecase.fetches)
+	clear(usecase.ponFetches)
+
+	t.Run("Failed pass", func(t *testing.T) {
+		usecase.ponFailing["2/9"] = errors.New("request timeout")
+		defer delete(usecase.ponFailing, "2/9")
+
+		metrics := gatherMetrics(t, newTestCollector(t, usecase))
+
+		assert.Len(t, metrics["zte_onu_rx_power_dbm"], 7)
+		assert.Equal(t, map[string]int{"2/9/1": 1, "2/9/2": 1, "2/9/3": 1, "2/9/4": 1}, usecase.fetches, "the ONUs of a failed pass are fetched one by one")
+	})
+
+	clear(usecase.fetches)
+	clear(usecase.ponFetches)
+
+	t.Run("Disabled", func(t *testing.T) {
+		t.Setenv("PROMETHEUS_PON_DETAILS", "false")
+
+		metrics := gatherMetrics(t, newTestCollector(t, usecase))
+
+		assert.Len(t, metrics["zte_onu_rx_power_dbm"], 7)
+		assert.Empty(t, usecase.ponFetches)
+		assert.Len(t, usecase.fetches, 7)
+	})
+}
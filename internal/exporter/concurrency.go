@@ -0,0 +1,85 @@
+package exporter
+
+import (
+	"sync"
+	"time"
+)
+
+// concurrencyLimiter bounds the number of ONUs whose details are fetched at the same time. When
+// adaptive, it follows AIMD: once a full window of fetches (as many as the current limit) were fast
+// and successful the limit grows by one, up to max, while a slow or failed fetch halves it, down to
+// min. A fixed limiter always allows min == max fetches.
+type concurrencyLimiter struct {
+	mu            sync.Mutex
+	cond          *sync.Cond
+	min           int
+	max           int
+	limit         int           // Current number of fetches allowed at the same time
+	inFlight      int           // Number of fetches currently holding a slot
+	latencyTarget time.Duration // Fetches slower than this count as congestion
+	successes     int           // Fast and successful fetches since the limit last changed
+	generation    uint64        // Incremented on every decrease, so fetches started before it do not decrease again
+}
+
+// newConcurrencyLimiter creates a limiter starting at initial, clamped to [min, max]. With min ==
+// max the limit never changes.
+func newConcurrencyLimiter(initial, minLimit, maxLimit int, latencyTarget time.Duration) *concurrencyLimiter {
+	minLimit = max(minLimit, 1)
+	maxLimit = max(maxLimit, minLimit)
+	l := &concurrencyLimiter{
+		min:           minLimit,
+		max:           maxLimit,
+		limit:         min(max(initial, minLimit), maxLimit),
+		latencyTarget: latencyTarget,
+	}
+	l.cond = sync.NewCond(&l.mu)
+	return l
+}
+
+// acquire waits for a free slot and returns the generation the fetch started in, to pass to release.
+func (l *concurrencyLimiter) acquire() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for l.inFlight >= l.limit {
+		l.cond.Wait()
+	}
+	l.inFlight++
+	return l.generation
+}
+
+// release frees the slot of a fetch started in generation and adapts the limit to how long the
+// fetch took and whether it failed.
+func (l *concurrencyLimiter) release(generation uint64, latency time.Duration, failed bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.inFlight--
+	l.cond.Broadcast()
+
+	if l.min == l.max {
+		return
+	}
+
+	if failed || latency > l.latencyTarget {
+		// Fetches already in flight when the limit was lowered saw the same congestion, so only
+		// the first of them backs off.
+		if generation == l.generation {
+			l.limit = max(l.min, l.limit/2)
+			l.successes = 0
+			l.generation++
+		}
+		return
+	}
+
+	l.successes++
+	if l.successes >= l.limit && l.limit < l.max {
+		l.limit++
+		l.successes = 0
+	}
+}
+
+// current returns the number of fetches currently allowed at the same time.
+func (l *concurrencyLimiter) current() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.limit
+}
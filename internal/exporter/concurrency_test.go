@@ -0,0 +1,83 @@
+package exporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fetch runs one simulated fetch of the given latency through the limiter.
+func fetch(l *concurrencyLimiter, latency time.Duration, failed bool) {
+	generation := l.acquire()
+	l.release(generation, latency, failed)
+}
+
+func TestConcurrencyLimiterBacksOffOnRisingLatency(t *testing.T) {
+	limiter := newConcurrencyLimiter(4, 1, 16, 500*time.Millisecond)
+
+	// A fast OLT lets the limit grow by one per window of fetches, up to the maximum.
+	for i := 0; i < 200; i++ {
+		fetch(limiter, 50*time.Millisecond, false)
+	}
+	require.Equal(t, 16, limiter.current())
+
+	// The OLT slows down: fetches under the target keep the limit, the first one above it halves it.
+	var history []int
+	for latency := 100 * time.Millisecond; latency <= 2*time.Second; latency += 100 * time.Millisecond {
+		fetch(limiter, latency, false)
+		history = append(history, limiter.current())
+	}
+	assert.Equal(t, 16, history[3], "400ms is still under the target")
+	assert.Equal(t, 8, history[5], "600ms halves the limit")
+	assert.Equal(t, 1, limiter.current(), "a persistently slow OLT ends at the minimum")
+	for i := 1; i < len(history); i++ {
+		assert.LessOrEqual(t, history[i], history[i-1], "concurrency never grows while the latency rises")
+	}
+}
+
+func TestConcurrencyLimiterBacksOffOnErrors(t *testing.T) {
+	limiter := newConcurrencyLimiter(8, 2, 16, time.Second)
+
+	fetch(limiter, 10*time.Millisecond, true)
+	assert.Equal(t, 4, limiter.current())
+	fetch(limiter, 10*time.Millisecond, true)
+	fetch(limiter, 10*time.Millisecond, true)
+	assert.Equal(t, 2, limiter.current(), "never below the minimum")
+}
+
+func TestConcurrencyLimiterBacksOffOncePerWindow(t *testing.T) {
+	limiter := newConcurrencyLimiter(8, 1, 16, time.Second)
+
+	// Eight fetches in flight all see the same congestion, only one of them halves the limit.
+	generations := make([]uint64, 8)
+	for i := range generations {
+		generations[i] = limiter.acquire()
+	}
+	for _, generation := range generations {
+		limiter.release(generation, 2*time.Second, false)
+	}
+	assert.Equal(t, 4, limiter.current())
+}
+
+func TestConcurrencyLimiterFixed(t *testing.T) {
+	limiter := newConcurrencyLimiter(2, 2, 2, 0)
+	first, second := limiter.acquire(), limiter.acquire()
+
+	acquired := make(chan struct{})
+	go func() {
+		fetch(limiter, time.Hour, true)
+		close(acquired)
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("a third fetch started while two slots were taken")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	limiter.release(first, time.Hour, true)
+	<-acquired
+	limiter.release(second, time.Hour, true)
+	assert.Equal(t, 2, limiter.current(), "a fixed limiter ignores latency and errors")
+}
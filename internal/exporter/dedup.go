@@ -0,0 +1,99 @@
+package exporter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
+	"github.com/rs/zerolog/log"
+)
+
+// dedupKey selects the identity used to deduplicate discovered ONUs and to label the per-ONU metrics.
+type dedupKey string
+
+const (
+	dedupBySerial   dedupKey = "serial"
+	dedupByMAC      dedupKey = "mac"
+	dedupByLocation dedupKey = "location"
+	dedupDisabled   dedupKey = "none" // Every discovered ONU is kept, keyed by serial@board/pon/onu_id
+)
+
+// parseDedupKey parses the configured dedup key, falling back to the serial number when the value
+// is empty or unknown.
+func parseDedupKey(value string) dedupKey {
+	switch key := dedupKey(strings.ToLower(strings.TrimSpace(value))); key {
+	case dedupBySerial, dedupByMAC, dedupByLocation, dedupDisabled:
+		return key
+	case "":
+		return dedupBySerial
+	default:
+		log.Warn().Str("dedup_key", value).Msg("Ignoring invalid dedup key, expected serial, mac, location or none")
+		return dedupBySerial
+	}
+}
+
+// identity returns the identity of a discovered ONU, or an empty string when the ONU does not
+// report it (e.g. no MAC address OID configured). ONUs without a serial number are told apart by
+// their location.
+func (k dedupKey) identity(onu model.ONUInfoPerBoard) string {
+	switch k {
+	case dedupByMAC:
+		return onu.MACAddress
+	case dedupByLocation:
+		return onuLocation(onu.Board, onu.PON, onu.ID)
+	case dedupDisabled:
+		return locatedSerial(onu.SerialNumber, onu.Board, onu.PON, onu.ID)
+	default:
+		return serialOrLocation(onu.SerialNumber, onu.Board, onu.PON, onu.ID)
+	}
+}
+
+// mappingSerial returns the serial_number label of zte_onu_mapping_info. Without dedup it carries
+// the location too, so the mapping still joins with the per-ONU metrics on serial_number.
+func (k dedupKey) mappingSerial(onu model.ONUCustomerInfo) string {
+	if k == dedupDisabled {
+		return locatedSerial(onu.SerialNumber, onu.Board, onu.PON, onu.ID)
+	}
+	return serialOrLocation(onu.SerialNumber, onu.Board, onu.PON, onu.ID)
+}
+
+// emptySlotIdentity returns the identity of a free ONU ID, which has no serial number or MAC
+// address, e.g. "empty@1/3/7".
+func (k dedupKey) emptySlotIdentity(board, pon, onuID int) string {
+	if k == dedupByLocation {
+		return onuLocation(board, pon, onuID)
+	}
+	return locatedSerial("empty", board, pon, onuID)
+}
+
+// onuLocation returns the location of an ONU as board/pon/onu_id, e.g. "1/3/7", the single format
+// of every identity holding a location.
+func onuLocation(board, pon, onuID int) string {
+	return fmt.Sprintf("%d/%d/%d", board, pon, onuID)
+}
+
+// locatedSerial augments a serial number with the location of the ONU, e.g. "ZTEGC0000001@1/3/7".
+func locatedSerial(serialNumber string, board, pon, onuID int) string {
+	return serialNumber + "@" + onuLocation(board, pon, onuID)
+}
+
+// serialOrLocation returns the serial number, or the location of the ONU when the OLT reports no
+// serial number, e.g. "1/3/7", so serial-less ONUs neither vanish nor collide.
+func serialOrLocation(serialNumber string, board, pon, onuID int) string {
+	if serialNumber != "" {
+		return serialNumber
+	}
+	return onuLocation(board, pon, onuID)
+}
+
+// labelName returns the name of the per-ONU metric label holding the identity.
+func (k dedupKey) labelName() string {
+	switch k {
+	case dedupByMAC:
+		return "mac_address"
+	case dedupByLocation:
+		return "location"
+	default: // Without dedup the serial number is kept, augmented with the location
+		return "serial_number"
+	}
+}
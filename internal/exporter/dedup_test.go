@@ -0,0 +1,32 @@
+package exporter
+
+import (
+	"testing"
+
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDedupKeyLocationFormat(t *testing.T) {
+	withSerial := model.ONUInfoPerBoard{Board: 1, PON: 3, ID: 7, SerialNumber: "ZTEGC0000001"}
+	withoutSerial := model.ONUInfoPerBoard{Board: 1, PON: 3, ID: 7}
+
+	tests := []struct {
+		key       dedupKey
+		onu       model.ONUInfoPerBoard
+		identity  string
+		emptySlot string
+	}{
+		{key: dedupBySerial, onu: withSerial, identity: "ZTEGC0000001", emptySlot: "empty@1/3/7"},
+		{key: dedupBySerial, onu: withoutSerial, identity: "1/3/7", emptySlot: "empty@1/3/7"},
+		{key: dedupByLocation, onu: withSerial, identity: "1/3/7", emptySlot: "1/3/7"},
+		{key: dedupDisabled, onu: withSerial, identity: "ZTEGC0000001@1/3/7", emptySlot: "empty@1/3/7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.key)+" "+tt.identity, func(t *testing.T) {
+			assert.Equal(t, tt.identity, tt.key.identity(tt.onu))
+			assert.Equal(t, tt.emptySlot, tt.key.emptySlotIdentity(1, 3, 7))
+		})
+	}
+}
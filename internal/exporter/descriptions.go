@@ -0,0 +1,69 @@
+package exporter
+
+import (
+	"bufio"
+	"os"
+	"strings"
+
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
+	"github.com/rs/zerolog/log"
+)
+
+// Sources of the description label of zte_onu_mapping_info.
+const (
+	descriptionSourceOlt  = "olt"  // Read from the OLT
+	descriptionSourceFile = "file" // Read from PROMETHEUS_DESCRIPTION_FILE
+	descriptionSourceNone = "none" // Neither has one
+)
+
+// descriptionFile holds the fallback ONU descriptions, keyed by upper-cased serial number.
+type descriptionFile map[string]string
+
+// loadDescriptionFile reads the fallback ONU descriptions from path, one serial=description entry
+// per line, e.g. "ZTEGC0000001=Customer A". Blank lines and lines starting with # are skipped. An
+// empty path, an unreadable file and invalid entries are logged and yield no descriptions.
+func loadDescriptionFile(path string) descriptionFile {
+	descriptions := make(descriptionFile)
+	if path == "" {
+		return descriptions
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		log.Error().Err(err).Str("path", path).Msg("Could not read the description file, ONU descriptions are only read from the OLT")
+		return descriptions
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		serialNumber, description, found := strings.Cut(line, "=")
+		serialNumber, description = strings.TrimSpace(serialNumber), strings.TrimSpace(description)
+		if !found || serialNumber == "" {
+			log.Warn().Str("entry", line).Msg("Ignoring invalid description, expected serial=description")
+			continue
+		}
+		descriptions[strings.ToUpper(serialNumber)] = description
+	}
+	if err := scanner.Err(); err != nil {
+		log.Error().Err(err).Str("path", path).Msg("Could not read the whole description file")
+	}
+
+	return descriptions
+}
+
+// resolve returns the description of the ONU and its source: the live OLT value when the OLT has
+// one, the description file otherwise.
+func (d descriptionFile) resolve(onu model.ONUCustomerInfo) (description, source string) {
+	if strings.TrimSpace(onu.Description) != "" {
+		return onu.Description, descriptionSourceOlt
+	}
+	if description := d[strings.ToUpper(onu.SerialNumber)]; description != "" {
+		return description, descriptionSourceFile
+	}
+	return "", descriptionSourceNone
+}
@@ -0,0 +1,55 @@
+package exporter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeDescriptionFile writes content to a description file in a temporary directory.
+func writeDescriptionFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "descriptions.txt")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadDescriptionFile(t *testing.T) {
+	path := writeDescriptionFile(t, "# Customers\nztegc0000001 = Customer A\n\nZTEGC0000002=Tower 3=north\ninvalid\n=no serial\n")
+
+	assert.Equal(t, descriptionFile{
+		"ZTEGC0000001": "Customer A",
+		"ZTEGC0000002": "Tower 3=north",
+	}, loadDescriptionFile(path))
+
+	assert.Empty(t, loadDescriptionFile(""))
+	assert.Empty(t, loadDescriptionFile(filepath.Join(t.TempDir(), "missing.txt")), "a missing file yields no descriptions")
+}
+
+func TestDescriptionFileResolve(t *testing.T) {
+	descriptions := descriptionFile{"ZTEGC0000001": "Customer A", "ZTEGC0000002": "Customer B"}
+
+	testCases := []struct {
+		name                string
+		onu                 model.ONUCustomerInfo
+		expectedDescription string
+		expectedSource      string
+	}{
+		{name: "OLT value preferred", onu: model.ONUCustomerInfo{SerialNumber: "ZTEGC0000001", Description: "Live"}, expectedDescription: "Live", expectedSource: "olt"},
+		{name: "file fallback", onu: model.ONUCustomerInfo{SerialNumber: "ztegc0000002"}, expectedDescription: "Customer B", expectedSource: "file"},
+		{name: "blank OLT value", onu: model.ONUCustomerInfo{SerialNumber: "ZTEGC0000001", Description: "  "}, expectedDescription: "Customer A", expectedSource: "file"},
+		{name: "no source", onu: model.ONUCustomerInfo{SerialNumber: "ZTEGC0000003"}, expectedDescription: "", expectedSource: "none"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			description, source := descriptions.resolve(tc.onu)
+			assert.Equal(t, tc.expectedDescription, description)
+			assert.Equal(t, tc.expectedSource, source)
+		})
+	}
+}
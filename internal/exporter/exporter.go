@@ -4,68 +4,703 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 )
 
-// Metric descriptions for the ZTE OLT exporter.
-var (
-	// OnuStatusGaugeDesc describes the operational status of the ONU.
-	OnuStatusGaugeDesc = prometheus.NewDesc(
-		"zte_onu_status",
-		"The operational status of the ONU (1=Online, 2=DyingGasp, 3=LOS, 4=PowerOff, 0=Other).",
-		[]string{"serial_number"}, nil,
-	)
-
-	// OnuMappingInfoGaugeDesc provides a mapping of serial numbers to descriptive labels.
-	OnuMappingInfoGaugeDesc = prometheus.NewDesc(
-		"zte_onu_mapping_info",
-		"Information mapping for the ZTE ONU device.",
-		[]string{"board", "pon", "onu_id", "name", "serial_number", "onu_type", "description", "offline_reason", "ip_address"}, nil,
-	)
-
-	// OnuRxPowerGaugeDesc describes the received optical power of the ONU.
-	OnuRxPowerGaugeDesc = prometheus.NewDesc(
-		"zte_onu_rx_power_dbm",
-		"The received optical power of the ONU in dBm.",
-		[]string{"serial_number"}, nil,
-	)
-
-	// OnuTxPowerGaugeDesc describes the transmitted optical power of the ONU.
-	OnuTxPowerGaugeDesc = prometheus.NewDesc(
-		"zte_onu_tx_power_dbm",
-		"The transmitted optical power of the ONU in dBm.",
-		[]string{"serial_number"}, nil,
-	)
-
-	// OnuUptimeGaugeDesc describes the uptime of the ONU in seconds.
-	OnuUptimeGaugeDesc = prometheus.NewDesc(
-		"zte_onu_uptime_seconds",
-		"The uptime of the ONU in seconds.",
-		[]string{"serial_number"}, nil,
-	)
-
-	// OnuLastDownDurationGaugeDesc describes the duration of the last downtime in seconds.
-	OnuLastDownDurationGaugeDesc = prometheus.NewDesc(
-		"zte_onu_last_down_duration_seconds",
-		"The duration of the last downtime in seconds.",
-		[]string{"serial_number"}, nil,
-	)
-
-	// OnuLastOnlineGaugeDesc describes the last online timestamp as a Unix epoch.
-	OnuLastOnlineGaugeDesc = prometheus.NewDesc(
-		"zte_onu_last_online_timestamp_seconds",
-		"The last online timestamp of the ONU as a Unix epoch.",
-		[]string{"serial_number"}, nil,
-	)
-
-	// OnuLastOfflineGaugeDesc describes the last offline timestamp as a Unix epoch.
-	OnuLastOfflineGaugeDesc = prometheus.NewDesc(
-		"zte_onu_last_offline_timestamp_seconds",
-		"The last offline timestamp of the ONU as a Unix epoch.",
-		[]string{"serial_number"}, nil,
-	)
-
-	// OnuGponOpticalDistanceGaugeDesc describes the GPON optical distance in meters.
-	OnuGponOpticalDistanceGaugeDesc = prometheus.NewDesc(
-		"zte_onu_gpon_optical_distance_meters",
-		"The GPON optical distance to the ONU in meters.",
-		[]string{"serial_number"}, nil,
-	)
-)
\ No newline at end of file
+// DefaultVendorPrefix is the vendor prefix prepended to every metric name unless configured otherwise.
+const DefaultVendorPrefix = "zte"
+
+// metricDescs holds the metric descriptions for the ZTE OLT exporter.
+type metricDescs struct {
+	// onuStatus describes the operational status of the ONU.
+	onuStatus *prometheus.Desc
+
+	// onuMappingInfo provides a mapping of serial numbers to descriptive labels.
+	onuMappingInfo *prometheus.Desc
+
+	// onuRxPower describes the received optical power of the ONU.
+	onuRxPower *prometheus.Desc
+
+	// onuTxPower describes the transmitted optical power of the ONU.
+	onuTxPower *prometheus.Desc
+
+	// onuPowerReadingInvalid counts the optical power readings outside of the valid range.
+	onuPowerReadingInvalid *prometheus.Desc
+
+	// onuUptime describes the uptime of the ONU in seconds.
+	onuUptime *prometheus.Desc
+
+	// onuLastDownDuration describes the duration of the last downtime in seconds.
+	onuLastDownDuration *prometheus.Desc
+
+	// onuLastOnline describes the last online timestamp as a Unix epoch.
+	onuLastOnline *prometheus.Desc
+
+	// onuLastOffline describes the last offline timestamp as a Unix epoch.
+	onuLastOffline *prometheus.Desc
+
+	// onuRegistered describes the first registration timestamp as a Unix epoch.
+	onuRegistered *prometheus.Desc
+
+	// onuGponOpticalDistance describes the GPON optical distance in meters.
+	onuGponOpticalDistance *prometheus.Desc
+
+	// onuGponOpticalDistanceFeet describes the GPON optical distance converted to feet.
+	onuGponOpticalDistanceFeet *prometheus.Desc
+
+	// onuMultiLocation flags a serial number discovered on more than one board/PON.
+	onuMultiLocation *prometheus.Desc
+
+	// onuDownstreamThroughput describes the downstream throughput of the ONU in kbps.
+	onuDownstreamThroughput *prometheus.Desc
+
+	// onuUpstreamThroughput describes the upstream throughput of the ONU in kbps.
+	onuUpstreamThroughput *prometheus.Desc
+
+	// ponUp describes whether the discovery of a PON succeeded.
+	ponUp *prometheus.Desc
+
+	// onuStatusCount describes the number of ONUs in a status across the OLT.
+	onuStatusCount *prometheus.Desc
+
+	// ponUsedOnuSlots describes the number of ONU IDs in use on a PON.
+	ponUsedOnuSlots *prometheus.Desc
+
+	// ponEmptyOnuSlots describes the number of ONU IDs still available on a PON.
+	ponEmptyOnuSlots *prometheus.Desc
+
+	// ponPortTxPower describes the transmit power of the OLT PON port.
+	ponPortTxPower *prometheus.Desc
+
+	// ponPortStatus describes whether the OLT PON port is operationally up.
+	ponPortStatus *prometheus.Desc
+
+	// onuRxDropped counts the frames dropped by the ONU on receive.
+	onuRxDropped *prometheus.Desc
+
+	// onuTxDropped counts the frames dropped by the ONU on transmit.
+	onuTxDropped *prometheus.Desc
+
+	// onuSignalQuality describes the signal quality index computed by the OLT.
+	onuSignalQuality *prometheus.Desc
+
+	// onuTemperature describes the temperature of the optical transceiver of the ONU.
+	onuTemperature *prometheus.Desc
+	// onuVoltage describes the supply voltage of the optical transceiver of the ONU.
+	onuVoltage *prometheus.Desc
+	// onuBiasCurrent describes the laser bias current of the optical transceiver of the ONU.
+	onuBiasCurrent *prometheus.Desc
+	// onuMulticastEnabled describes whether IGMP snooping is enabled on the ONU.
+	onuMulticastEnabled *prometheus.Desc
+
+	// onuMulticastGroups describes the number of multicast groups joined by the ONU.
+	onuMulticastGroups *prometheus.Desc
+
+	// onuCatvStatus describes whether the CATV (RF video) port of the ONU is enabled.
+	onuCatvStatus *prometheus.Desc
+
+	// onuCatvLevel describes the RF output level of the CATV port of the ONU.
+	onuCatvLevel *prometheus.Desc
+
+	// onuNegotiatedDownstreamRate and onuNegotiatedUpstreamRate describe the GPON line rates
+	// negotiated by the ONU.
+	onuNegotiatedDownstreamRate *prometheus.Desc
+	onuNegotiatedUpstreamRate   *prometheus.Desc
+
+	// onuAlarmCount describes the number of alarms and events the OLT recorded for the ONU.
+	onuAlarmCount *prometheus.Desc
+	// onuLoopDetected describes whether a loop is detected behind the ONU.
+	onuLoopDetected *prometheus.Desc
+	// onuEncryptionEnabled describes whether the downstream GPON encryption of the ONU is active.
+	onuEncryptionEnabled *prometheus.Desc
+
+	// onuUpgradeState describes the software upgrade state of the ONU.
+	onuUpgradeState *prometheus.Desc
+
+	// onuBatteryStatus describes the state of the backup battery of the ONU.
+	onuBatteryStatus *prometheus.Desc
+
+	// onuOnBattery describes whether the ONU runs on its backup battery.
+	onuOnBattery *prometheus.Desc
+
+	// onuRegistrationFailReason describes why the last registration of the ONU failed.
+	onuRegistrationFailReason *prometheus.Desc
+	// onuLastOfflineReason describes why the ONU last went offline, as a reason code.
+	onuLastOfflineReason *prometheus.Desc
+
+	// onuTcontCount describes the number of T-CONTs allocated to the ONU.
+	onuTcontCount *prometheus.Desc
+
+	// onuTcontInfo maps the serial number to every T-CONT allocated to the ONU.
+	onuTcontInfo *prometheus.Desc
+
+	// onuUniSpeed describes the negotiated speed of an Ethernet (UNI) port of the ONU.
+	onuUniSpeed *prometheus.Desc
+
+	// onuUniDuplex describes the negotiated duplex mode of an Ethernet (UNI) port of the ONU.
+	onuUniDuplex *prometheus.Desc
+
+	// onuQosProfileInfo maps the serial number to the QoS profile of the ONU.
+	onuQosProfileInfo *prometheus.Desc
+
+	// exporterOnuProcessedOrder describes the position of the ONU in the processing order of the scrape.
+	exporterOnuProcessedOrder *prometheus.Desc
+	// onuDetailFetchDuration describes how long fetching the details of the ONU took.
+	onuDetailFetchDuration *prometheus.Desc
+}
+
+// newMetricDescs builds the metric descriptions, prefixing every metric name with vendorPrefix.
+// An empty vendorPrefix yields unprefixed names such as "onu_status".
+func newMetricDescs(vendorPrefix string, dedup dedupKey) *metricDescs {
+	name := func(metric string) string {
+		return prometheus.BuildFQName(vendorPrefix, "", metric)
+	}
+
+	// Per-ONU metrics are anchored to the identity selected by dedup, the serial number by default.
+	identityLabels := []string{dedup.labelName(), "maintenance"}
+
+	return &metricDescs{
+		onuStatus: prometheus.NewDesc(
+			name("onu_status"),
+			"The operational status of the ONU (1=Online, 2=DyingGasp, 3=LOS, 4=PowerOff, 0=Other, -1=Empty slot).",
+			identityLabels, nil,
+		),
+		onuMappingInfo: prometheus.NewDesc(
+			name("onu_mapping_info"),
+			"Information mapping for the ZTE ONU device.",
+			[]string{"board", "pon", "onu_id", "name", "serial_number", "onu_type", "description", "description_source", "offline_reason", "ip_address", "ip_gateway", "ip_mask", "mac_address", "equipment_id", "profile_template", "transceiver_type", "maintenance"}, nil,
+		),
+		onuRxPower: prometheus.NewDesc(
+			name("onu_rx_power_dbm"),
+			"The received optical power of the ONU in dBm.",
+			identityLabels, nil,
+		),
+		onuTxPower: prometheus.NewDesc(
+			name("onu_tx_power_dbm"),
+			"The transmitted optical power of the ONU in dBm.",
+			identityLabels, nil,
+		),
+		onuPowerReadingInvalid: prometheus.NewDesc(
+			name("onu_power_reading_invalid_total"),
+			"The number of optical power readings of the ONU outside of POWER_MIN_DBM to POWER_MAX_DBM, by direction (rx or tx).",
+			[]string{dedup.labelName(), "direction"}, nil,
+		),
+		onuUptime: prometheus.NewDesc(
+			name("onu_uptime_seconds"),
+			"The uptime of the ONU in seconds.",
+			identityLabels, nil,
+		),
+		onuLastDownDuration: prometheus.NewDesc(
+			name("onu_last_down_duration_seconds"),
+			"The duration of the last downtime in seconds.",
+			identityLabels, nil,
+		),
+		onuLastOnline: prometheus.NewDesc(
+			name("onu_last_online_timestamp_seconds"),
+			"The last online timestamp of the ONU as a Unix epoch.",
+			identityLabels, nil,
+		),
+		onuLastOffline: prometheus.NewDesc(
+			name("onu_last_offline_timestamp_seconds"),
+			"The last offline timestamp of the ONU as a Unix epoch.",
+			identityLabels, nil,
+		),
+		onuRegistered: prometheus.NewDesc(
+			name("onu_registered_timestamp_seconds"),
+			"The first registration timestamp of the ONU as a Unix epoch.",
+			identityLabels, nil,
+		),
+		onuGponOpticalDistance: prometheus.NewDesc(
+			name("onu_gpon_optical_distance_meters"),
+			"The GPON optical distance to the ONU in meters.",
+			identityLabels, nil,
+		),
+		onuGponOpticalDistanceFeet: prometheus.NewDesc(
+			name("onu_gpon_optical_distance_feet"),
+			"The GPON optical distance to the ONU in feet, converted from the distance in meters.",
+			identityLabels, nil,
+		),
+		onuMultiLocation: prometheus.NewDesc(
+			name("onu_multi_location"),
+			"Set to 1 when the ONU serial number was discovered on more than one board/PON in the same scrape.",
+			identityLabels, nil,
+		),
+		onuDownstreamThroughput: prometheus.NewDesc(
+			name("onu_downstream_throughput_kbps"),
+			"The downstream throughput of the ONU in kbps, derived from the traffic counter between scrapes.",
+			identityLabels, nil,
+		),
+		onuUpstreamThroughput: prometheus.NewDesc(
+			name("onu_upstream_throughput_kbps"),
+			"The upstream throughput of the ONU in kbps, derived from the traffic counter between scrapes.",
+			identityLabels, nil,
+		),
+		onuRxDropped: prometheus.NewDesc(
+			name("onu_rx_dropped_total"),
+			"The number of frames dropped by the ONU on receive, as reported by the OLT.",
+			identityLabels, nil,
+		),
+		onuTxDropped: prometheus.NewDesc(
+			name("onu_tx_dropped_total"),
+			"The number of frames dropped by the ONU on transmit, as reported by the OLT.",
+			identityLabels, nil,
+		),
+		onuSignalQuality: prometheus.NewDesc(
+			name("onu_signal_quality"),
+			"The signal quality index of the ONU computed by the OLT, from 0 (worst) to 100 (best).",
+			identityLabels, nil,
+		),
+		onuTemperature: prometheus.NewDesc(
+			name("onu_temperature_celsius"),
+			"The temperature of the optical transceiver of the ONU, in degrees Celsius.",
+			identityLabels, nil,
+		),
+		onuVoltage: prometheus.NewDesc(
+			name("onu_voltage_volts"),
+			"The supply voltage of the optical transceiver of the ONU, in volts.",
+			identityLabels, nil,
+		),
+		onuBiasCurrent: prometheus.NewDesc(
+			name("onu_bias_current_milliamperes"),
+			"The laser bias current of the optical transceiver of the ONU, in mA.",
+			identityLabels, nil,
+		),
+		onuMulticastEnabled: prometheus.NewDesc(
+			name("onu_multicast_enabled"),
+			"Whether multicast (IGMP snooping) is enabled (1) or disabled (0) on the ONU, as reported by the OLT.",
+			identityLabels, nil,
+		),
+		onuMulticastGroups: prometheus.NewDesc(
+			name("onu_multicast_groups"),
+			"The number of multicast groups currently joined by the ONU, as reported by the OLT.",
+			identityLabels, nil,
+		),
+		onuCatvStatus: prometheus.NewDesc(
+			name("onu_catv_status"),
+			"Whether the CATV (RF video) port of the ONU is enabled (1) or disabled (0), as reported by the OLT.",
+			identityLabels, nil,
+		),
+		onuCatvLevel: prometheus.NewDesc(
+			name("onu_catv_level_dbmv"),
+			"The RF output level of the CATV port of the ONU, in dBmV.",
+			identityLabels, nil,
+		),
+		onuNegotiatedDownstreamRate: prometheus.NewDesc(
+			name("onu_negotiated_downstream_mbps"),
+			"The downstream GPON line rate negotiated by the ONU, in Mbit/s.",
+			identityLabels, nil,
+		),
+		onuNegotiatedUpstreamRate: prometheus.NewDesc(
+			name("onu_negotiated_upstream_mbps"),
+			"The upstream GPON line rate negotiated by the ONU, in Mbit/s.",
+			identityLabels, nil,
+		),
+		onuAlarmCount: prometheus.NewDesc(
+			name("onu_alarm_count"),
+			"The number of alarms and events the OLT recorded for the ONU. The OLT may clear it, e.g. on reboot.",
+			identityLabels, nil,
+		),
+		onuLoopDetected: prometheus.NewDesc(
+			name("onu_loop_detected"),
+			"Whether the OLT detected a loop behind the ONU (1) or not (0).",
+			identityLabels, nil,
+		),
+		onuEncryptionEnabled: prometheus.NewDesc(
+			name("onu_encryption_enabled"),
+			"Whether the downstream GPON (AES) encryption of the ONU is active (1) or not (0).",
+			identityLabels, nil,
+		),
+		onuUpgradeState: prometheus.NewDesc(
+			name("onu_upgrade_state"),
+			"The software upgrade state of the ONU, as reported by the OLT.",
+			[]string{dedup.labelName(), "state", "maintenance"}, nil,
+		),
+		onuBatteryStatus: prometheus.NewDesc(
+			name("onu_battery_status"),
+			"The state of the backup battery of the ONU, as reported by the OLT.",
+			[]string{dedup.labelName(), "state", "maintenance"}, nil,
+		),
+		onuOnBattery: prometheus.NewDesc(
+			name("onu_on_battery"),
+			"Whether the ONU runs on its backup battery (1), i.e. lost mains power, or not (0).",
+			identityLabels, nil,
+		),
+		onuRegistrationFailReason: prometheus.NewDesc(
+			name("onu_last_registration_fail_reason"),
+			"The reason code of the last failed registration of the ONU, as reported by the OLT (1 when none failed).",
+			[]string{dedup.labelName(), "reason", "maintenance"}, nil,
+		),
+		onuLastOfflineReason: prometheus.NewDesc(
+			name("onu_last_offline_reason"),
+			"The reason code of the last time the ONU went offline, as reported by the OLT (1=Unknown, 2=LOS, 3=LOSi, 4=LOFi, 5=sfi, 6=loai, 7=loami, 8=AuthFail, 9=PowerOff, 10=deactiveSucc, 11=deactiveFail, 12=Reboot, 13=Shutdown, 0=Other). The text is the offline_reason label of the mapping info.",
+			identityLabels, nil,
+		),
+		onuTcontCount: prometheus.NewDesc(
+			name("onu_tcont_count"),
+			"The number of upstream T-CONTs allocated to the ONU.",
+			identityLabels, nil,
+		),
+		onuTcontInfo: prometheus.NewDesc(
+			name("onu_tcont_info"),
+			"The allocation of an upstream T-CONT of the ONU: its alloc-id, DBA profile and number of GEM ports.",
+			[]string{dedup.labelName(), "tcont", "alloc_id", "profile", "gem_ports", "maintenance"}, nil,
+		),
+		onuUniSpeed: prometheus.NewDesc(
+			name("onu_uni_speed_mbps"),
+			"The negotiated speed of an Ethernet (UNI) port of the ONU in Mbps.",
+			[]string{dedup.labelName(), "port", "maintenance"}, nil,
+		),
+		onuUniDuplex: prometheus.NewDesc(
+			name("onu_uni_duplex"),
+			"The negotiated duplex mode of an Ethernet (UNI) port of the ONU (1 = full, 0 = half).",
+			[]string{dedup.labelName(), "port", "maintenance"}, nil,
+		),
+		onuQosProfileInfo: prometheus.NewDesc(
+			name("onu_qos_profile_info"),
+			"The QoS profile name or configured priority of the ONU, as reported by the OLT.",
+			[]string{dedup.labelName(), "qos_profile", "maintenance"}, nil,
+		),
+		exporterOnuProcessedOrder: prometheus.NewDesc(
+			name("exporter_onu_processed_order"),
+			"The position of the ONU in the order the scrape processed the ONUs, starting at 1 (debugging aid).",
+			[]string{dedup.labelName()}, nil,
+		),
+		onuDetailFetchDuration: prometheus.NewDesc(
+			name("onu_detail_fetch_duration_seconds"),
+			"How long fetching the details of the ONU, or of its whole PON when read in one pass, from the OLT took during the scrape, failed fetches included (debugging aid).",
+			[]string{dedup.labelName()}, nil,
+		),
+		ponUp: prometheus.NewDesc(
+			name("pon_up"),
+			"Whether the ONU discovery of the PON succeeded (1) or failed or was skipped by the circuit breaker (0).",
+			[]string{"board", "pon"}, nil,
+		),
+		onuStatusCount: prometheus.NewDesc(
+			name("onu_status_count"),
+			"Number of ONUs in the status (Online, LOS, Dying Gasp, Power-Off or Other) across the OLT, counted once per ONU identity.",
+			[]string{"status"}, nil,
+		),
+		ponUsedOnuSlots: prometheus.NewDesc(
+			name("pon_used_onu_slots"),
+			"Number of ONU IDs in use on the PON, as found by the ONU discovery.",
+			[]string{"board", "pon"}, nil,
+		),
+		ponEmptyOnuSlots: prometheus.NewDesc(
+			name("pon_empty_onu_slots"),
+			"Number of ONU IDs still available on the PON, out of the ONU IDs it is provisioned for.",
+			[]string{"board", "pon"}, nil,
+		),
+		ponPortTxPower: prometheus.NewDesc(
+			name("pon_port_tx_power_dbm"),
+			"The transmit power of the OLT PON port, in dBm.",
+			[]string{"board", "pon"}, nil,
+		),
+		ponPortStatus: prometheus.NewDesc(
+			name("pon_port_status"),
+			"Whether the OLT PON port is operationally up (1) or not (0).",
+			[]string{"board", "pon"}, nil,
+		),
+	}
+}
+
+// describe sends every metric description to ch.
+func (d *metricDescs) describe(ch chan<- *prometheus.Desc) {
+	ch <- d.onuStatus
+	ch <- d.onuMappingInfo
+	ch <- d.onuRxPower
+	ch <- d.onuTxPower
+	ch <- d.onuPowerReadingInvalid
+	ch <- d.onuUptime
+	ch <- d.onuLastDownDuration
+	ch <- d.onuLastOnline
+	ch <- d.onuLastOffline
+	ch <- d.onuRegistered
+	ch <- d.onuGponOpticalDistance
+	ch <- d.onuGponOpticalDistanceFeet
+	ch <- d.onuMultiLocation
+	ch <- d.onuDownstreamThroughput
+	ch <- d.onuUpstreamThroughput
+	ch <- d.onuRxDropped
+	ch <- d.onuTxDropped
+	ch <- d.onuSignalQuality
+	ch <- d.onuTemperature
+	ch <- d.onuVoltage
+	ch <- d.onuBiasCurrent
+	ch <- d.onuMulticastEnabled
+	ch <- d.onuMulticastGroups
+	ch <- d.onuCatvStatus
+	ch <- d.onuCatvLevel
+	ch <- d.onuNegotiatedDownstreamRate
+	ch <- d.onuNegotiatedUpstreamRate
+	ch <- d.onuAlarmCount
+	ch <- d.onuLoopDetected
+	ch <- d.onuEncryptionEnabled
+	ch <- d.onuUpgradeState
+	ch <- d.onuBatteryStatus
+	ch <- d.onuOnBattery
+	ch <- d.onuRegistrationFailReason
+	ch <- d.onuLastOfflineReason
+	ch <- d.onuTcontCount
+	ch <- d.onuTcontInfo
+	ch <- d.onuUniSpeed
+	ch <- d.onuUniDuplex
+	ch <- d.onuQosProfileInfo
+	ch <- d.exporterOnuProcessedOrder
+	ch <- d.onuDetailFetchDuration
+	ch <- d.ponUp
+	ch <- d.onuStatusCount
+	ch <- d.ponUsedOnuSlots
+	ch <- d.ponEmptyOnuSlots
+	ch <- d.ponPortTxPower
+	ch <- d.ponPortStatus
+}
+
+// internalMetricDescs holds the descriptions of the exporter's own operational metrics.
+type internalMetricDescs struct {
+	// exporterPonsSkipped counts PON discoveries skipped by the circuit breaker.
+	exporterPonsSkipped *prometheus.Desc
+
+	// exporterPonsOpenCircuit describes the number of PONs currently skipped by the circuit breaker.
+	exporterPonsOpenCircuit *prometheus.Desc
+
+	// exporterLastScrapeDuration describes how long the last completed scrape took.
+	exporterLastScrapeDuration *prometheus.Desc
+
+	// exporterLastScrapeOnus describes the number of ONUs processed by the last completed scrape.
+	exporterLastScrapeOnus *prometheus.Desc
+
+	// exporterScrapeDuration describes how long the scrape took, the last completed one when served
+	// apart from the ONU metrics.
+	exporterScrapeDuration *prometheus.Desc
+
+	// exporterScrapeSuccess describes whether the scrape discovered at least one PON and fetched
+	// the details of every ONU in time.
+	exporterScrapeSuccess *prometheus.Desc
+
+	// exporterPonScrapeErrors counts the failed discoveries of every PON.
+	exporterPonScrapeErrors *prometheus.Desc
+
+	// exporterCardinalityGuardTripped describes whether the last scrape skipped the mapping info
+	// because it exceeded the configured number of series.
+	exporterCardinalityGuardTripped *prometheus.Desc
+
+	// exporterEffectiveConcurrency describes the number of ONUs currently fetched at the same time.
+	exporterEffectiveConcurrency *prometheus.Desc
+
+	// scrapeCacheAge describes how long ago the cached ONU metrics were read from the OLT.
+	scrapeCacheAge *prometheus.Desc
+}
+
+// newInternalMetricDescs builds the internal metric descriptions, prefixed like newMetricDescs.
+func newInternalMetricDescs(vendorPrefix string) *internalMetricDescs {
+	name := func(metric string) string {
+		return prometheus.BuildFQName(vendorPrefix, "", metric)
+	}
+
+	return &internalMetricDescs{
+		exporterPonsSkipped: prometheus.NewDesc(
+			name("exporter_pons_skipped_total"),
+			"Total number of PON discoveries skipped because the PON circuit breaker was open.",
+			nil, nil,
+		),
+		exporterPonsOpenCircuit: prometheus.NewDesc(
+			name("exporter_pons_open_circuit"),
+			"Number of PONs currently skipped because of repeated discovery failures.",
+			nil, nil,
+		),
+		exporterLastScrapeDuration: prometheus.NewDesc(
+			name("exporter_last_scrape_duration_seconds"),
+			"Duration of the last completed ONU scrape in seconds.",
+			nil, nil,
+		),
+		exporterLastScrapeOnus: prometheus.NewDesc(
+			name("exporter_last_scrape_onus"),
+			"Number of ONUs processed by the last completed ONU scrape.",
+			nil, nil,
+		),
+		exporterScrapeDuration: prometheus.NewDesc(
+			name("exporter_scrape_duration_seconds"),
+			"Duration of the ONU scrape in seconds.",
+			nil, nil,
+		),
+		exporterScrapeSuccess: prometheus.NewDesc(
+			name("exporter_scrape_success"),
+			"Whether the ONU scrape discovered at least one PON and fetched every ONU before the scrape timeout (1) or not (0). Failed PONs are counted by exporter_pon_scrape_errors_total.",
+			nil, nil,
+		),
+		exporterPonScrapeErrors: prometheus.NewDesc(
+			name("exporter_pon_scrape_errors_total"),
+			"Total number of failed ONU discoveries of the PON.",
+			[]string{"board", "pon"}, nil,
+		),
+		exporterCardinalityGuardTripped: prometheus.NewDesc(
+			name("exporter_cardinality_guard_tripped"),
+			"Whether the last ONU scrape skipped the mapping info series because there were more than PROMETHEUS_MAPPING_INFO_LIMIT (1) or not (0).",
+			nil, nil,
+		),
+		exporterEffectiveConcurrency: prometheus.NewDesc(
+			name("exporter_effective_concurrency"),
+			"Number of ONUs whose details are currently allowed to be fetched at the same time.",
+			nil, nil,
+		),
+		scrapeCacheAge: prometheus.NewDesc(
+			name("scrape_cache_age_seconds"),
+			"Seconds since the cached ONU metrics were read from the OLT, only reported with PROMETHEUS_CACHE_TTL.",
+			nil, nil,
+		),
+	}
+}
+
+// describe sends every internal metric description to ch.
+func (d *internalMetricDescs) describe(ch chan<- *prometheus.Desc) {
+	ch <- d.exporterPonsSkipped
+	ch <- d.exporterPonsOpenCircuit
+	ch <- d.exporterLastScrapeDuration
+	ch <- d.exporterLastScrapeOnus
+	ch <- d.exporterScrapeDuration
+	ch <- d.exporterScrapeSuccess
+	ch <- d.exporterPonScrapeErrors
+	ch <- d.exporterCardinalityGuardTripped
+	ch <- d.exporterEffectiveConcurrency
+	ch <- d.scrapeCacheAge
+}
+
+// oltMetricDescs holds the metric descriptions of the OLT chassis itself.
+type oltMetricDescs struct {
+	// uplinkAdmin describes the administrative status of an uplink port.
+	uplinkAdmin *prometheus.Desc
+
+	// uplinkOper describes the operational status of an uplink port.
+	uplinkOper *prometheus.Desc
+
+	// uplinkBytes counts the bytes transferred by an uplink port.
+	uplinkBytes *prometheus.Desc
+
+	// ponTxPower describes the downstream transmit power of a PON port.
+	ponTxPower *prometheus.Desc
+
+	// ponTransceiverTemperature describes the temperature of the optical module of a PON port.
+	ponTransceiverTemperature *prometheus.Desc
+
+	// ponTransceiverTxPower describes the transmit power of the optical module of a PON port.
+	ponTransceiverTxPower *prometheus.Desc
+
+	// ponTransceiverRxPower describes the receive power of the optical module of a PON port.
+	ponTransceiverRxPower *prometheus.Desc
+
+	// ponTransceiverBias describes the laser bias current of the optical module of a PON port.
+	ponTransceiverBias *prometheus.Desc
+
+	// ponTransceiverVoltage describes the supply voltage of the optical module of a PON port.
+	ponTransceiverVoltage *prometheus.Desc
+
+	// psuStatus describes the status of a power supply unit of the chassis.
+	psuStatus *prometheus.Desc
+
+	// uptime describes the time since the OLT agent started, in seconds.
+	uptime *prometheus.Desc
+
+	// cpuUsage describes the CPU load of the OLT as a ratio.
+	cpuUsage *prometheus.Desc
+
+	// memoryUsage describes the memory utilization of the OLT as a ratio.
+	memoryUsage *prometheus.Desc
+
+	// temperature describes the temperature of the OLT chassis.
+	temperature *prometheus.Desc
+}
+
+// newOltMetricDescs builds the OLT metric descriptions, prefixing every metric name with vendorPrefix.
+func newOltMetricDescs(vendorPrefix string) *oltMetricDescs {
+	name := func(metric string) string {
+		return prometheus.BuildFQName(vendorPrefix, "", metric)
+	}
+
+	return &oltMetricDescs{
+		uplinkAdmin: prometheus.NewDesc(
+			name("olt_uplink_admin"),
+			"Whether the uplink port is administratively up (1) or not (0).",
+			[]string{"port"}, nil,
+		),
+		uplinkOper: prometheus.NewDesc(
+			name("olt_uplink_oper"),
+			"Whether the uplink port is operationally up (1) or not (0).",
+			[]string{"port"}, nil,
+		),
+		uplinkBytes: prometheus.NewDesc(
+			name("olt_uplink_bytes_total"),
+			"The number of bytes received (in) or sent (out) by the uplink port.",
+			[]string{"port", "direction"}, nil,
+		),
+		ponTxPower: prometheus.NewDesc(
+			name("pon_tx_power_dbm"),
+			"The downstream transmit power of the OLT toward the PON, in dBm.",
+			[]string{"board", "pon"}, nil,
+		),
+		ponTransceiverTemperature: prometheus.NewDesc(
+			name("pon_transceiver_temperature_celsius"),
+			"The temperature of the optical module of the OLT PON port, in degrees Celsius.",
+			[]string{"board", "pon"}, nil,
+		),
+		ponTransceiverTxPower: prometheus.NewDesc(
+			name("pon_transceiver_tx_power_dbm"),
+			"The transmit power of the optical module of the OLT PON port, in dBm.",
+			[]string{"board", "pon"}, nil,
+		),
+		ponTransceiverRxPower: prometheus.NewDesc(
+			name("pon_transceiver_rx_power_dbm"),
+			"The receive power of the optical module of the OLT PON port, in dBm.",
+			[]string{"board", "pon"}, nil,
+		),
+		ponTransceiverBias: prometheus.NewDesc(
+			name("pon_transceiver_bias_milliamperes"),
+			"The laser bias current of the optical module of the OLT PON port, in mA.",
+			[]string{"board", "pon"}, nil,
+		),
+		ponTransceiverVoltage: prometheus.NewDesc(
+			name("pon_transceiver_voltage_volts"),
+			"The supply voltage of the optical module of the OLT PON port, in volts.",
+			[]string{"board", "pon"}, nil,
+		),
+		psuStatus: prometheus.NewDesc(
+			name("olt_psu_status"),
+			"Whether the power supply unit of the OLT chassis is ok (1) or faulty (0).",
+			[]string{"psu"}, nil,
+		),
+		uptime: prometheus.NewDesc(
+			name("olt_uptime_seconds"),
+			"The time since the SNMP agent of the OLT started, in seconds.",
+			nil, nil,
+		),
+		cpuUsage: prometheus.NewDesc(
+			name("olt_cpu_usage_ratio"),
+			"The CPU load of the OLT, from 0 to 1.",
+			nil, nil,
+		),
+		memoryUsage: prometheus.NewDesc(
+			name("olt_memory_usage_ratio"),
+			"The memory utilization of the OLT, from 0 to 1.",
+			nil, nil,
+		),
+		temperature: prometheus.NewDesc(
+			name("olt_temperature_celsius"),
+			"The temperature of the OLT chassis, in degrees Celsius.",
+			nil, nil,
+		),
+	}
+}
+
+// describe sends every OLT metric description to ch.
+func (d *oltMetricDescs) describe(ch chan<- *prometheus.Desc) {
+	ch <- d.uplinkAdmin
+	ch <- d.uplinkOper
+	ch <- d.uplinkBytes
+	ch <- d.ponTxPower
+	ch <- d.ponTransceiverTemperature
+	ch <- d.ponTransceiverTxPower
+	ch <- d.ponTransceiverRxPower
+	ch <- d.ponTransceiverBias
+	ch <- d.ponTransceiverVoltage
+	ch <- d.psuStatus
+	ch <- d.uptime
+	ch <- d.cpuUsage
+	ch <- d.memoryUsage
+	ch <- d.temperature
+}
@@ -0,0 +1,58 @@
+package exporter
+
+import (
+	"sync"
+	"time"
+)
+
+// flapState is the status of an ONU seen by the previous scrape and when it last changed.
+type flapState struct {
+	status   string
+	lastFlap time.Time // Zero until the status changes between two scrapes
+	seen     time.Time
+}
+
+// flapTracker remembers the discovered status of every ONU across scrapes to tell which ones
+// flapped recently.
+type flapTracker struct {
+	mu     sync.Mutex
+	window time.Duration // How long an ONU counts as flapping after its status changed
+	states map[string]flapState
+}
+
+// newFlapTracker creates an empty flapTracker with the given window.
+func newFlapTracker(window time.Duration) *flapTracker {
+	return &flapTracker{window: window, states: make(map[string]flapState)}
+}
+
+// observe records the status of identity and reports since when it has been flapping. ok is
+// false when the status has not changed within the window.
+func (t *flapTracker) observe(identity, status string, at time.Time) (lastFlap time.Time, ok bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state, exists := t.states[identity]
+	if exists && state.status != status {
+		state.lastFlap = at
+	}
+	state.status = status
+	state.seen = at
+	t.states[identity] = state
+
+	if state.lastFlap.IsZero() || at.Sub(state.lastFlap) > t.window {
+		return time.Time{}, false
+	}
+	return state.lastFlap, true
+}
+
+// prune forgets ONUs not seen since cutoff, e.g. ONUs that were removed from the OLT.
+func (t *flapTracker) prune(cutoff time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for identity, state := range t.states {
+		if state.seen.Before(cutoff) {
+			delete(t.states, identity)
+		}
+	}
+}
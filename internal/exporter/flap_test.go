@@ -0,0 +1,79 @@
+package exporter
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlapTracker(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tracker := newFlapTracker(10 * time.Minute)
+
+	_, ok := tracker.observe("onu", "Online", start)
+	assert.False(t, ok, "first observation is not a flap")
+
+	_, ok = tracker.observe("onu", "Online", start.Add(time.Minute))
+	assert.False(t, ok, "unchanged status is not a flap")
+
+	lastFlap, ok := tracker.observe("onu", "LOS", start.Add(2*time.Minute))
+	assert.True(t, ok)
+	assert.Equal(t, start.Add(2*time.Minute), lastFlap)
+
+	_, ok = tracker.observe("onu", "LOS", start.Add(5*time.Minute))
+	assert.True(t, ok, "still flapping within the window")
+
+	_, ok = tracker.observe("onu", "LOS", start.Add(13*time.Minute))
+	assert.False(t, ok, "window elapsed since the last flap")
+
+	tracker.prune(start.Add(14 * time.Minute))
+	assert.Empty(t, tracker.states)
+}
+
+func TestCollectPrioritizesFlappingOnus(t *testing.T) {
+	t.Setenv("PROMETHEUS_PRIORITIZE_FLAPPING", "true")
+
+	usecase := newFakeOnuUsecase()
+	for id := 1; id <= 10; id++ {
+		usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: id, SerialNumber: fmt.Sprintf("ZTEGC%07d", id), Status: "Online"})
+	}
+	collector := newTestCollector(t, usecase)
+	gatherMetrics(t, collector)
+
+	// Two ONUs go down between scrapes, the second one more recently.
+	setStatus := func(id int, status string) {
+		usecase.discovered["1/1"][id-1].Status = status
+	}
+	setStatus(4, "LOS")
+	gatherMetrics(t, collector)
+	setStatus(7, "DyingGasp")
+	gatherMetrics(t, collector)
+
+	uniqueOnus := make(map[string]model.ONUInfoPerBoard)
+	for _, onu := range usecase.discovered["1/1"] {
+		uniqueOnus[collector.dedupKey.identity(onu)] = onu
+	}
+	queue := collector.queueOrder(uniqueOnus, time.Now())
+	require.Len(t, queue, 10)
+	assert.Equal(t, 7, queue[0].ID)
+	assert.Equal(t, 4, queue[1].ID)
+	for _, onu := range queue[2:] {
+		assert.NotContains(t, []int{4, 7}, onu.ID, "stable ONUs are queued after the flapping ones")
+	}
+}
+
+func TestQueueOrderWithoutPrioritization(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	collector := newTestCollector(t, usecase)
+
+	uniqueOnus := map[string]model.ONUInfoPerBoard{
+		"ZTEGC0000001": {Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online"},
+		"ZTEGC0000002": {Board: 1, PON: 1, ID: 2, SerialNumber: "ZTEGC0000002", Status: "LOS"},
+	}
+	assert.Len(t, collector.queueOrder(uniqueOnus, time.Now()), 2)
+	assert.Empty(t, collector.flaps.states, "statuses are not tracked unless prioritization is enabled")
+}
@@ -0,0 +1,106 @@
+package exporter
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// internalCollector exposes the operational metrics of an OnuCollector, such as the circuit breaker
+// state and the duration of the last scrape, so they can be served apart from the ONU metrics.
+type internalCollector struct {
+	onu *OnuCollector
+}
+
+// InternalCollector returns a collector of the exporter's own metrics. With
+// PROMETHEUS_SEPARATE_INTERNAL_METRICS=true these are left out of the OnuCollector and should be
+// registered on a dedicated registry instead.
+func (c *OnuCollector) InternalCollector() prometheus.Collector {
+	return &internalCollector{onu: c}
+}
+
+// SeparateInternalMetrics reports whether the internal metrics are left out of the OnuCollector.
+func (c *OnuCollector) SeparateInternalMetrics() bool {
+	return c.separateInternal
+}
+
+// Describe sends the descriptions of the internal metrics.
+func (c *internalCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.onu.internalDescs.describe(ch)
+}
+
+// Collect sends the current internal metrics, without scraping the OLT.
+func (c *internalCollector) Collect(ch chan<- prometheus.Metric) {
+	c.onu.collectInternal(ch)
+}
+
+// ponErrorCounter counts the failed discoveries of every PON since startup.
+type ponErrorCounter struct {
+	mu     sync.Mutex
+	counts map[ponKey]int
+}
+
+func newPonErrorCounter() *ponErrorCounter {
+	return &ponErrorCounter{counts: make(map[ponKey]int)}
+}
+
+// inc records a failed discovery of the PON.
+func (p *ponErrorCounter) inc(key ponKey) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.counts[key]++
+}
+
+// each calls fn with the count of every PON that failed at least once, by board and PON.
+func (p *ponErrorCounter) each(fn func(key ponKey, count int)) {
+	p.mu.Lock()
+	keys := make([]ponKey, 0, len(p.counts))
+	for key := range p.counts {
+		keys = append(keys, key)
+	}
+	counts := make(map[ponKey]int, len(p.counts))
+	for key, count := range p.counts {
+		counts[key] = count
+	}
+	p.mu.Unlock()
+
+	slices.SortFunc(keys, func(a, b ponKey) int { return cmp.Or(cmp.Compare(a.board, b.board), cmp.Compare(a.pon, b.pon)) })
+	for _, key := range keys {
+		fn(key, counts[key])
+	}
+}
+
+// collectInternal sends the circuit breaker state, the statistics of the last completed scrape, the
+// current fetch concurrency and, with PROMETHEUS_CACHE_TTL, the age of the cached metrics.
+func (c *OnuCollector) collectInternal(ch chan<- prometheus.Metric) {
+	openCircuits, skippedTotal := c.breaker.stats()
+	ch <- prometheus.MustNewConstMetric(c.internalDescs.exporterPonsOpenCircuit, prometheus.GaugeValue, float64(openCircuits))
+	ch <- prometheus.MustNewConstMetric(c.internalDescs.exporterPonsSkipped, prometheus.CounterValue, float64(skippedTotal))
+
+	duration := time.Duration(c.lastScrapeDuration.Load())
+	ch <- prometheus.MustNewConstMetric(c.internalDescs.exporterLastScrapeDuration, prometheus.GaugeValue, duration.Seconds())
+	ch <- prometheus.MustNewConstMetric(c.internalDescs.exporterScrapeDuration, prometheus.GaugeValue, duration.Seconds())
+	success := 0.0
+	if c.lastScrapeSuccess.Load() {
+		success = 1
+	}
+	ch <- prometheus.MustNewConstMetric(c.internalDescs.exporterScrapeSuccess, prometheus.GaugeValue, success)
+	c.ponErrors.each(func(key ponKey, count int) {
+		ch <- prometheus.MustNewConstMetric(c.internalDescs.exporterPonScrapeErrors, prometheus.CounterValue, float64(count), strconv.Itoa(key.board), strconv.Itoa(key.pon))
+	})
+	ch <- prometheus.MustNewConstMetric(c.internalDescs.exporterLastScrapeOnus, prometheus.GaugeValue, float64(c.lastScrapeOnus.Load()))
+
+	tripped := 0.0
+	if c.guardTripped.Load() {
+		tripped = 1
+	}
+	ch <- prometheus.MustNewConstMetric(c.internalDescs.exporterCardinalityGuardTripped, prometheus.GaugeValue, tripped)
+	ch <- prometheus.MustNewConstMetric(c.internalDescs.exporterEffectiveConcurrency, prometheus.GaugeValue, float64(c.concurrency.current()))
+	if age, ok := c.cache.age(); ok {
+		ch <- prometheus.MustNewConstMetric(c.internalDescs.scrapeCacheAge, prometheus.GaugeValue, age.Seconds())
+	}
+}
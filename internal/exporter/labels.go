@@ -0,0 +1,93 @@
+package exporter
+
+import (
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// mappingLabels holds the free-text labels of zte_onu_mapping_info.
+type mappingLabels struct {
+	name            string
+	onuType         string
+	description     string
+	offlineReason   string
+	equipmentID     string
+	template        string
+	transceiverType string
+}
+
+// labelCacheEntry holds the sanitized labels of a serial and the raw values they came from.
+type labelCacheEntry struct {
+	raw       mappingLabels
+	sanitized mappingLabels
+	seen      time.Time
+}
+
+// labelCache remembers the sanitized mapping labels per serial number, so steady-state scrapes
+// skip re-processing values that did not change since the previous scrape.
+type labelCache struct {
+	mu       sync.Mutex
+	sanitize func(string) string
+	entries  map[string]labelCacheEntry
+}
+
+// newLabelCache creates an empty labelCache using sanitizeLabelValue.
+func newLabelCache() *labelCache {
+	return &labelCache{
+		sanitize: sanitizeLabelValue,
+		entries:  make(map[string]labelCacheEntry),
+	}
+}
+
+// get returns the sanitized labels of serialNumber, sanitizing raw again only when it differs
+// from the raw values cached for that serial.
+func (l *labelCache) get(serialNumber string, raw mappingLabels, at time.Time) mappingLabels {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry, ok := l.entries[serialNumber]
+	if !ok || entry.raw != raw {
+		entry = labelCacheEntry{
+			raw: raw,
+			sanitized: mappingLabels{
+				name:            l.sanitize(raw.name),
+				onuType:         l.sanitize(raw.onuType),
+				description:     l.sanitize(raw.description),
+				offlineReason:   l.sanitize(raw.offlineReason),
+				equipmentID:     l.sanitize(raw.equipmentID),
+				template:        l.sanitize(raw.template),
+				transceiverType: l.sanitize(raw.transceiverType),
+			},
+		}
+	}
+	entry.seen = at
+	l.entries[serialNumber] = entry
+	return entry.sanitized
+}
+
+// prune forgets serials last seen before cutoff, e.g. ONUs that were removed from the OLT.
+func (l *labelCache) prune(cutoff time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for serialNumber, entry := range l.entries {
+		if entry.seen.Before(cutoff) {
+			delete(l.entries, serialNumber)
+		}
+	}
+}
+
+// sanitizeLabelValue drops invalid UTF-8 and control characters, such as the NUL padding some
+// firmware returns in OCTET STRING values, and trims surrounding whitespace.
+func sanitizeLabelValue(value string) string {
+	value = strings.ToValidUTF8(value, "")
+	value = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, value)
+	return strings.TrimSpace(value)
+}
@@ -0,0 +1,61 @@
+package exporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizeLabelValue(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{name: "clean value", value: "customer-01", expected: "customer-01"},
+		{name: "NUL padding", value: "customer-01\x00\x00\x00", expected: "customer-01"},
+		{name: "surrounding whitespace", value: "  ZTE-F660 \r\n", expected: "ZTE-F660"},
+		{name: "invalid UTF-8", value: "jl. merdeka \xff\xfe5", expected: "jl. merdeka 5"},
+		{name: "empty", value: "", expected: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, sanitizeLabelValue(tc.value))
+		})
+	}
+}
+
+func TestLabelCacheReusesUnchangedLabels(t *testing.T) {
+	cache := newLabelCache()
+	calls := 0
+	cache.sanitize = func(value string) string {
+		calls++
+		return sanitizeLabelValue(value)
+	}
+	now := time.Now()
+	raw := mappingLabels{name: "customer-01\x00", onuType: "ZTE-F660", description: "jl. merdeka", offlineReason: "LOS", equipmentID: "ZTE-F660V5.2", template: "F660-BRIDGE", transceiverType: "GPON Class B+"}
+
+	labels := cache.get("ZTEGC0000001", raw, now)
+	assert.Equal(t, "customer-01", labels.name)
+	assert.Equal(t, 7, calls)
+
+	// Unchanged raw values reuse the cached labels.
+	assert.Equal(t, labels, cache.get("ZTEGC0000001", raw, now.Add(time.Minute)))
+	assert.Equal(t, 7, calls)
+
+	// A changed raw value invalidates the entry.
+	raw.offlineReason = "DyingGasp"
+	labels = cache.get("ZTEGC0000001", raw, now.Add(2*time.Minute))
+	assert.Equal(t, "DyingGasp", labels.offlineReason)
+	assert.Equal(t, 14, calls)
+
+	// Other serials are cached independently.
+	cache.get("ZTEGC0000002", raw, now)
+	assert.Equal(t, 21, calls)
+
+	cache.prune(now.Add(time.Minute))
+	assert.Len(t, cache.entries, 1)
+	assert.Contains(t, cache.entries, "ZTEGC0000001")
+}
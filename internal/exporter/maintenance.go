@@ -0,0 +1,71 @@
+package exporter
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// maintenanceScope holds the boards, PONs and serial numbers under planned maintenance. Their
+// metrics are still collected but carry maintenance="true", so alert rules can ignore them.
+type maintenanceScope struct {
+	boards  map[int]bool
+	pons    map[ponKey]bool
+	serials map[string]bool
+}
+
+// parseMaintenanceScope parses a comma-separated list of PONs ("1/3", or "2/*" for a whole board)
+// and a comma-separated list of serial numbers. Invalid PON entries are logged and ignored.
+func parseMaintenanceScope(pons, serials string) *maintenanceScope {
+	scope := &maintenanceScope{
+		boards:  make(map[int]bool),
+		pons:    make(map[ponKey]bool),
+		serials: make(map[string]bool),
+	}
+
+	for _, entry := range splitList(pons) {
+		boardStr, ponStr, found := strings.Cut(entry, "/")
+		board, err := strconv.Atoi(boardStr)
+		if !found || err != nil {
+			log.Warn().Str("entry", entry).Msg("Ignoring invalid maintenance PON, expected board/pon")
+			continue
+		}
+		if ponStr == "*" {
+			scope.boards[board] = true
+			continue
+		}
+		pon, err := strconv.Atoi(ponStr)
+		if err != nil {
+			log.Warn().Str("entry", entry).Msg("Ignoring invalid maintenance PON, expected board/pon")
+			continue
+		}
+		scope.pons[ponKey{board: board, pon: pon}] = true
+	}
+
+	for _, serialNumber := range splitList(serials) {
+		scope.serials[serialNumber] = true
+	}
+
+	return scope
+}
+
+// label returns the value of the maintenance label for an ONU: "true" when it is in scope and
+// empty otherwise, which Prometheus treats as an absent label so regular series are unchanged.
+func (m *maintenanceScope) label(board, pon int, serialNumber string) string {
+	if m.boards[board] || m.pons[ponKey{board: board, pon: pon}] || m.serials[serialNumber] {
+		return "true"
+	}
+	return ""
+}
+
+// splitList splits a comma-separated list, dropping blank entries.
+func splitList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,36 @@
+package exporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaintenanceScopeLabel(t *testing.T) {
+	scope := parseMaintenanceScope(" 1/3 ,2/*,invalid,1/x,", "ZTEGC0000001, ")
+
+	testCases := []struct {
+		name         string
+		board        int
+		pon          int
+		serialNumber string
+		expected     string
+	}{
+		{name: "configured PON", board: 1, pon: 3, serialNumber: "ZTEGC0000009", expected: "true"},
+		{name: "other PON of the same board", board: 1, pon: 4, serialNumber: "ZTEGC0000009", expected: ""},
+		{name: "whole board", board: 2, pon: 16, serialNumber: "ZTEGC0000009", expected: "true"},
+		{name: "configured serial on any PON", board: 1, pon: 1, serialNumber: "ZTEGC0000001", expected: "true"},
+		{name: "out of scope", board: 1, pon: 1, serialNumber: "ZTEGC0000009", expected: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, scope.label(tc.board, tc.pon, tc.serialNumber))
+		})
+	}
+}
+
+func TestMaintenanceScopeEmpty(t *testing.T) {
+	scope := parseMaintenanceScope("", "")
+	assert.Equal(t, "", scope.label(1, 1, "ZTEGC0000001"))
+}
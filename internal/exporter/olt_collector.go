@@ -0,0 +1,211 @@
+package exporter
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/usecase"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog/log"
+)
+
+// ifStatusUp is the IF-MIB ifAdminStatus/ifOperStatus value of an interface that is up.
+const ifStatusUp = 1
+
+// OltCollector implements the prometheus.Collector interface for metrics of the OLT chassis,
+// such as its uplink (NNI) ports, the transmit power of its PON ports, its power supplies and its
+// system readings.
+type OltCollector struct {
+	uplinkUsecase  usecase.UplinkUseCaseInterface
+	ponUsecase     usecase.PonUseCaseInterface
+	chassisUsecase usecase.ChassisUseCaseInterface
+	collectUplinks bool // Walk the uplink ports on every scrape
+	descs          *oltMetricDescs
+	cache          *scrapeCache // Metrics of the last scrape, served again until they are stale
+}
+
+// NewOltCollector creates a new OltCollector.
+func NewOltCollector(uplinkUsecase usecase.UplinkUseCaseInterface, ponUsecase usecase.PonUseCaseInterface, chassisUsecase usecase.ChassisUseCaseInterface) *OltCollector {
+	return &OltCollector{
+		uplinkUsecase:  uplinkUsecase,
+		ponUsecase:     ponUsecase,
+		chassisUsecase: chassisUsecase,
+		collectUplinks: envBool("PROMETHEUS_COLLECT_UPLINKS", false),
+		descs:          newOltMetricDescs(vendorPrefixFromEnv()),
+		cache:          newScrapeCache(envDuration("PROMETHEUS_CACHE_TTL", 0)),
+	}
+}
+
+// Describe sends the static descriptions of all metrics collected by the OLT collector.
+func (c *OltCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.descs.describe(ch)
+}
+
+// Collect fetches the OLT metrics and delivers them to Prometheus, or serves the cached metrics
+// of a recent scrape.
+func (c *OltCollector) Collect(ch chan<- prometheus.Metric) {
+	c.cache.collect(ch, c.collect)
+}
+
+// collect fetches the OLT metrics.
+func (c *OltCollector) collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), scrapeTimeout)
+	defer cancel()
+
+	c.collectSystemHealth(ctx, ch)
+	c.collectPonTxPowers(ctx, ch)
+	c.collectPonTransceivers(ctx, ch)
+	c.collectPowerSupplies(ctx, ch)
+	if c.collectUplinks {
+		c.collectUplinkPorts(ctx, ch)
+	}
+}
+
+// collectSystemHealth emits the uptime, CPU and memory usage and temperature of the OLT, each only
+// when its OID is configured and the OLT reports it. Usages are read in percent.
+func (c *OltCollector) collectSystemHealth(ctx context.Context, ch chan<- prometheus.Metric) {
+	health, err := c.chassisUsecase.GetSystemHealth(ctx)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to get system health")
+		return
+	}
+
+	readings := []struct {
+		desc  *prometheus.Desc
+		value string
+		scale float64
+	}{
+		{c.descs.uptime, health.Uptime, 1},
+		{c.descs.cpuUsage, health.CPUUsage, 0.01},
+		{c.descs.memoryUsage, health.MemoryUsage, 0.01},
+		{c.descs.temperature, health.Temperature, 1},
+	}
+	for _, reading := range readings {
+		if reading.value == "" {
+			continue
+		}
+		value, err := strconv.ParseFloat(reading.value, 64)
+		if err != nil {
+			log.Warn().Err(err).Str("value_str", reading.value).Msg("Could not parse system health reading")
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(reading.desc, prometheus.GaugeValue, value*reading.scale)
+	}
+}
+
+// collectPonTxPowers emits the transmit power of the PON ports whose OID is configured.
+func (c *OltCollector) collectPonTxPowers(ctx context.Context, ch chan<- prometheus.Metric) {
+	txPowers, err := c.ponUsecase.GetPonTxPowers(ctx)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to get PON tx powers")
+		return
+	}
+
+	for _, pon := range txPowers {
+		txPower, err := strconv.ParseFloat(pon.TxPower, 64)
+		if err != nil {
+			log.Warn().Err(err).Int("board", pon.Board).Int("pon", pon.PON).Str("tx_power_str", pon.TxPower).Msg("Could not parse PON tx power")
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(c.descs.ponTxPower, prometheus.GaugeValue, txPower, strconv.Itoa(pon.Board), strconv.Itoa(pon.PON))
+	}
+}
+
+// collectPonTransceivers emits the digital diagnostics of the optical modules of the PON ports,
+// each reading only when its OID is configured and the module reports it.
+func (c *OltCollector) collectPonTransceivers(ctx context.Context, ch chan<- prometheus.Metric) {
+	transceivers, err := c.ponUsecase.GetPonTransceivers(ctx)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to get PON transceivers")
+		return
+	}
+
+	for _, pon := range transceivers {
+		board, ponID := strconv.Itoa(pon.Board), strconv.Itoa(pon.PON)
+		readings := []struct {
+			desc  *prometheus.Desc
+			value string
+		}{
+			{c.descs.ponTransceiverTemperature, pon.Temperature},
+			{c.descs.ponTransceiverTxPower, pon.TxPower},
+			{c.descs.ponTransceiverRxPower, pon.RxPower},
+			{c.descs.ponTransceiverBias, pon.Bias},
+			{c.descs.ponTransceiverVoltage, pon.Voltage},
+		}
+		for _, reading := range readings {
+			if reading.value == "" {
+				continue
+			}
+			value, err := strconv.ParseFloat(reading.value, 64)
+			if err != nil {
+				log.Warn().Err(err).Int("board", pon.Board).Int("pon", pon.PON).Str("value_str", reading.value).Msg("Could not parse PON transceiver reading")
+				continue
+			}
+			ch <- prometheus.MustNewConstMetric(reading.desc, prometheus.GaugeValue, value, board, ponID)
+		}
+	}
+}
+
+// collectPowerSupplies emits the status of the power supplies, if the PSU status OID is configured.
+// Empty PSU slots are skipped, so a chassis with a single supply does not report a fault.
+func (c *OltCollector) collectPowerSupplies(ctx context.Context, ch chan<- prometheus.Metric) {
+	powerSupplies, err := c.chassisUsecase.GetPowerSupplies(ctx)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to get power supplies")
+		return
+	}
+
+	for _, psu := range powerSupplies {
+		var value float64
+		switch psu.Status {
+		case "ok":
+			value = 1
+		case "fault":
+			value = 0
+		case "absent":
+			continue
+		default:
+			log.Warn().Int("psu", psu.Index).Msg("Unknown PSU status")
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(c.descs.psuStatus, prometheus.GaugeValue, value, strconv.Itoa(psu.Index))
+	}
+}
+
+// collectUplinkPorts emits the status and traffic counters of the uplink ports.
+func (c *OltCollector) collectUplinkPorts(ctx context.Context, ch chan<- prometheus.Metric) {
+	uplinkPorts, err := c.uplinkUsecase.GetUplinkPorts(ctx)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to get uplink ports")
+		return
+	}
+
+	for _, port := range uplinkPorts {
+		ch <- prometheus.MustNewConstMetric(c.descs.uplinkAdmin, prometheus.GaugeValue, statusToNumeric(port.AdminStatus), port.Name)
+		ch <- prometheus.MustNewConstMetric(c.descs.uplinkOper, prometheus.GaugeValue, statusToNumeric(port.OperStatus), port.Name)
+		c.sendUplinkBytes(ch, port.Name, "in", port.InOctets)
+		c.sendUplinkBytes(ch, port.Name, "out", port.OutOctets)
+	}
+}
+
+// sendUplinkBytes emits the byte counter of one direction of an uplink port, if it could be read.
+func (c *OltCollector) sendUplinkBytes(ch chan<- prometheus.Metric, port, direction, octets string) {
+	if octets == "" {
+		return
+	}
+
+	value, err := strconv.ParseUint(octets, 10, 64)
+	if err != nil {
+		log.Warn().Err(err).Str("port", port).Str("octets_str", octets).Msg("Could not parse uplink counter")
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(c.descs.uplinkBytes, prometheus.CounterValue, float64(value), port, direction)
+}
+
+// statusToNumeric maps an IF-MIB status to 1 when up and 0 otherwise.
+func statusToNumeric(status int) float64 {
+	if status == ifStatusUp {
+		return 1
+	}
+	return 0
+}
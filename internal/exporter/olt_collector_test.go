@@ -0,0 +1,207 @@
+package exporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeUplinkUsecase is an in-memory UplinkUseCaseInterface.
+type fakeUplinkUsecase struct {
+	ports []model.UplinkPort
+	err   error
+}
+
+func (f *fakeUplinkUsecase) GetUplinkPorts(_ context.Context) ([]model.UplinkPort, error) {
+	return f.ports, f.err
+}
+
+// fakePonUsecase is an in-memory PonUseCaseInterface.
+type fakePonUsecase struct {
+	txPowers     []model.PonTxPower
+	transceivers []model.PonTransceiver
+	ports        map[string]model.PonPort // keyed by "board/pon"
+	err          error
+}
+
+func (f *fakePonUsecase) GetPonTxPowers(_ context.Context) ([]model.PonTxPower, error) {
+	return f.txPowers, f.err
+}
+
+func (f *fakePonUsecase) GetPonTransceivers(_ context.Context) ([]model.PonTransceiver, error) {
+	return f.transceivers, f.err
+}
+
+func (f *fakePonUsecase) GetPonPort(_ context.Context, boardID, ponID int) (model.PonPort, error) {
+	return f.ports[fmt.Sprintf("%d/%d", boardID, ponID)], f.err
+}
+
+// fakeChassisUsecase is an in-memory ChassisUseCaseInterface.
+type fakeChassisUsecase struct {
+	powerSupplies []model.PowerSupply
+	health        model.ChassisHealth
+	err           error
+}
+
+func (f *fakeChassisUsecase) GetPowerSupplies(_ context.Context) ([]model.PowerSupply, error) {
+	return f.powerSupplies, f.err
+}
+
+func (f *fakeChassisUsecase) GetSystemHealth(_ context.Context) (model.ChassisHealth, error) {
+	return f.health, f.err
+}
+
+func TestOltCollectorUplinks(t *testing.T) {
+	t.Setenv("PROMETHEUS_COLLECT_UPLINKS", "true")
+	collector := NewOltCollector(&fakeUplinkUsecase{ports: []model.UplinkPort{
+		{IfIndex: 268, Name: "gei_1/3/1", AdminStatus: 1, OperStatus: 1, InOctets: "18446744073709551615", OutOctets: "1024"},
+		{IfIndex: 270, Name: "xgei_1/4/1", AdminStatus: 1, OperStatus: 2, InOctets: "4096"},
+	}}, &fakePonUsecase{}, &fakeChassisUsecase{})
+
+	metrics := gatherMetrics(t, collector)
+
+	oper := make(map[string]float64)
+	for _, m := range metrics["zte_olt_uplink_oper"] {
+		oper[m.labels["port"]] = m.value
+	}
+	assert.Equal(t, map[string]float64{"gei_1/3/1": 1, "xgei_1/4/1": 0}, oper)
+	assert.Len(t, metrics["zte_olt_uplink_admin"], 2)
+
+	bytes := make(map[string]float64)
+	for _, m := range metrics["zte_olt_uplink_bytes_total"] {
+		bytes[m.labels["port"]+"/"+m.labels["direction"]] = m.value
+	}
+	assert.Equal(t, map[string]float64{
+		"gei_1/3/1/in":  18446744073709551615,
+		"gei_1/3/1/out": 1024,
+		"xgei_1/4/1/in": 4096,
+	}, bytes)
+}
+
+func TestOltCollectorUplinksDisabledOrFailing(t *testing.T) {
+	collector := NewOltCollector(&fakeUplinkUsecase{ports: []model.UplinkPort{{Name: "gei_1/3/1", OperStatus: 1}}}, &fakePonUsecase{}, &fakeChassisUsecase{})
+	assert.Empty(t, gatherMetrics(t, collector), "uplinks are not collected by default")
+
+	t.Setenv("PROMETHEUS_COLLECT_UPLINKS", "true")
+	collector = NewOltCollector(&fakeUplinkUsecase{err: errors.New("request timeout")}, &fakePonUsecase{}, &fakeChassisUsecase{})
+	require.Empty(t, gatherMetrics(t, collector))
+}
+
+func TestOltCollectorPonTxPower(t *testing.T) {
+	collector := NewOltCollector(&fakeUplinkUsecase{}, &fakePonUsecase{txPowers: []model.PonTxPower{
+		{Board: 1, PON: 1, TxPower: "3.12"},
+		{Board: 2, PON: 16, TxPower: "4.50"},
+		{Board: 2, PON: 15, TxPower: "N/A"},
+	}}, &fakeChassisUsecase{})
+
+	metrics := gatherMetrics(t, collector)
+
+	txPowers := make(map[string]float64)
+	for _, m := range metrics["zte_pon_tx_power_dbm"] {
+		txPowers[m.labels["board"]+"/"+m.labels["pon"]] = m.value
+	}
+	assert.Equal(t, map[string]float64{"1/1": 3.12, "2/16": 4.5}, txPowers, "unparseable values are skipped")
+
+	collector = NewOltCollector(&fakeUplinkUsecase{}, &fakePonUsecase{err: errors.New("request timeout")}, &fakeChassisUsecase{})
+	require.Empty(t, gatherMetrics(t, collector))
+}
+
+func TestOltCollectorPonTransceivers(t *testing.T) {
+	collector := NewOltCollector(&fakeUplinkUsecase{}, &fakePonUsecase{transceivers: []model.PonTransceiver{
+		{Board: 1, PON: 1, Temperature: "42.50", TxPower: "3.12", RxPower: "-18.25", Bias: "12.345", Voltage: "3.287"},
+		{Board: 2, PON: 16, Temperature: "38.00"},
+	}}, &fakeChassisUsecase{})
+
+	metrics := gatherMetrics(t, collector)
+
+	temperatures := make(map[string]float64)
+	for _, m := range metrics["zte_pon_transceiver_temperature_celsius"] {
+		temperatures[m.labels["board"]+"/"+m.labels["pon"]] = m.value
+	}
+	assert.Equal(t, map[string]float64{"1/1": 42.5, "2/16": 38}, temperatures)
+
+	for name, expected := range map[string]float64{
+		"zte_pon_transceiver_tx_power_dbm":      3.12,
+		"zte_pon_transceiver_rx_power_dbm":      -18.25,
+		"zte_pon_transceiver_bias_milliamperes": 12.345,
+		"zte_pon_transceiver_voltage_volts":     3.287,
+	} {
+		require.Len(t, metrics[name], 1, "%s is only reported where it was read", name)
+		assert.Equal(t, expected, metrics[name][0].value, name)
+		assert.Equal(t, "1", metrics[name][0].labels["pon"], name)
+	}
+}
+
+func TestOltCollectorPowerSupplies(t *testing.T) {
+	collector := NewOltCollector(&fakeUplinkUsecase{}, &fakePonUsecase{}, &fakeChassisUsecase{powerSupplies: []model.PowerSupply{
+		{Index: 1, Status: "ok"},
+		{Index: 2, Status: "fault"},
+		{Index: 3, Status: "absent"},
+		{Index: 4, Status: ""},
+	}})
+
+	metrics := gatherMetrics(t, collector)
+
+	psuStatus := make(map[string]float64)
+	for _, m := range metrics["zte_olt_psu_status"] {
+		psuStatus[m.labels["psu"]] = m.value
+	}
+	assert.Equal(t, map[string]float64{"1": 1, "2": 0}, psuStatus, "absent and unknown PSUs are skipped")
+
+	collector = NewOltCollector(&fakeUplinkUsecase{}, &fakePonUsecase{}, &fakeChassisUsecase{err: errors.New("request timeout")})
+	require.Empty(t, gatherMetrics(t, collector))
+}
+
+func TestOltCollectorSystemHealth(t *testing.T) {
+	collector := NewOltCollector(&fakeUplinkUsecase{}, &fakePonUsecase{}, &fakeChassisUsecase{health: model.ChassisHealth{
+		Uptime:      "8640012.34",
+		CPUUsage:    "27.00",
+		MemoryUsage: "64.50",
+		Temperature: "41.00",
+	}})
+
+	metrics := gatherMetrics(t, collector)
+
+	for name, expected := range map[string]float64{
+		"zte_olt_uptime_seconds":      8640012.34,
+		"zte_olt_cpu_usage_ratio":     0.27,
+		"zte_olt_memory_usage_ratio":  0.645,
+		"zte_olt_temperature_celsius": 41,
+	} {
+		require.Len(t, metrics[name], 1, name)
+		assert.InDelta(t, expected, metrics[name][0].value, 1e-9, name)
+	}
+
+	collector = NewOltCollector(&fakeUplinkUsecase{}, &fakePonUsecase{}, &fakeChassisUsecase{health: model.ChassisHealth{Uptime: "120.00"}})
+	metrics = gatherMetrics(t, collector)
+	assert.Len(t, metrics["zte_olt_uptime_seconds"], 1)
+	assert.Empty(t, metrics["zte_olt_cpu_usage_ratio"], "readings that were not read are not reported")
+	assert.Empty(t, metrics["zte_olt_temperature_celsius"])
+}
+
+// countingChassisUsecase counts the reads of the system health
+type countingChassisUsecase struct {
+	fakeChassisUsecase
+	healthReads int
+}
+
+func (f *countingChassisUsecase) GetSystemHealth(ctx context.Context) (model.ChassisHealth, error) {
+	f.healthReads++
+	return f.fakeChassisUsecase.GetSystemHealth(ctx)
+}
+
+func TestOltCollectorCacheTTL(t *testing.T) {
+	t.Setenv("PROMETHEUS_CACHE_TTL", "1m")
+	chassis := &countingChassisUsecase{fakeChassisUsecase: fakeChassisUsecase{health: model.ChassisHealth{Uptime: "3600"}}}
+	collector := NewOltCollector(&fakeUplinkUsecase{}, &fakePonUsecase{}, chassis)
+
+	for scrape := 0; scrape < 3; scrape++ {
+		assert.Len(t, gatherMetrics(t, collector)["zte_olt_uptime_seconds"], 1)
+	}
+	assert.Equal(t, 1, chassis.healthReads, "scrapes within the TTL do not read the OLT")
+}
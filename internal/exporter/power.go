@@ -0,0 +1,104 @@
+package exporter
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Directions of the optical power readings of an ONU.
+const (
+	powerDirectionRx = "rx"
+	powerDirectionTx = "tx"
+)
+
+// powerRange bounds the optical power readings reported as zte_onu_rx_power_dbm and
+// zte_onu_tx_power_dbm. Readings outside of it, such as the sentinel values some firmware returns
+// for a missing transceiver, are counted as invalid instead.
+type powerRange struct {
+	min float64 // Lowest valid reading, in dBm
+	max float64 // Highest valid reading, in dBm
+}
+
+// parsePowerRange returns the range between min and max, falling back to -50 to 20 dBm, well beyond
+// what a GPON transceiver sends or receives, when it is empty.
+func parsePowerRange(min, max float64) powerRange {
+	if min > max {
+		log.Warn().Float64("min", min).Float64("max", max).Msg("Ignoring empty power range, POWER_MIN_DBM is above POWER_MAX_DBM")
+		return powerRange{min: -50, max: 20}
+	}
+	return powerRange{min: min, max: max}
+}
+
+// contains reports whether the reading is within the range, bounds included.
+func (r powerRange) contains(dbm float64) bool {
+	return dbm >= r.min && dbm <= r.max
+}
+
+// invalidPowerKey identifies the invalid power counter of an ONU and direction.
+type invalidPowerKey struct {
+	identity  string
+	direction string
+}
+
+// invalidPowerCount is the number of invalid power readings of an ONU and direction, and when the
+// last one was read.
+type invalidPowerCount struct {
+	count int
+	seen  time.Time
+}
+
+// invalidPowerCounter counts the power readings outside of the valid range per ONU and direction.
+type invalidPowerCounter struct {
+	mu     sync.Mutex
+	counts map[invalidPowerKey]invalidPowerCount
+}
+
+func newInvalidPowerCounter() *invalidPowerCounter {
+	return &invalidPowerCounter{counts: make(map[invalidPowerKey]invalidPowerCount)}
+}
+
+// inc records an invalid reading of the ONU in the given direction.
+func (p *invalidPowerCounter) inc(identity, direction string, at time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	key := invalidPowerKey{identity: identity, direction: direction}
+	p.counts[key] = invalidPowerCount{count: p.counts[key].count + 1, seen: at}
+}
+
+// each calls fn with the count of every ONU and direction that had an invalid reading, sorted so
+// the output of a scrape is stable across runs.
+func (p *invalidPowerCounter) each(fn func(key invalidPowerKey, count int)) {
+	p.mu.Lock()
+	counts := make(map[invalidPowerKey]int, len(p.counts))
+	for key, entry := range p.counts {
+		counts[key] = entry.count
+	}
+	p.mu.Unlock()
+
+	keys := make([]invalidPowerKey, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b invalidPowerKey) int {
+		return cmp.Or(cmp.Compare(a.identity, b.identity), cmp.Compare(a.direction, b.direction))
+	})
+	for _, key := range keys {
+		fn(key, counts[key])
+	}
+}
+
+// prune forgets the counters of ONUs without an invalid reading since cutoff.
+func (p *invalidPowerCounter) prune(cutoff time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for key, entry := range p.counts {
+		if entry.seen.Before(cutoff) {
+			delete(p.counts, key)
+		}
+	}
+}
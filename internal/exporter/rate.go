@@ -0,0 +1,60 @@
+package exporter
+
+import (
+	"sync"
+	"time"
+)
+
+// counterSample is a counter value observed at a point in time.
+type counterSample struct {
+	value uint64
+	at    time.Time
+}
+
+// counterRateTracker turns raw SNMP counters into per-second rates across scrapes.
+type counterRateTracker struct {
+	mu      sync.Mutex
+	samples map[string]counterSample
+}
+
+// newCounterRateTracker creates an empty counterRateTracker.
+func newCounterRateTracker() *counterRateTracker {
+	return &counterRateTracker{samples: make(map[string]counterSample)}
+}
+
+// rate records value for key and returns the per-second increase since the previous sample.
+// ok is false on the first sample, when no time has elapsed, or when the counter went
+// backwards (ONU reboot or counter reset), since no meaningful rate exists in those cases.
+func (t *counterRateTracker) rate(key string, value uint64, at time.Time) (perSecond float64, ok bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	previous, exists := t.samples[key]
+	t.samples[key] = counterSample{value: value, at: at}
+	if !exists || value < previous.value {
+		return 0, false
+	}
+
+	elapsed := at.Sub(previous.at).Seconds()
+	if elapsed <= 0 {
+		return 0, false
+	}
+	return float64(value-previous.value) / elapsed, true
+}
+
+// prune forgets samples older than cutoff, e.g. of ONUs that were removed from the OLT.
+func (t *counterRateTracker) prune(cutoff time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for key, sample := range t.samples {
+		if sample.at.Before(cutoff) {
+			delete(t.samples, key)
+		}
+	}
+}
+
+// octetsRateToKbps converts a rate in octets per second to kilobits per second.
+func octetsRateToKbps(octetsPerSecond float64) float64 {
+	return octetsPerSecond * 8 / 1000
+}
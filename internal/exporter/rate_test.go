@@ -0,0 +1,81 @@
+package exporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCounterRateTracker(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		previous *counterSample
+		current  counterSample
+		expected float64
+		ok       bool
+	}{
+		{
+			name:    "first sample has no rate",
+			current: counterSample{value: 1000, at: start},
+		},
+		{
+			name:     "increase over one minute",
+			previous: &counterSample{value: 1000, at: start},
+			current:  counterSample{value: 61000, at: start.Add(time.Minute)},
+			expected: 1000,
+			ok:       true,
+		},
+		{
+			name:     "unchanged counter",
+			previous: &counterSample{value: 1000, at: start},
+			current:  counterSample{value: 1000, at: start.Add(30 * time.Second)},
+			expected: 0,
+			ok:       true,
+		},
+		{
+			name:     "counter reset after reboot",
+			previous: &counterSample{value: 5000, at: start},
+			current:  counterSample{value: 10, at: start.Add(time.Minute)},
+		},
+		{
+			name:     "no elapsed time",
+			previous: &counterSample{value: 1000, at: start},
+			current:  counterSample{value: 2000, at: start},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := newCounterRateTracker()
+			if tt.previous != nil {
+				tracker.rate("onu", tt.previous.value, tt.previous.at)
+			}
+
+			perSecond, ok := tracker.rate("onu", tt.current.value, tt.current.at)
+			assert.Equal(t, tt.ok, ok)
+			assert.InDelta(t, tt.expected, perSecond, 1e-9)
+		})
+	}
+}
+
+func TestCounterRateTrackerPrune(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tracker := newCounterRateTracker()
+	tracker.rate("stale", 100, start)
+	tracker.rate("fresh", 100, start.Add(2*time.Hour))
+
+	tracker.prune(start.Add(time.Hour))
+
+	_, ok := tracker.rate("stale", 200, start.Add(3*time.Hour))
+	assert.False(t, ok, "pruned samples start over")
+	_, ok = tracker.rate("fresh", 200, start.Add(3*time.Hour))
+	assert.True(t, ok)
+}
+
+func TestOctetsRateToKbps(t *testing.T) {
+	assert.InDelta(t, 8, octetsRateToKbps(1000), 1e-9)
+	assert.InDelta(t, 80000, octetsRateToKbps(10_000_000), 1e-9)
+}
@@ -0,0 +1,26 @@
+package exporter
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Register registers collector with registerer, replacing previous when it is not nil. Collectors
+// reconstructed on a config reload describe the same metrics as the collector they replace, which
+// registerer would reject as duplicates, so the previous registration is removed first. A registry
+// never forgets the label names of a metric, so a reload changing them (e.g. PROMETHEUS_DEDUP_KEY)
+// fails; previous is then registered again so its metrics are still served.
+func Register(registerer prometheus.Registerer, previous, collector prometheus.Collector) error {
+	if previous != nil {
+		registerer.Unregister(previous)
+	}
+	// Also removes a collector describing the same metrics that the caller no longer holds
+	registerer.Unregister(collector)
+
+	if err := registerer.Register(collector); err != nil {
+		if previous != nil {
+			_ = registerer.Register(previous)
+		}
+		return err
+	}
+	return nil
+}
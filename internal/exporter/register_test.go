@@ -0,0 +1,68 @@
+package exporter
+
+import (
+	"testing"
+
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterReload(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online"})
+	registry := prometheus.NewRegistry()
+
+	first := newTestCollector(t, usecase)
+	require.NoError(t, Register(registry, nil, first))
+
+	// A reload reconstructs the collector with the same metric descriptions
+	second := newTestCollector(t, usecase)
+	require.NotPanics(t, func() {
+		require.NoError(t, Register(registry, first, second))
+	})
+
+	// A collector that is not handed over as previous is still replaced
+	third := newTestCollector(t, usecase)
+	require.NoError(t, Register(registry, nil, third))
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() == "zte_onu_status" {
+			assert.Len(t, family.GetMetric(), 1, "the ONU is reported by a single collector")
+		}
+	}
+}
+
+func TestRegisterReloadWithChangedLabels(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online"})
+	registry := prometheus.NewRegistry()
+
+	first := newTestCollector(t, usecase)
+	require.NoError(t, Register(registry, nil, first))
+
+	// A registry keeps the label names of a metric name for good, so the reload is rejected
+	t.Setenv("PROMETHEUS_DEDUP_KEY", "location")
+	second := newTestCollector(t, usecase)
+	assert.Error(t, Register(registry, first, second))
+
+	// ... and the previous collector is still served
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	found := false
+	for _, family := range families {
+		if family.GetName() == "zte_onu_status" {
+			found = true
+			require.Len(t, family.GetMetric(), 1)
+			labels := make(map[string]string)
+			for _, pair := range family.GetMetric()[0].GetLabel() {
+				labels[pair.GetName()] = pair.GetValue()
+			}
+			assert.Equal(t, "ZTEGC0000001", labels["serial_number"])
+		}
+	}
+	assert.True(t, found)
+}
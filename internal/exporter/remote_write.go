@@ -0,0 +1,162 @@
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/s2"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// RemoteWriter periodically gathers the metrics of its collectors and pushes them to a Prometheus
+// remote-write endpoint (remote-write 1.0: a snappy-compressed protobuf WriteRequest), for setups
+// without a Prometheus server scraping the exporter.
+type RemoteWriter struct {
+	gatherer prometheus.Gatherer
+	url      string
+	interval time.Duration
+	client   *http.Client
+}
+
+// NewRemoteWriter creates a RemoteWriter pushing the metrics of gatherer to url every interval.
+// timeout bounds every push; an interval or timeout of zero or less falls back to 1 minute and 30
+// seconds respectively.
+func NewRemoteWriter(gatherer prometheus.Gatherer, url string, interval, timeout time.Duration) *RemoteWriter {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	return &RemoteWriter{
+		gatherer: gatherer,
+		url:      url,
+		interval: interval,
+		client:   &http.Client{Timeout: timeout},
+	}
+}
+
+// Run pushes the metrics right away, then every interval until ctx is done. A failed push is
+// logged and the next one is attempted at the next interval.
+func (w *RemoteWriter) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		if err := w.Push(ctx); err != nil {
+			log.Warn().Err(err).Str("url", w.url).Msg("Failed to push metrics to the remote-write endpoint")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Push gathers the metrics once and sends them in a single remote-write request. Metrics that
+// could not be gathered are logged and left out.
+func (w *RemoteWriter) Push(ctx context.Context) error {
+	families, err := w.gatherer.Gather()
+	if err != nil {
+		log.Warn().Err(err).Msg("Some metrics could not be gathered for remote write")
+	}
+
+	body := s2.EncodeSnappy(nil, encodeWriteRequest(families, time.Now()))
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/x-protobuf")
+	request.Header.Set("Content-Encoding", "snappy")
+	request.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+
+	response, err := w.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if response.StatusCode/100 != 2 {
+		message, _ := io.ReadAll(io.LimitReader(response.Body, 512))
+		return fmt.Errorf("remote write: %s: %s", response.Status, strings.TrimSpace(string(message)))
+	}
+	_, _ = io.Copy(io.Discard, response.Body)
+	return nil
+}
+
+// remoteWriteLabel is a label of a remote-write time series.
+type remoteWriteLabel struct {
+	name, value string
+}
+
+// encodeWriteRequest encodes the gauges, counters and untyped metrics of families as a
+// remote-write WriteRequest, one time series with a single sample per metric. Samples without a
+// timestamp of their own are stamped with now.
+func encodeWriteRequest(families []*dto.MetricFamily, now time.Time) []byte {
+	var request []byte
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			var value float64
+			switch {
+			case metric.Gauge != nil:
+				value = metric.GetGauge().GetValue()
+			case metric.Counter != nil:
+				value = metric.GetCounter().GetValue()
+			case metric.Untyped != nil:
+				value = metric.GetUntyped().GetValue()
+			default:
+				continue // The collectors of the exporter report no histogram or summary
+			}
+			timestamp := now.UnixMilli()
+			if metric.TimestampMs != nil {
+				timestamp = metric.GetTimestampMs()
+			}
+
+			// Remote write requires the labels sorted by name, the metric name included, and leaves
+			// out empty ones, which Prometheus treats as absent anyway
+			labels := []remoteWriteLabel{{name: "__name__", value: family.GetName()}}
+			for _, pair := range metric.GetLabel() {
+				if pair.GetValue() != "" {
+					labels = append(labels, remoteWriteLabel{name: pair.GetName(), value: pair.GetValue()})
+				}
+			}
+			slices.SortFunc(labels, func(a, b remoteWriteLabel) int { return strings.Compare(a.name, b.name) })
+
+			request = protowire.AppendTag(request, 1, protowire.BytesType) // WriteRequest.timeseries
+			request = protowire.AppendBytes(request, encodeTimeSeries(labels, value, timestamp))
+		}
+	}
+	return request
+}
+
+// encodeTimeSeries encodes a remote-write TimeSeries with a single sample.
+func encodeTimeSeries(labels []remoteWriteLabel, value float64, timestamp int64) []byte {
+	var series []byte
+	for _, label := range labels {
+		var encoded []byte
+		encoded = protowire.AppendTag(encoded, 1, protowire.BytesType) // Label.name
+		encoded = protowire.AppendString(encoded, label.name)
+		encoded = protowire.AppendTag(encoded, 2, protowire.BytesType) // Label.value
+		encoded = protowire.AppendString(encoded, label.value)
+		series = protowire.AppendTag(series, 1, protowire.BytesType) // TimeSeries.labels
+		series = protowire.AppendBytes(series, encoded)
+	}
+
+	var sample []byte
+	sample = protowire.AppendTag(sample, 1, protowire.Fixed64Type) // Sample.value
+	sample = protowire.AppendFixed64(sample, math.Float64bits(value))
+	sample = protowire.AppendTag(sample, 2, protowire.VarintType) // Sample.timestamp
+	sample = protowire.AppendVarint(sample, uint64(timestamp))
+	series = protowire.AppendTag(series, 2, protowire.BytesType) // TimeSeries.samples
+	return protowire.AppendBytes(series, sample)
+}
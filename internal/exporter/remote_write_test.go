@@ -0,0 +1,145 @@
+package exporter
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/s2"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// remoteWriteSample is a decoded remote-write sample with the labels of its time series.
+type remoteWriteSample struct {
+	labels    map[string]string
+	value     float64
+	timestamp int64
+}
+
+// fakeRemoteWriteReceiver decodes the WriteRequests it receives.
+type fakeRemoteWriteReceiver struct {
+	mu       sync.Mutex
+	requests [][]remoteWriteSample
+	headers  http.Header
+	status   int
+}
+
+func (r *fakeRemoteWriteReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	compressed, _ := io.ReadAll(req.Body)
+	body, err := s2.Decode(nil, compressed)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var samples []remoteWriteSample
+	forEachField(body, func(number protowire.Number, series []byte) { // WriteRequest.timeseries
+		sample := remoteWriteSample{labels: make(map[string]string)}
+		forEachField(series, func(number protowire.Number, field []byte) {
+			switch number {
+			case 1: // TimeSeries.labels
+				var name, value string
+				forEachField(field, func(number protowire.Number, text []byte) {
+					if number == 1 {
+						name = string(text)
+					} else {
+						value = string(text)
+					}
+				})
+				sample.labels[name] = value
+			case 2: // TimeSeries.samples
+				for len(field) > 0 {
+					number, _, n := protowire.ConsumeTag(field)
+					field = field[n:]
+					if number == 1 { // Sample.value
+						bits, n := protowire.ConsumeFixed64(field)
+						sample.value = math.Float64frombits(bits)
+						field = field[n:]
+					} else { // Sample.timestamp
+						timestamp, n := protowire.ConsumeVarint(field)
+						sample.timestamp = int64(timestamp)
+						field = field[n:]
+					}
+				}
+			}
+		})
+		samples = append(samples, sample)
+	})
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, samples)
+	r.headers = req.Header.Clone()
+	if r.status != 0 {
+		http.Error(w, "rejected", r.status)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// forEachField calls fn with the number and bytes of every length-delimited field of message.
+func forEachField(message []byte, fn func(number protowire.Number, value []byte)) {
+	for len(message) > 0 {
+		number, _, n := protowire.ConsumeTag(message)
+		message = message[n:]
+		value, n := protowire.ConsumeBytes(message)
+		message = message[n:]
+		fn(number, value)
+	}
+}
+
+func TestRemoteWriterPush(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online", RXPower: "-20.5"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 2, PON: 3, ID: 4, SerialNumber: "ZTEGC0000002", Status: "LOS"})
+	registry := prometheus.NewRegistry()
+	require.NoError(t, registry.Register(newTestCollector(t, usecase)))
+
+	receiver := &fakeRemoteWriteReceiver{}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	start := time.Now()
+	require.NoError(t, NewRemoteWriter(registry, server.URL, time.Minute, time.Second).Push(context.Background()))
+
+	receiver.mu.Lock()
+	defer receiver.mu.Unlock()
+	require.Len(t, receiver.requests, 1)
+	assert.Equal(t, "snappy", receiver.headers.Get("Content-Encoding"))
+	assert.Equal(t, "application/x-protobuf", receiver.headers.Get("Content-Type"))
+	assert.Equal(t, "0.1.0", receiver.headers.Get("X-Prometheus-Remote-Write-Version"))
+
+	statuses := make(map[string]float64)
+	var rxPower []remoteWriteSample
+	for _, sample := range receiver.requests[0] {
+		assert.GreaterOrEqual(t, sample.timestamp, start.UnixMilli())
+		switch sample.labels["__name__"] {
+		case "zte_onu_status":
+			statuses[sample.labels["serial_number"]] = sample.value
+		case "zte_onu_rx_power_dbm":
+			rxPower = append(rxPower, sample)
+		}
+	}
+	assert.Equal(t, map[string]float64{"ZTEGC0000001": 1, "ZTEGC0000002": 3}, statuses)
+	require.Len(t, rxPower, 1)
+	assert.Equal(t, map[string]string{"__name__": "zte_onu_rx_power_dbm", "serial_number": "ZTEGC0000001"}, rxPower[0].labels, "the empty maintenance label is left out")
+	assert.Equal(t, -20.5, rxPower[0].value)
+}
+
+func TestRemoteWriterPushRejected(t *testing.T) {
+	receiver := &fakeRemoteWriteReceiver{status: http.StatusBadRequest}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	err := NewRemoteWriter(prometheus.NewRegistry(), server.URL, time.Minute, time.Second).Push(context.Background())
+	assert.ErrorContains(t, err, "400 Bad Request: rejected")
+}
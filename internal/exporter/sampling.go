@@ -0,0 +1,108 @@
+package exporter
+
+import (
+	"hash/fnv"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
+)
+
+// detailSampler spreads the detail fetches of the ONUs over several scrapes. Every ONU belongs to
+// one of rounds groups, by a hash of its identity, and only the group of the current round has its
+// details fetched; the other ONUs reuse their last fetched details with the fresh status, name and
+// RX power of the discovery. Every ONU is thus refreshed every rounds scrapes, whatever ONUs come
+// and go. With a single round every ONU is fetched on every scrape.
+type detailSampler struct {
+	rounds int
+	mu     sync.Mutex
+	round  uint64                       // Number of scrapes started so far
+	cache  map[string]sampledOnuDetails // Last fetched details, keyed by identity
+}
+
+// sampledOnuDetails are the last fetched details of an ONU and when the ONU was last discovered.
+type sampledOnuDetails struct {
+	onu      model.ONUCustomerInfo
+	lastSeen time.Time
+}
+
+// newDetailSampler creates a sampler fetching the details of about fraction of the ONUs per
+// scrape. A fraction outside of (0, 1] fetches every ONU on every scrape.
+func newDetailSampler(fraction float64) *detailSampler {
+	rounds := 1
+	if fraction > 0 && fraction < 1 {
+		rounds = int(math.Ceil(1 / fraction))
+	}
+	return &detailSampler{rounds: rounds, cache: make(map[string]sampledOnuDetails)}
+}
+
+// nextRound starts a scrape and returns its round.
+func (s *detailSampler) nextRound() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	round := s.round
+	s.round++
+	return round
+}
+
+// sampled reports whether the details of the ONU with the given identity are fetched in round.
+func (s *detailSampler) sampled(identity string, round uint64) bool {
+	if s.rounds <= 1 {
+		return true
+	}
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(identity))
+	return uint64(hash.Sum32())%uint64(s.rounds) == round%uint64(s.rounds)
+}
+
+// details returns the details of a discovered ONU that is not sampled in round: its last fetched
+// details, or only what the discovery knows if it was never fetched, overlaid with the discovery.
+// Traffic counters are left out, a throughput derived from stale octets would read as zero. ok is
+// false when the ONU is sampled and its details must be fetched.
+func (s *detailSampler) details(identity string, discoveredOnu model.ONUInfoPerBoard, round uint64, at time.Time) (onu model.ONUCustomerInfo, ok bool) {
+	if s.sampled(identity, round) {
+		return model.ONUCustomerInfo{}, false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cached := s.cache[identity]
+	cached.lastSeen = at
+	s.cache[identity] = cached
+
+	onu = cached.onu
+	onu.Board = discoveredOnu.Board
+	onu.PON = discoveredOnu.PON
+	onu.ID = discoveredOnu.ID
+	onu.Name = discoveredOnu.Name
+	onu.OnuType = discoveredOnu.OnuType
+	onu.SerialNumber = discoveredOnu.SerialNumber
+	onu.RXPower = discoveredOnu.RXPower
+	onu.Status = discoveredOnu.Status
+	onu.MACAddress = discoveredOnu.MACAddress
+	onu.DownstreamOctets = ""
+	onu.UpstreamOctets = ""
+	return onu, true
+}
+
+// store remembers the fetched details of an ONU for the rounds it is not sampled in.
+func (s *detailSampler) store(identity string, onu model.ONUCustomerInfo, at time.Time) {
+	if s.rounds <= 1 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cache[identity] = sampledOnuDetails{onu: onu, lastSeen: at}
+}
+
+// prune forgets the details of ONUs that have not been discovered since before.
+func (s *detailSampler) prune(before time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for identity, cached := range s.cache {
+		if cached.lastSeen.Before(before) {
+			delete(s.cache, identity)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package exporter
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// scrapeCache keeps the metrics of the last completed scrape, so scrapes arriving within the TTL
+// (e.g. Prometheus and a federation scraping the same exporter) do not walk the OLT again. Once
+// the metrics are stale, they are still served while a single refresh runs in the background.
+// Only the metrics read from the OLT belong in the cache, the exporter's own metrics are sent live.
+type scrapeCache struct {
+	mu          sync.Mutex
+	ttl         time.Duration // How long the metrics of a scrape are served without refreshing them, 0 disables the cache
+	now         func() time.Time
+	metrics     []prometheus.Metric
+	collectedAt time.Time
+	refreshing  chan struct{} // Closed once the running refresh completes, nil when none runs
+}
+
+// newScrapeCache creates a cache serving the metrics of a scrape for ttl.
+func newScrapeCache(ttl time.Duration) *scrapeCache {
+	return &scrapeCache{ttl: ttl, now: time.Now}
+}
+
+// collect sends the cached metrics to ch, starting a refresh through collect when they are
+// stale. Only the first scrape, having nothing to serve yet, waits for the refresh.
+func (s *scrapeCache) collect(ch chan<- prometheus.Metric, collect func(chan<- prometheus.Metric)) {
+	if s.ttl <= 0 {
+		collect(ch)
+		return
+	}
+
+	s.mu.Lock()
+	if s.refreshing == nil && (s.collectedAt.IsZero() || s.now().Sub(s.collectedAt) >= s.ttl) {
+		s.refreshing = make(chan struct{})
+		go s.refresh(collect, s.refreshing)
+	}
+	refreshing := s.refreshing
+	metrics := s.metrics
+	first := s.collectedAt.IsZero()
+	s.mu.Unlock()
+
+	if first {
+		<-refreshing
+		s.mu.Lock()
+		metrics = s.metrics
+		s.mu.Unlock()
+	}
+
+	for _, metric := range metrics {
+		ch <- metric
+	}
+}
+
+// age returns how long ago the cached metrics were collected. ok is false when the cache is
+// disabled or nothing was collected yet.
+func (s *scrapeCache) age() (age time.Duration, ok bool) {
+	if s.ttl <= 0 {
+		return 0, false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.collectedAt.IsZero() {
+		return 0, false
+	}
+	return s.now().Sub(s.collectedAt), true
+}
+
+// refresh runs a scrape and replaces the cached metrics with its metrics.
+func (s *scrapeCache) refresh(collect func(chan<- prometheus.Metric), done chan struct{}) {
+	metricCh := make(chan prometheus.Metric)
+	go func() {
+		defer close(metricCh)
+		collect(metricCh)
+	}()
+	var metrics []prometheus.Metric
+	for metric := range metricCh {
+		metrics = append(metrics, metric)
+	}
+
+	s.mu.Lock()
+	s.metrics = metrics
+	s.collectedAt = s.now()
+	s.refreshing = nil
+	s.mu.Unlock()
+	close(done)
+}
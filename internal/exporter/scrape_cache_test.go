@@ -0,0 +1,124 @@
+package exporter
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingScrape is a scrape sending a single gauge holding the number of scrapes so far. Every
+// scrape waits for release, when set, before sending it.
+type countingScrape struct {
+	desc    *prometheus.Desc
+	scrapes atomic.Int64
+	release chan struct{}
+}
+
+func newCountingScrape() *countingScrape {
+	return &countingScrape{desc: prometheus.NewDesc("test_scrapes", "Number of scrapes.", nil, nil)}
+}
+
+func (s *countingScrape) collect(ch chan<- prometheus.Metric) {
+	scrapes := s.scrapes.Add(1)
+	if s.release != nil {
+		<-s.release
+	}
+	ch <- prometheus.MustNewConstMetric(s.desc, prometheus.GaugeValue, float64(scrapes))
+}
+
+// servedScrape returns the gauge served by the cache, the scrape whose metrics were served.
+func servedScrape(t *testing.T, cache *scrapeCache, scrape *countingScrape) float64 {
+	ch := make(chan prometheus.Metric, 1)
+	cache.collect(ch, scrape.collect)
+	require.Len(t, ch, 1)
+	var metric dto.Metric
+	require.NoError(t, (<-ch).Write(&metric))
+	return metric.GetGauge().GetValue()
+}
+
+// refreshed reports whether the cache has no refresh running.
+func refreshed(cache *scrapeCache) bool {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	return cache.refreshing == nil
+}
+
+func newTestScrapeCache(ttl time.Duration) (cache *scrapeCache, advance func(time.Duration)) {
+	var elapsed atomic.Int64
+	start := time.Now()
+	cache = newScrapeCache(ttl)
+	cache.now = func() time.Time { return start.Add(time.Duration(elapsed.Load())) }
+	return cache, func(d time.Duration) { elapsed.Add(int64(d)) }
+}
+
+func TestScrapeCacheTTL(t *testing.T) {
+	cache, advance := newTestScrapeCache(time.Minute)
+	scrape := newCountingScrape()
+
+	assert.Equal(t, 1.0, servedScrape(t, cache, scrape), "the first scrape waits for the OLT")
+	advance(59 * time.Second)
+	assert.Equal(t, 1.0, servedScrape(t, cache, scrape), "fresh metrics are served from the cache")
+	assert.Equal(t, int64(1), scrape.scrapes.Load())
+
+	advance(time.Second)
+	assert.Equal(t, 1.0, servedScrape(t, cache, scrape), "stale metrics are served while refreshing")
+	assert.Eventually(t, func() bool { return refreshed(cache) }, time.Second, time.Millisecond)
+	assert.Equal(t, 2.0, servedScrape(t, cache, scrape), "the refreshed metrics are served next")
+	assert.Equal(t, int64(2), scrape.scrapes.Load())
+}
+
+func TestScrapeCacheDisabled(t *testing.T) {
+	cache, _ := newTestScrapeCache(0)
+	scrape := newCountingScrape()
+
+	assert.Equal(t, 1.0, servedScrape(t, cache, scrape))
+	assert.Equal(t, 2.0, servedScrape(t, cache, scrape), "every scrape reads the OLT")
+}
+
+func TestScrapeCacheConcurrentScrapes(t *testing.T) {
+	cache, advance := newTestScrapeCache(time.Minute)
+	scrape := newCountingScrape()
+	scrape.release = make(chan struct{})
+
+	// Concurrent first scrapes all wait for a single scrape of the OLT.
+	var wg sync.WaitGroup
+	served := make([]float64, 8)
+	for i := range served {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			served[i] = servedScrape(t, cache, scrape)
+		}()
+	}
+	assert.Eventually(t, func() bool { return scrape.scrapes.Load() == 1 }, time.Second, time.Millisecond)
+	close(scrape.release)
+	wg.Wait()
+	assert.Equal(t, []float64{1, 1, 1, 1, 1, 1, 1, 1}, served)
+	assert.Equal(t, int64(1), scrape.scrapes.Load())
+
+	// Concurrent scrapes of stale metrics get them right away, while a single refresh is blocked.
+	scrape.release = make(chan struct{})
+	advance(time.Minute)
+	for i := range served {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			served[i] = servedScrape(t, cache, scrape)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, []float64{1, 1, 1, 1, 1, 1, 1, 1}, served)
+	assert.Eventually(t, func() bool { return scrape.scrapes.Load() == 2 }, time.Second, time.Millisecond)
+	assert.False(t, refreshed(cache))
+
+	close(scrape.release)
+	assert.Eventually(t, func() bool { return refreshed(cache) }, time.Second, time.Millisecond)
+	assert.Equal(t, 2.0, servedScrape(t, cache, scrape))
+	assert.Equal(t, int64(2), scrape.scrapes.Load(), "no scrape was started twice")
+}
@@ -0,0 +1,62 @@
+package exporter
+
+import (
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/rs/zerolog/log"
+)
+
+// smokeSelector picks the single PON scraped by a smoke scrape, a cheap check that the OLT
+// answers without walking the whole chassis. A nil selector scrapes every PON.
+type smokeSelector struct {
+	fixed  *ponKey      // The PON scraped on every scrape, nil to rotate or pick at random
+	random bool         // Pick a random PON on every scrape instead of rotating
+	next   atomic.Int64 // Index of the PON of the next rotating scrape
+}
+
+// parseSmokeSelector parses the smoke mode: "rotate" to scrape the PONs of the scan range in turn,
+// "random" to pick one at random, or "board/pon" for a fixed PON of the scan range. An empty mode
+// disables smoke scrapes, an invalid one or a PON outside the scan range is logged and rotates.
+func parseSmokeSelector(mode string, keys []ponKey) *smokeSelector {
+	switch mode = strings.TrimSpace(mode); mode {
+	case "":
+		return nil
+	case "rotate":
+		return &smokeSelector{}
+	case "random":
+		return &smokeSelector{random: true}
+	}
+
+	boardStr, ponStr, found := strings.Cut(mode, "/")
+	board, boardErr := strconv.Atoi(boardStr)
+	pon, ponErr := strconv.Atoi(ponStr)
+	key := ponKey{board: board, pon: pon}
+	if !found || boardErr != nil || ponErr != nil || !slices.Contains(keys, key) {
+		log.Warn().Str("mode", mode).Msg("Invalid smoke PON, expected rotate, random or a board/pon of the scan range, rotating")
+		return &smokeSelector{}
+	}
+	return &smokeSelector{fixed: &key}
+}
+
+// varies reports whether successive smoke scrapes walk different PONs.
+func (s *smokeSelector) varies() bool {
+	return s != nil && s.fixed == nil
+}
+
+// pick returns the PONs of keys to scrape: all of them without smoke scrapes, a single one otherwise.
+func (s *smokeSelector) pick(keys []ponKey) []ponKey {
+	switch {
+	case s == nil || len(keys) == 0:
+		return keys
+	case s.fixed != nil:
+		return []ponKey{*s.fixed}
+	case s.random:
+		return []ponKey{keys[rand.IntN(len(keys))]}
+	default:
+		return []ponKey{keys[(s.next.Add(1)-1)%int64(len(keys))]}
+	}
+}
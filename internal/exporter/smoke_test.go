@@ -0,0 +1,34 @@
+package exporter
+
+import (
+	"testing"
+
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSmokeSelectorPick(t *testing.T) {
+	keys := ponKeys(model.ScanRange{BoardMin: 1, BoardMax: 2, PonMin: 1, PonMax: 2})
+
+	assert.Nil(t, parseSmokeSelector(" ", keys), "smoke scrapes are disabled by default")
+	assert.Equal(t, keys, parseSmokeSelector("", keys).pick(keys))
+
+	rotating := parseSmokeSelector("rotate", keys)
+	var rotated []ponKey
+	for range len(keys) + 1 {
+		rotated = append(rotated, rotating.pick(keys)...)
+	}
+	assert.Equal(t, append(keys, keys[0]), rotated)
+
+	random := parseSmokeSelector("random", keys)
+	for range 10 {
+		picked := random.pick(keys)
+		assert.Len(t, picked, 1)
+		assert.Contains(t, keys, picked[0])
+	}
+
+	assert.Equal(t, []ponKey{{board: 2, pon: 1}}, parseSmokeSelector("2/1", keys).pick(keys))
+	for _, invalid := range []string{"3/1", "2/x", "2"} {
+		assert.Equal(t, &smokeSelector{}, parseSmokeSelector(invalid, keys), "%q rotates", invalid)
+	}
+}
@@ -0,0 +1,31 @@
+package exporter
+
+import (
+	"sync"
+	"time"
+
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
+)
+
+// scrapeSnapshot keeps the detailed ONU information of the last completed scrape, so the
+// HTTP API can serve aggregated views without polling the OLT a second time.
+type scrapeSnapshot struct {
+	mu        sync.RWMutex
+	onus      []model.ONUCustomerInfo
+	scrapedAt time.Time
+}
+
+// store replaces the snapshot with the ONUs of a completed scrape.
+func (s *scrapeSnapshot) store(onus []model.ONUCustomerInfo, scrapedAt time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onus = onus
+	s.scrapedAt = scrapedAt
+}
+
+// load returns a copy of the snapshot. scrapedAt is the zero time before the first scrape.
+func (s *scrapeSnapshot) load() (onus []model.ONUCustomerInfo, scrapedAt time.Time) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]model.ONUCustomerInfo(nil), s.onus...), s.scrapedAt
+}
@@ -0,0 +1,80 @@
+package exporter
+
+import (
+	"maps"
+	"strconv"
+	"strings"
+
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
+	"github.com/rs/zerolog/log"
+)
+
+// emptySlotStatus is the zte_onu_status value of a free ONU ID, see PROMETHEUS_EMIT_EMPTY_SLOTS.
+const emptySlotStatus = -1
+
+// defaultStatusValues maps the ONU status strings to their zte_onu_status value. Any other status
+// is reported as 0.
+var defaultStatusValues = map[string]float64{
+	"Online":     1,
+	"Dying Gasp": 2,
+	"LOS":        3,
+	"Power-Off":  4,
+}
+
+// countedStatuses are the statuses counted by zte_onu_status_count, any other status is counted
+// as otherStatus.
+var countedStatuses = []string{"Online", "LOS", "Dying Gasp", "Power-Off"}
+
+// otherStatus is the zte_onu_status_count status of the ONUs in none of the countedStatuses.
+const otherStatus = "Other"
+
+// statusCount is the number of ONUs in a status.
+type statusCount struct {
+	status string
+	onus   int
+}
+
+// countStatuses counts the ONUs, keyed by identity, in each of the countedStatuses and otherStatus,
+// in that order. Statuses without ONUs are counted as 0, so they do not vanish from the series.
+func countStatuses(onus map[string]model.ONUInfoPerBoard) []statusCount {
+	byStatus := make(map[string]int, len(countedStatuses)+1)
+	for _, onu := range onus {
+		byStatus[onu.Status]++
+	}
+
+	counts := make([]statusCount, 0, len(countedStatuses)+1)
+	other := len(onus)
+	for _, status := range countedStatuses {
+		counts = append(counts, statusCount{status: status, onus: byStatus[status]})
+		other -= byStatus[status]
+	}
+	return append(counts, statusCount{status: otherStatus, onus: other})
+}
+
+// statusValues maps the ONU status strings to their zte_onu_status value.
+type statusValues map[string]float64
+
+// parseStatusValues returns the default status values overridden and extended by a comma-separated
+// list of status=value entries, e.g. "Auth Failed=5,Offline=6", for statuses of other firmware.
+// Invalid entries are logged and ignored.
+func parseStatusValues(overrides string) statusValues {
+	values := maps.Clone(defaultStatusValues)
+
+	for _, entry := range splitList(overrides) {
+		status, valueStr, found := strings.Cut(entry, "=")
+		status = strings.TrimSpace(status)
+		value, err := strconv.ParseFloat(strings.TrimSpace(valueStr), 64)
+		if !found || status == "" || err != nil {
+			log.Warn().Str("entry", entry).Msg("Ignoring invalid status value, expected status=value")
+			continue
+		}
+		values[status] = value
+	}
+
+	return values
+}
+
+// value returns the zte_onu_status value of status, 0 when it is not mapped.
+func (s statusValues) value(status string) float64 {
+	return s[status]
+}
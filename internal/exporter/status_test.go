@@ -0,0 +1,36 @@
+package exporter
+
+import (
+	"testing"
+
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseStatusValues(t *testing.T) {
+	values := parseStatusValues("LOS=5, Auth Failed = 6,invalid,Offline=x,=7")
+
+	assert.Equal(t, 1.0, values.value("Online"), "defaults are kept")
+	assert.Equal(t, 5.0, values.value("LOS"), "defaults can be overridden")
+	assert.Equal(t, 6.0, values.value("Auth Failed"), "new statuses can be added")
+	assert.Zero(t, values.value("Offline"), "invalid entries are ignored")
+	assert.Zero(t, values.value("Synchronization"))
+	assert.Equal(t, 3.0, defaultStatusValues["LOS"], "the defaults are not modified")
+}
+
+func TestCollectCustomStatusValues(t *testing.T) {
+	t.Setenv("PROMETHEUS_STATUS_VALUES", "LOS=5,Auth Failed=6")
+
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 2, SerialNumber: "ZTEGC0000002", Status: "LOS"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 3, SerialNumber: "ZTEGC0000003", Status: "Auth Failed"})
+
+	metrics := gatherMetrics(t, newTestCollector(t, usecase))
+
+	statuses := map[string]float64{}
+	for _, m := range metrics["zte_onu_status"] {
+		statuses[m.labels["serial_number"]] = m.value
+	}
+	assert.Equal(t, map[string]float64{"ZTEGC0000001": 1, "ZTEGC0000002": 5, "ZTEGC0000003": 6}, statuses)
+}
@@ -0,0 +1,100 @@
+package exporter
+
+import (
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/usecase"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// TargetUsecases are the usecases polling one OLT of a multi-target setup.
+type TargetUsecases struct {
+	Onu     usecase.OnuUseCaseInterface
+	Uplink  usecase.UplinkUseCaseInterface
+	Pon     usecase.PonUseCaseInterface
+	Chassis usecase.ChassisUseCaseInterface
+	Closer  io.Closer // Releases the SNMP sessions of the target on Close, optional
+}
+
+// TargetPool provides the collectors of the OLTs polled through /metrics?target=, the way the
+// snmp_exporter multi-target pattern does. The usecases of a target, and thus its SNMP repository
+// with its connected sessions, are created on its first scrape and reused by the next ones until
+// Close. Every target and scan range gets
+// its own ONU collector, so the state kept across scrapes (throughput, flaps, circuit breaker...)
+// never mixes two OLTs or two ranges.
+type TargetPool struct {
+	newUsecases  func(target string) (TargetUsecases, bool)
+	defaultRange model.ScanRange
+	mu           sync.Mutex
+	targets      map[string]*targetCollectors
+}
+
+// targetCollectors are the collectors of one target.
+type targetCollectors struct {
+	usecases TargetUsecases
+	olt      *OltCollector
+	onus     map[model.ScanRange]*OnuCollector
+}
+
+// NewTargetPool creates a TargetPool building the usecases of a target with newUsecases, which
+// reports false for a target that is not configured.
+func NewTargetPool(newUsecases func(target string) (TargetUsecases, bool)) *TargetPool {
+	return &TargetPool{
+		newUsecases:  newUsecases,
+		defaultRange: ScanRangeFromEnv(),
+		targets:      make(map[string]*targetCollectors),
+	}
+}
+
+// ScanRange returns the scan range of a target scrape that does not set one.
+func (p *TargetPool) ScanRange() model.ScanRange {
+	return p.defaultRange
+}
+
+// TargetCollectors returns the collectors of target scanning the boards and PONs of scanRange. ok
+// is false when target is not configured.
+func (p *TargetPool) TargetCollectors(target string, scanRange model.ScanRange) (collectors []prometheus.Collector, ok bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	collectorsOfTarget, ok := p.targets[target]
+	if !ok {
+		usecases, ok := p.newUsecases(target)
+		if !ok {
+			return nil, false
+		}
+		collectorsOfTarget = &targetCollectors{
+			usecases: usecases,
+			olt:      NewOltCollector(usecases.Uplink, usecases.Pon, usecases.Chassis),
+			onus:     make(map[model.ScanRange]*OnuCollector),
+		}
+		p.targets[target] = collectorsOfTarget
+	}
+
+	onuCollector, ok := collectorsOfTarget.onus[scanRange]
+	if !ok {
+		onuCollector = NewOnuCollector(collectorsOfTarget.usecases.Onu, collectorsOfTarget.usecases.Pon, scanRange)
+		collectorsOfTarget.onus[scanRange] = onuCollector
+	}
+
+	return []prometheus.Collector{onuCollector, collectorsOfTarget.olt}, true
+}
+
+// Close releases the SNMP sessions of every target created so far. A target scraped afterwards
+// gets new usecases.
+func (p *TargetPool) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var errs []error
+	for target, collectorsOfTarget := range p.targets {
+		if collectorsOfTarget.usecases.Closer != nil {
+			errs = append(errs, collectorsOfTarget.usecases.Closer.Close())
+		}
+		delete(p.targets, target)
+	}
+	return errors.Join(errs...)
+}
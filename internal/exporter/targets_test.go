@@ -0,0 +1,113 @@
+package exporter
+
+import (
+	"testing"
+
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTargetPoolCollectsEveryTargetIndependently(t *testing.T) {
+	first := newFakeOnuUsecase()
+	first.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online"})
+	second := newFakeOnuUsecase()
+	second.addOnu(model.ONUCustomerInfo{Board: 2, PON: 4, ID: 9, SerialNumber: "ZTEGC0000002", Status: "LOS"})
+	usecases := map[string]TargetUsecases{
+		"10.0.0.1": {Onu: first, Uplink: &fakeUplinkUsecase{}, Pon: &fakePonUsecase{}, Chassis: &fakeChassisUsecase{}},
+		"10.0.0.2": {Onu: second, Uplink: &fakeUplinkUsecase{}, Pon: &fakePonUsecase{}, Chassis: &fakeChassisUsecase{}},
+	}
+	created := make(map[string]int)
+	newTestCollector(t, first) // Sets the default scan range
+	pool := NewTargetPool(func(target string) (TargetUsecases, bool) {
+		created[target]++
+		targetUsecases, ok := usecases[target]
+		return targetUsecases, ok
+	})
+
+	serials := func(target string) []string {
+		collectors, ok := pool.TargetCollectors(target, pool.ScanRange())
+		require.True(t, ok)
+		require.Len(t, collectors, 2)
+		var result []string
+		for _, metric := range gatherMetrics(t, collectors[0])["zte_onu_mapping_info"] {
+			result = append(result, metric.labels["board"]+"/"+metric.labels["pon"]+" "+metric.labels["serial_number"])
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"1/1 ZTEGC0000001"}, serials("10.0.0.1"))
+	assert.Equal(t, []string{"2/4 ZTEGC0000002"}, serials("10.0.0.2"))
+	assert.Equal(t, []string{"1/1 ZTEGC0000001"}, serials("10.0.0.1"))
+	assert.Equal(t, map[string]int{"10.0.0.1": 1, "10.0.0.2": 1}, created, "the usecases of a target are reused across scrapes")
+
+	_, ok := pool.TargetCollectors("10.0.0.3", pool.ScanRange())
+	assert.False(t, ok)
+}
+
+func TestTargetPoolScanRange(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 2, PON: 4, ID: 9, SerialNumber: "ZTEGC0000002", Status: "Online"})
+	newTestCollector(t, usecase)
+	pool := NewTargetPool(func(string) (TargetUsecases, bool) {
+		return TargetUsecases{Onu: usecase, Uplink: &fakeUplinkUsecase{}, Pon: &fakePonUsecase{}, Chassis: &fakeChassisUsecase{}}, true
+	})
+
+	collectors, ok := pool.TargetCollectors("10.0.0.1", model.ScanRange{BoardMin: 2, BoardMax: 2, PonMin: 1, PonMax: 16})
+	require.True(t, ok)
+	statuses := gatherMetrics(t, collectors[0])["zte_onu_status"]
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "ZTEGC0000002", statuses[0].labels["serial_number"])
+
+	other, _ := pool.TargetCollectors("10.0.0.1", pool.ScanRange())
+	assert.NotSame(t, collectors[0], other[0], "every scan range has its own collector")
+	assert.Same(t, collectors[1], other[1], "the OLT collector is shared by the scan ranges of a target")
+}
+
+// closeCounter counts its Close calls
+type closeCounter struct {
+	closed int
+}
+
+func (c *closeCounter) Close() error {
+	c.closed++
+	return nil
+}
+
+func TestTargetPoolClose(t *testing.T) {
+	newTestCollector(t, newFakeOnuUsecase())
+	closers := make(map[string]*closeCounter)
+	pool := NewTargetPool(func(target string) (TargetUsecases, bool) {
+		closers[target] = &closeCounter{}
+		return TargetUsecases{Onu: newFakeOnuUsecase(), Uplink: &fakeUplinkUsecase{}, Pon: &fakePonUsecase{}, Chassis: &fakeChassisUsecase{}, Closer: closers[target]}, true
+	})
+
+	first, _ := pool.TargetCollectors("10.0.0.1", pool.ScanRange())
+	pool.TargetCollectors("10.0.0.2", pool.ScanRange())
+	require.NoError(t, pool.Close())
+	assert.Equal(t, 1, closers["10.0.0.1"].closed)
+	assert.Equal(t, 1, closers["10.0.0.2"].closed)
+
+	again, _ := pool.TargetCollectors("10.0.0.1", pool.ScanRange())
+	assert.NotSame(t, first[0], again[0], "a target scraped after Close gets new collectors")
+}
+
+func TestTargetPoolSmokePonOfScanRange(t *testing.T) {
+	usecase := newFakeOnuUsecase()
+	usecase.addOnu(model.ONUCustomerInfo{Board: 3, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online"})
+	usecase.addOnu(model.ONUCustomerInfo{Board: 3, PON: 2, ID: 1, SerialNumber: "ZTEGC0000002", Status: "Online"})
+	newTestCollector(t, usecase) // The default scan range leaves board 3 out
+	t.Setenv("PROMETHEUS_SMOKE_PON", "3/2")
+	pool := NewTargetPool(func(string) (TargetUsecases, bool) {
+		return TargetUsecases{Onu: usecase, Uplink: &fakeUplinkUsecase{}, Pon: &fakePonUsecase{}, Chassis: &fakeChassisUsecase{}}, true
+	})
+
+	collectors, ok := pool.TargetCollectors("10.0.0.1", model.ScanRange{BoardMin: 3, BoardMax: 3, PonMin: 1, PonMax: 2})
+	require.True(t, ok)
+	for range 2 {
+		statuses := gatherMetrics(t, collectors[0])["zte_onu_status"]
+		require.Len(t, statuses, 1)
+		assert.Equal(t, "ZTEGC0000002", statuses[0].labels["serial_number"], "the smoke PON is checked against the scan range of the target")
+	}
+}
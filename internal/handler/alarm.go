@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/usecase"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/utils"
+	"github.com/rs/zerolog/log"
+)
+
+// AlarmHandler is a struct that represent the alarm handler
+type AlarmHandler struct {
+	alarmUsecase usecase.AlarmUseCaseInterface
+}
+
+// NewAlarmHandler will create an object that represent the alarm handler
+func NewAlarmHandler(alarmUsecase usecase.AlarmUseCaseInterface) *AlarmHandler {
+	return &AlarmHandler{alarmUsecase: alarmUsecase}
+}
+
+// GetAlarms is a method to get every ONU in an alarm condition as of the last Prometheus scrape
+// example: http://localhost:8081/api/v1/alarms
+func (a *AlarmHandler) GetAlarms(w http.ResponseWriter, _ *http.Request) {
+
+	log.Info().Msg("Received a request to GetAlarms")
+
+	alarmList, ok := a.alarmUsecase.GetAlarms()
+	if !ok {
+		log.Warn().Msg("No completed scrape yet")
+		utils.ErrorServiceUnavailable(w, fmt.Errorf("no scrape has completed yet")) // error 503
+		return
+	}
+
+	// Convert result to JSON format according to WebResponse structure
+	response := utils.WebResponse{
+		Code:   http.StatusOK, // 200
+		Status: "OK",          // "OK"
+		Data:   alarmList,     // data
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, response) // 200
+}
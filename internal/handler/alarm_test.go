@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/config"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSnapshotSource serves a fixed set of scraped ONUs.
+type fakeSnapshotSource struct {
+	onus      []model.ONUCustomerInfo
+	scrapedAt time.Time
+}
+
+func (f *fakeSnapshotSource) LastScrape() ([]model.ONUCustomerInfo, time.Time) {
+	return f.onus, f.scrapedAt
+}
+
+// alarmResponse mirrors the JSON returned by GetAlarms.
+type alarmResponse struct {
+	Code int                `json:"code"`
+	Data model.OnuAlarmList `json:"data"`
+}
+
+func newTestAlarmHandler(source *fakeSnapshotSource) *AlarmHandler {
+	cfg := &config.Config{AlarmCfg: config.AlarmConfig{
+		RxPowerWarning:     -25,
+		RxPowerCritical:    -28,
+		RxPowerMax:         -8,
+		TxPowerMin:         0.5,
+		TxPowerMax:         5,
+		OpticalDistanceMax: 20000,
+	}}
+	return NewAlarmHandler(usecase.NewAlarmUsecase(source, cfg))
+}
+
+func TestGetAlarms(t *testing.T) {
+	source := &fakeSnapshotSource{
+		scrapedAt: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+		onus: []model.ONUCustomerInfo{
+			{Board: 1, PON: 1, ID: 1, SerialNumber: "HEALTHY01", Status: "Online", RXPower: "-19.50", TXPower: "2.30", GponOpticalDistance: "1200"},
+			{Board: 1, PON: 1, ID: 2, SerialNumber: "WEAKRX001", Status: "Online", RXPower: "-26.10", TXPower: "2.30", GponOpticalDistance: "1500"},
+			{Board: 1, PON: 2, ID: 3, SerialNumber: "DEADRX001", Status: "Online", RXPower: "-29.00", TXPower: "2.10", GponOpticalDistance: "800"},
+			{Board: 2, PON: 4, ID: 5, SerialNumber: "LOSONU001", Status: "LOS", RXPower: "0", TXPower: "0"},
+			{Board: 2, PON: 4, ID: 6, SerialNumber: "POWEROFF1", Status: "Power-Off"},
+			{Board: 1, PON: 3, ID: 9, SerialNumber: "FARAWAY01", Status: "Online", RXPower: "-20.00", TXPower: "2.50", GponOpticalDistance: "25000"},
+			{Board: 1, PON: 3, ID: 10, SerialNumber: "NOREADING", Status: "Online", RXPower: "655.35", TXPower: "2.50", GponOpticalDistance: "300"},
+		},
+	}
+
+	rr := httptest.NewRecorder()
+	newTestAlarmHandler(source).GetAlarms(rr, httptest.NewRequest(http.MethodGet, "/api/v1/alarms", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var response alarmResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+	assert.Equal(t, "2024-05-01T10:00:00Z", response.Data.ScrapedAt)
+
+	severities := make(map[string]string)
+	var order []string
+	for _, alarm := range response.Data.Alarms {
+		severities[alarm.SerialNumber] = alarm.Severity
+		order = append(order, alarm.SerialNumber)
+		assert.NotEmpty(t, alarm.Reasons)
+	}
+
+	assert.Equal(t, map[string]string{
+		"WEAKRX001": usecase.SeverityWarning,
+		"DEADRX001": usecase.SeverityCritical,
+		"LOSONU001": usecase.SeverityCritical,
+		"POWEROFF1": usecase.SeverityWarning,
+		"FARAWAY01": usecase.SeverityWarning,
+	}, severities)
+
+	// Critical alarms come first, then ordered by location.
+	assert.Equal(t, []string{"DEADRX001", "LOSONU001", "WEAKRX001", "FARAWAY01", "POWEROFF1"}, order)
+}
+
+func TestGetAlarmsNoAlarms(t *testing.T) {
+	source := &fakeSnapshotSource{
+		scrapedAt: time.Now(),
+		onus: []model.ONUCustomerInfo{
+			{Board: 1, PON: 1, ID: 1, SerialNumber: "HEALTHY01", Status: "Online", RXPower: "-19.50", TXPower: "2.30", GponOpticalDistance: "1200"},
+		},
+	}
+
+	rr := httptest.NewRecorder()
+	newTestAlarmHandler(source).GetAlarms(rr, httptest.NewRequest(http.MethodGet, "/api/v1/alarms", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"alarms":[]`)
+}
+
+func TestGetAlarmsBeforeFirstScrape(t *testing.T) {
+	rr := httptest.NewRecorder()
+	newTestAlarmHandler(&fakeSnapshotSource{}).GetAlarms(rr, httptest.NewRequest(http.MethodGet, "/api/v1/alarms", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+}
@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/usecase"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/utils"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rs/zerolog/log"
+)
+
+// numericOIDPattern matches a numeric OID such as ".1.3.6.1.4.1.3902.1082.500.10.2.3.3.1.2"
+var numericOIDPattern = regexp.MustCompile(`^\.?[0-9]+(\.[0-9]+)*$`)
+
+// OnuMetricsSource provides the Prometheus metrics of a single ONU, by serial number.
+type OnuMetricsSource interface {
+	SerialCollector(serialNumber string) (prometheus.Collector, bool)
+}
+
+// DebugHandler is a struct that represent the debug handler
+type DebugHandler struct {
+	debugUsecase  usecase.DebugUseCaseInterface
+	metricsSource OnuMetricsSource
+}
+
+// NewDebugHandler will create an object that represent the debug handler
+func NewDebugHandler(debugUsecase usecase.DebugUseCaseInterface, metricsSource OnuMetricsSource) *DebugHandler {
+	return &DebugHandler{debugUsecase: debugUsecase, metricsSource: metricsSource}
+}
+
+// GetWalk is a method to walk an OID of the OLT and return the raw OID-value pairs, capped in count
+// example: http://localhost:8081/api/v1/debug/walk?oid=.1.3.6.1.4.1.3902.1082.500.10.2.3.3.1.2.285278465
+func (d *DebugHandler) GetWalk(w http.ResponseWriter, r *http.Request) {
+
+	log.Info().Msg("Received a request to GetWalk")
+
+	oid := r.URL.Query().Get("oid")
+
+	// Validate oid value and return error 400 if it is not a numeric OID
+	if !numericOIDPattern.MatchString(oid) {
+		log.Error().Str("oid", oid).Msg("Invalid 'oid' parameter")
+		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'oid' parameter. It must be a numeric OID")) // error 400
+		return
+	}
+
+	// Call usecase to walk the OID
+	walkResult, err := d.debugUsecase.Walk(r.Context(), oid)
+	if err != nil {
+		log.Error().Err(err).Str("oid", oid).Msg("Failed to walk OID")
+		utils.ErrorInternalServerError(w, fmt.Errorf("cannot walk oid")) // error 500
+		return
+	}
+
+	// Convert result to JSON format according to WebResponse structure
+	response := utils.WebResponse{
+		Code:   http.StatusOK, // 200
+		Status: "OK",          // "OK"
+		Data:   walkResult,    // data
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, response) // 200
+}
+
+// GetConfig is a method to get the configuration in use for a board and PON: the resolved OIDs, the
+// SNMP settings with credentials redacted and the collector scan range
+// example: http://localhost:8081/api/v1/debug/config?board_id=1&pon_id=1
+func (d *DebugHandler) GetConfig(w http.ResponseWriter, r *http.Request) {
+
+	log.Info().Msg("Received a request to GetConfig")
+
+	boardID, err := strconv.Atoi(r.URL.Query().Get("board_id"))
+
+	// Validate boardID value and return error 400 if boardID is not 1 or 2
+	if err != nil || (boardID != 1 && boardID != 2) {
+		log.Error().Err(err).Msg("Invalid 'board_id' parameter")
+		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'board_id' parameter. It must be 1 or 2")) // error 400
+		return
+	}
+
+	ponID, err := strconv.Atoi(r.URL.Query().Get("pon_id"))
+
+	// Validate ponID value and return error 400 if ponID is not between 1 and 16
+	if err != nil || ponID < 1 || ponID > 16 {
+		log.Error().Err(err).Msg("Invalid 'pon_id' parameter")
+		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'pon_id' parameter. It must be between 1 and 16")) // error 400
+		return
+	}
+
+	// Call usecase to resolve the configuration
+	effectiveConfig, err := d.debugUsecase.GetConfig(boardID, ponID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get config")
+		utils.ErrorInternalServerError(w, fmt.Errorf("cannot get config")) // error 500
+		return
+	}
+
+	// Convert result to JSON format according to WebResponse structure
+	response := utils.WebResponse{
+		Code:   http.StatusOK,   // 200
+		Status: "OK",            // "OK"
+		Data:   effectiveConfig, // data
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, response) // 200
+}
+
+// GetMetrics is a method to get the full metric set of one ONU in the Prometheus text format, so
+// alert rules can be checked against the series that exist for a device
+// example: http://localhost:8081/api/v1/debug/metrics?serial=ZTEGC0000001
+func (d *DebugHandler) GetMetrics(w http.ResponseWriter, r *http.Request) {
+
+	log.Info().Msg("Received a request to GetMetrics")
+
+	serialNumber := r.URL.Query().Get("serial")
+
+	// Validate serial value and return error 400 if it is empty
+	if serialNumber == "" {
+		log.Error().Msg("Missing 'serial' parameter")
+		utils.ErrorBadRequest(w, fmt.Errorf("missing 'serial' parameter")) // error 400
+		return
+	}
+
+	// Return error 404 if the serial number was not found by the last scrape
+	collector, ok := d.metricsSource.SerialCollector(serialNumber)
+	if !ok {
+		log.Error().Str("serial_number", serialNumber).Msg("Unknown serial number")
+		utils.ErrorNotFound(w, fmt.Errorf("serial number %s is not known", serialNumber)) // error 404
+		return
+	}
+
+	registry := prometheus.NewRegistry()
+	if err := registry.Register(collector); err != nil {
+		log.Error().Err(err).Str("serial_number", serialNumber).Msg("Failed to register the ONU collector")
+		utils.ErrorInternalServerError(w, fmt.Errorf("cannot collect metrics")) // error 500
+		return
+	}
+
+	promhttp.HandlerFor(registry, promhttp.HandlerOpts{}).ServeHTTP(w, r)
+}
@@ -0,0 +1,262 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/config"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/middleware"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/usecase"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeWalkRepository walks a fixed number of ONU name rows under any OID.
+type fakeWalkRepository struct {
+	rows   int
+	err    error
+	walked []string
+}
+
+func (f *fakeWalkRepository) Get(_ context.Context, _ []string) (*gosnmp.SnmpPacket, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeWalkRepository) Walk(_ context.Context, oid string, walkFunc func(pdu gosnmp.SnmpPDU) error) error {
+	f.walked = append(f.walked, oid)
+	if f.err != nil {
+		return f.err
+	}
+	for i := 1; i <= f.rows; i++ {
+		if err := walkFunc(gosnmp.SnmpPDU{Name: oid + "." + strconv.Itoa(i), Type: gosnmp.OctetString, Value: []byte("onu-" + strconv.Itoa(i))}); err != nil {
+			return err
+		}
+	}
+	return walkFunc(gosnmp.SnmpPDU{Name: oid + ".mac", Type: gosnmp.OctetString, Value: []byte{0xaa, 0xbb, 0xcc, 0x00, 0x11, 0xff}})
+}
+
+// fakeScanRange reports the default scan range of the collector.
+type fakeScanRange struct{}
+
+func (fakeScanRange) ScanRange() model.ScanRange {
+	return model.ScanRange{BoardMin: 1, BoardMax: 2, PonMin: 1, PonMax: 16}
+}
+
+// walkResponse mirrors the JSON returned by GetWalk.
+type walkResponse struct {
+	Code int                  `json:"code"`
+	Data model.SnmpWalkResult `json:"data"`
+}
+
+// newTestDebugRouter returns GetWalk behind the debug token, as registered by the router.
+func newTestDebugRouter(repo *fakeWalkRepository, maxResults int) http.Handler {
+	cfg := &config.Config{DebugCfg: config.DebugConfig{Enabled: true, Token: "s3cret", WalkMaxResults: maxResults}}
+	debugHandler := NewDebugHandler(usecase.NewDebugUsecase(repo, cfg, fakeScanRange{}), fakeMetricsSource{})
+	return middleware.BearerToken(cfg.DebugCfg.Token)(http.HandlerFunc(debugHandler.GetWalk))
+}
+
+func TestGetWalk(t *testing.T) {
+	const oid = ".1.3.6.1.4.1.3902.1082.500.10.2.3.3.1.2.285278465"
+	repo := &fakeWalkRepository{rows: 2}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/debug/walk?oid="+oid, nil)
+	req.Header.Set("Authorization", "Bearer s3cret")
+	rr := httptest.NewRecorder()
+	newTestDebugRouter(repo, 10).ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var response walkResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+	assert.Equal(t, []string{oid}, repo.walked)
+	assert.Equal(t, model.SnmpWalkResult{
+		OID:   oid,
+		Count: 3,
+		Entries: []model.SnmpWalkEntry{
+			{OID: oid + ".1", Type: "OctetString", Value: "onu-1"},
+			{OID: oid + ".2", Type: "OctetString", Value: "onu-2"},
+			{OID: oid + ".mac", Type: "OctetString", Value: "0xaabbcc0011ff"},
+		},
+	}, response.Data)
+}
+
+func TestGetWalkTruncated(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/debug/walk?oid=.1.3.6", nil)
+	req.Header.Set("Authorization", "Bearer s3cret")
+	rr := httptest.NewRecorder()
+	newTestDebugRouter(&fakeWalkRepository{rows: 50}, 5).ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var response walkResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+	assert.True(t, response.Data.Truncated)
+	assert.Equal(t, 5, response.Data.Count)
+	assert.Len(t, response.Data.Entries, 5)
+}
+
+func TestGetWalkErrors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		query         string
+		authorization string
+		walkErr       error
+		expectedCode  int
+	}{
+		{name: "missing token", query: "?oid=.1.3.6", expectedCode: http.StatusUnauthorized},
+		{name: "wrong token", query: "?oid=.1.3.6", authorization: "Bearer guess", expectedCode: http.StatusUnauthorized},
+		{name: "missing oid", query: "", authorization: "Bearer s3cret", expectedCode: http.StatusBadRequest},
+		{name: "non-numeric oid", query: "?oid=ifName", authorization: "Bearer s3cret", expectedCode: http.StatusBadRequest},
+		{name: "walk failure", query: "?oid=.1.3.6", authorization: "Bearer s3cret", walkErr: errors.New("request timeout"), expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &fakeWalkRepository{rows: 1, err: tc.walkErr}
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/debug/walk"+tc.query, nil)
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+			rr := httptest.NewRecorder()
+			newTestDebugRouter(repo, 10).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedCode, rr.Code)
+			if tc.expectedCode != http.StatusInternalServerError {
+				assert.Empty(t, repo.walked, "the OLT is not walked for rejected requests")
+			}
+		})
+	}
+}
+
+// configResponse mirrors the JSON returned by GetConfig.
+type configResponse struct {
+	Code int                   `json:"code"`
+	Data model.EffectiveConfig `json:"data"`
+}
+
+// newTestConfigRouter returns GetConfig behind the debug token, as registered by the router.
+func newTestConfigRouter(cfg *config.Config) http.Handler {
+	debugHandler := NewDebugHandler(usecase.NewDebugUsecase(&fakeWalkRepository{}, cfg, fakeScanRange{}), fakeMetricsSource{})
+	return middleware.BearerToken(cfg.DebugCfg.Token)(http.HandlerFunc(debugHandler.GetConfig))
+}
+
+func TestGetConfig(t *testing.T) {
+	cfg := &config.Config{
+		SnmpCfg:  config.SnmpConfig{IP: "192.0.2.10", Port: 161, Community: "private", WalkMethod: "next"},
+		DebugCfg: config.DebugConfig{Enabled: true, Token: "s3cret"},
+		OltCfg:   config.OltConfig{BaseOID1: ".1.3.6.1.4.1.3902.1082", BaseOID2: ".1.3.6.1.4.1.3902.1012"},
+		Pons: map[int]map[int]model.OltConfig{1: {1: {
+			OnuIDNameOID: ".500.10.2.3.3.1.2.285278465",
+			OnuTypeOID:   ".3.50.11.2.1.17.268501248",
+		}}, 2: {3: {
+			OnuIDNameOID:  ".500.10.2.3.3.1.2.285278979",
+			OnuTypeOID:    ".3.50.11.2.1.17.268501763",
+			OnuStatusOID:  ".500.10.2.3.8.1.4.285278979",
+			OnuLosFlagOID: ".500.10.2.3.8.1.30.285278979",
+		}}},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/debug/config?board_id=2&pon_id=3", nil)
+	req.Header.Set("Authorization", "Bearer s3cret")
+	rr := httptest.NewRecorder()
+	newTestConfigRouter(cfg).ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.NotContains(t, rr.Body.String(), "private", "the community is redacted")
+	assert.NotContains(t, rr.Body.String(), "s3cret", "the debug token is not returned")
+
+	var response configResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+	assert.Equal(t, 2, response.Data.Board)
+	assert.Equal(t, 3, response.Data.PON)
+	assert.Equal(t, map[string]string{
+		"onu_id_name":   cfg.OltCfg.BaseOID1 + cfg.Pons[2][3].OnuIDNameOID,
+		"onu_type":      cfg.OltCfg.BaseOID2 + cfg.Pons[2][3].OnuTypeOID,
+		"onu_status_id": cfg.OltCfg.BaseOID1 + cfg.Pons[2][3].OnuStatusOID,
+		"onu_los_flag":  cfg.OltCfg.BaseOID1 + cfg.Pons[2][3].OnuLosFlagOID,
+	}, response.Data.OIDs, "only the configured keys of the requested PON are returned")
+	assert.Equal(t, model.SnmpSettings{IP: "192.0.2.10", Port: 161, Community: "REDACTED", WalkMethod: "next"}, response.Data.Snmp)
+	assert.Equal(t, model.ScanRange{BoardMin: 1, BoardMax: 2, PonMin: 1, PonMax: 16}, response.Data.ScanRange)
+}
+
+func TestGetConfigErrors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		query         string
+		authorization string
+		expectedCode  int
+	}{
+		{name: "missing token", query: "?board_id=1&pon_id=1", expectedCode: http.StatusUnauthorized},
+		{name: "missing board", query: "?pon_id=1", authorization: "Bearer s3cret", expectedCode: http.StatusBadRequest},
+		{name: "invalid board", query: "?board_id=3&pon_id=1", authorization: "Bearer s3cret", expectedCode: http.StatusBadRequest},
+		{name: "invalid pon", query: "?board_id=1&pon_id=17", authorization: "Bearer s3cret", expectedCode: http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &config.Config{DebugCfg: config.DebugConfig{Enabled: true, Token: "s3cret"}}
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/debug/config"+tc.query, nil)
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+			rr := httptest.NewRecorder()
+			newTestConfigRouter(cfg).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedCode, rr.Code)
+		})
+	}
+}
+
+// fakeMetricsSource serves a fixed metric for the serial number ZTEGC0000001 only.
+type fakeMetricsSource struct{}
+
+func (fakeMetricsSource) SerialCollector(serialNumber string) (prometheus.Collector, bool) {
+	if serialNumber != "ZTEGC0000001" {
+		return nil, false
+	}
+	gauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "zte_onu_rx_power_dbm", Help: "ONU RX power in dBm."}, []string{"serial_number"})
+	gauge.WithLabelValues(serialNumber).Set(-21.5)
+	return gauge, true
+}
+
+// newTestMetricsRouter returns GetMetrics behind the debug token, as registered by the router.
+func newTestMetricsRouter() http.Handler {
+	cfg := &config.Config{DebugCfg: config.DebugConfig{Enabled: true, Token: "s3cret"}}
+	debugHandler := NewDebugHandler(usecase.NewDebugUsecase(&fakeWalkRepository{}, cfg, fakeScanRange{}), fakeMetricsSource{})
+	return middleware.BearerToken(cfg.DebugCfg.Token)(http.HandlerFunc(debugHandler.GetMetrics))
+}
+
+func TestGetMetrics(t *testing.T) {
+	testCases := []struct {
+		name          string
+		query         string
+		authorization string
+		expectedCode  int
+	}{
+		{name: "known serial", query: "?serial=ZTEGC0000001", authorization: "Bearer s3cret", expectedCode: http.StatusOK},
+		{name: "unknown serial", query: "?serial=ZTEGC9999999", authorization: "Bearer s3cret", expectedCode: http.StatusNotFound},
+		{name: "missing serial", authorization: "Bearer s3cret", expectedCode: http.StatusBadRequest},
+		{name: "missing token", query: "?serial=ZTEGC0000001", expectedCode: http.StatusUnauthorized},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/debug/metrics"+tc.query, nil)
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+			rr := httptest.NewRecorder()
+			newTestMetricsRouter().ServeHTTP(rr, req)
+			assert.Equal(t, tc.expectedCode, rr.Code)
+			if tc.expectedCode == http.StatusOK {
+				assert.Contains(t, rr.Header().Get("Content-Type"), "text/plain")
+				assert.Contains(t, rr.Body.String(), `zte_onu_rx_power_dbm{serial_number="ZTEGC0000001"} -21.5`)
+			}
+		})
+	}
+}
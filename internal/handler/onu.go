@@ -103,7 +103,7 @@ func (o *OnuHandler) GetByBoardIDAndPonID(w http.ResponseWriter, r *http.Request
 }
 
 // GetByBoardIDPonIDAndOnuID is a method to get onu info by board id, pon id, and onu id
-// example: http://localhost:8080/board/1/pon/1/onu
+// example: http://localhost:8080/board/1/pon/1/onu/1 or http://localhost:8080/onu/1/1/1
 func (o *OnuHandler) GetByBoardIDPonIDAndOnuID(w http.ResponseWriter, r *http.Request) {
 
 	boardID := chi.URLParam(r, "board_id") // 1 or 2
@@ -140,7 +140,7 @@ func (o *OnuHandler) GetByBoardIDPonIDAndOnuID(w http.ResponseWriter, r *http.Re
 	}
 
 	// Call usecase to get data from SNMP
-	onuInfoList, err := o.ponUsecase.GetByBoardIDPonIDAndOnuID(boardIDInt, ponIDInt, onuIDInt)
+	onuInfoList, err := o.ponUsecase.GetByBoardIDPonIDAndOnuID(r.Context(), boardIDInt, ponIDInt, onuIDInt)
 
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to get data from SNMP")
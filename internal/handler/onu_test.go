@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeOnuUsecase serves the details of fixed ONUs, keyed by board, PON and ONU ID. Only the
+// single ONU lookup is implemented.
+type fakeOnuUsecase struct {
+	usecase.OnuUseCaseInterface
+	onus map[[3]int]model.ONUCustomerInfo
+	err  error
+}
+
+func (f *fakeOnuUsecase) GetByBoardIDPonIDAndOnuID(_ context.Context, boardID, ponID, onuID int) (model.ONUCustomerInfo, error) {
+	return f.onus[[3]int{boardID, ponID, onuID}], f.err
+}
+
+// onuResponse mirrors the JSON returned by GetByBoardIDPonIDAndOnuID.
+type onuResponse struct {
+	Code int                   `json:"code"`
+	Data model.ONUCustomerInfo `json:"data"`
+}
+
+// serveOnu routes a request to GetByBoardIDPonIDAndOnuID like /api/v1/onu/{board_id}/{pon_id}/{onu_id}.
+func serveOnu(onuUsecase usecase.OnuUseCaseInterface, path string) *httptest.ResponseRecorder {
+	router := chi.NewRouter()
+	router.Get("/api/v1/onu/{board_id}/{pon_id}/{onu_id}", NewOnuHandler(onuUsecase).GetByBoardIDPonIDAndOnuID)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+	return rr
+}
+
+func TestGetByBoardIDPonIDAndOnuID(t *testing.T) {
+	onuUsecase := &fakeOnuUsecase{onus: map[[3]int]model.ONUCustomerInfo{
+		{2, 16, 7}: {Board: 2, PON: 16, ID: 7, Name: "customer-7", SerialNumber: "ZTEGC0000007", Status: "Online", RXPower: "-19.50"},
+	}}
+
+	rr := serveOnu(onuUsecase, "/api/v1/onu/2/16/7")
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+	var response onuResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, onuUsecase.onus[[3]int{2, 16, 7}], response.Data)
+}
+
+func TestGetByBoardIDPonIDAndOnuIDErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		err  error
+		code int
+	}{
+		{name: "Board out of range", path: "/api/v1/onu/3/1/1", code: http.StatusBadRequest},
+		{name: "Board not a number", path: "/api/v1/onu/x/1/1", code: http.StatusBadRequest},
+		{name: "PON out of range", path: "/api/v1/onu/1/17/1", code: http.StatusBadRequest},
+		{name: "ONU ID out of range", path: "/api/v1/onu/1/1/0", code: http.StatusBadRequest},
+		{name: "ONU ID not a number", path: "/api/v1/onu/1/1/one", code: http.StatusBadRequest},
+		{name: "Unknown ONU", path: "/api/v1/onu/1/1/5", code: http.StatusNotFound},
+		{name: "SNMP failure", path: "/api/v1/onu/1/1/5", err: errors.New("failed to walk OID"), code: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := serveOnu(&fakeOnuUsecase{err: tt.err}, tt.path)
+			assert.Equal(t, tt.code, rr.Code)
+		})
+	}
+}
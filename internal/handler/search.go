@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/usecase"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/utils"
+	"github.com/rs/zerolog/log"
+)
+
+// SearchHandler is a struct that represent the search handler
+type SearchHandler struct {
+	searchUsecase usecase.SearchUseCaseInterface
+}
+
+// NewSearchHandler will create an object that represent the search handler
+func NewSearchHandler(searchUsecase usecase.SearchUseCaseInterface) *SearchHandler {
+	return &SearchHandler{searchUsecase: searchUsecase}
+}
+
+// FindBySerialNumber is a method to find the board, PON and ID of an ONU by its serial number
+// example: http://localhost:8081/api/v1/onu/search?serial=ZTEGC0000001
+func (s *SearchHandler) FindBySerialNumber(w http.ResponseWriter, r *http.Request) {
+
+	serial := strings.TrimSpace(r.URL.Query().Get("serial"))
+
+	log.Info().Str("serial", serial).Msg("Received a request to FindBySerialNumber")
+
+	// Validate serial value and return error 400 if it is missing
+	if serial == "" {
+		log.Error().Msg("Missing 'serial' parameter")
+		utils.ErrorBadRequest(w, fmt.Errorf("missing 'serial' parameter")) // error 400
+		return
+	}
+
+	// Call usecase to search the scanned PONs from SNMP
+	onuInfo, err := s.searchUsecase.FindBySerialNumber(r.Context(), serial)
+
+	if errors.Is(err, usecase.ErrOnuNotFound) {
+		log.Error().Str("serial", serial).Msg("Data not found")
+		utils.ErrorNotFound(w, fmt.Errorf("data not found")) // error 404
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get data from SNMP")
+		utils.ErrorInternalServerError(w, fmt.Errorf("cannot get data from snmp")) // error 500
+		return
+	}
+
+	// Convert result to JSON format according to WebResponse structure
+	response := utils.WebResponse{
+		Code:   http.StatusOK, // 200
+		Status: "OK",          // "OK"
+		Data:   onuInfo,       // data
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, response) // 200
+}
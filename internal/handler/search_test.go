@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSearchUsecase finds the ONU of a single serial number, placed on board 2 PON 9.
+type fakeSearchUsecase struct {
+	err error
+}
+
+func (f *fakeSearchUsecase) FindBySerialNumber(_ context.Context, serial string) (model.ONUCustomerInfo, error) {
+	if f.err != nil {
+		return model.ONUCustomerInfo{}, f.err
+	}
+	if serial != "ZTEGC0000003" {
+		return model.ONUCustomerInfo{}, usecase.ErrOnuNotFound
+	}
+	return model.ONUCustomerInfo{Board: 2, PON: 9, ID: 3, SerialNumber: serial, Status: "Online"}, nil
+}
+
+func serveSearch(searchUsecase usecase.SearchUseCaseInterface, path string) *httptest.ResponseRecorder {
+	rr := httptest.NewRecorder()
+	NewSearchHandler(searchUsecase).FindBySerialNumber(rr, httptest.NewRequest(http.MethodGet, path, nil))
+	return rr
+}
+
+func TestFindBySerialNumber(t *testing.T) {
+	rr := serveSearch(&fakeSearchUsecase{}, "/api/v1/onu/search?serial=ZTEGC0000003")
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var response onuResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, model.ONUCustomerInfo{Board: 2, PON: 9, ID: 3, SerialNumber: "ZTEGC0000003", Status: "Online"}, response.Data)
+}
+
+func TestFindBySerialNumberErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		err  error
+		code int
+	}{
+		{name: "Missing serial", path: "/api/v1/onu/search", code: http.StatusBadRequest},
+		{name: "Blank serial", path: "/api/v1/onu/search?serial=%20", code: http.StatusBadRequest},
+		{name: "Not found", path: "/api/v1/onu/search?serial=ZTEGC9999999", code: http.StatusNotFound},
+		{name: "SNMP error", path: "/api/v1/onu/search?serial=ZTEGC0000003", err: errors.New("request timeout"), code: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := serveSearch(&fakeSearchUsecase{err: tt.err}, tt.path)
+
+			assert.Equal(t, tt.code, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/utils"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rs/zerolog/log"
+)
+
+// TargetMetricsSource provides the Prometheus collectors of the OLTs polled by target.
+type TargetMetricsSource interface {
+	ScanRange() model.ScanRange
+	TargetCollectors(target string, scanRange model.ScanRange) ([]prometheus.Collector, bool)
+}
+
+// TargetHandler is a struct that represent the handler of the multi-target metrics
+type TargetHandler struct {
+	metricsSource TargetMetricsSource
+}
+
+// NewTargetHandler will create an object that represent the multi-target metrics handler
+func NewTargetHandler(metricsSource TargetMetricsSource) *TargetHandler {
+	return &TargetHandler{metricsSource: metricsSource}
+}
+
+// GetMetrics is a method to get the metrics of one OLT in the Prometheus text format, scanning the
+// boards and PONs of the optional board_min, board_max, pon_min and pon_max parameters
+// example: http://localhost:8081/metrics?target=10.0.0.2&board_min=1&board_max=1
+func (t *TargetHandler) GetMetrics(w http.ResponseWriter, r *http.Request) {
+
+	log.Info().Msg("Received a request to GetMetrics of a target")
+
+	query := r.URL.Query()
+	target := query.Get("target")
+
+	// Validate target value and return error 400 if it is empty
+	if target == "" {
+		log.Error().Msg("Missing 'target' parameter")
+		utils.ErrorBadRequest(w, fmt.Errorf("missing 'target' parameter")) // error 400
+		return
+	}
+
+	// Fill the scan range from the parameters, keeping the default bound of the missing ones
+	scanRange := t.metricsSource.ScanRange()
+	bounds := []struct {
+		name     string
+		value    *int
+		min, max int
+	}{
+		{"board_min", &scanRange.BoardMin, 1, 2},
+		{"board_max", &scanRange.BoardMax, 1, 2},
+		{"pon_min", &scanRange.PonMin, 1, 16},
+		{"pon_max", &scanRange.PonMax, 1, 16},
+	}
+	for _, bound := range bounds {
+		if !query.Has(bound.name) {
+			continue
+		}
+		value, err := strconv.Atoi(query.Get(bound.name))
+
+		// Validate the bound and return error 400 if it is not a board or PON ID
+		if err != nil || value < bound.min || value > bound.max {
+			log.Error().Err(err).Msgf("Invalid '%s' parameter", bound.name)
+			utils.ErrorBadRequest(w, fmt.Errorf("invalid '%s' parameter. It must be between %d and %d", bound.name, bound.min, bound.max)) // error 400
+			return
+		}
+		*bound.value = value
+	}
+
+	// Return error 400 if a range is empty
+	if scanRange.BoardMin > scanRange.BoardMax || scanRange.PonMin > scanRange.PonMax {
+		log.Error().Interface("scan_range", scanRange).Msg("Empty scan range")
+		utils.ErrorBadRequest(w, fmt.Errorf("invalid scan range. The minimum must not exceed the maximum")) // error 400
+		return
+	}
+
+	// Return error 404 if the target is not configured
+	collectors, ok := t.metricsSource.TargetCollectors(target, scanRange)
+	if !ok {
+		log.Error().Str("target", target).Msg("Unknown target")
+		utils.ErrorNotFound(w, fmt.Errorf("target %s is not configured", target)) // error 404
+		return
+	}
+
+	registry := prometheus.NewRegistry()
+	for _, collector := range collectors {
+		if err := registry.Register(collector); err != nil {
+			log.Error().Err(err).Str("target", target).Msg("Failed to register the target collectors")
+			utils.ErrorInternalServerError(w, fmt.Errorf("cannot collect metrics")) // error 500
+			return
+		}
+	}
+
+	promhttp.HandlerFor(registry, promhttp.HandlerOpts{}).ServeHTTP(w, r)
+}
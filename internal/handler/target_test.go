@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeTargetSource serves a single gauge labeled with the requested target, for known targets.
+type fakeTargetSource struct {
+	targets   map[string]bool
+	requested []model.ScanRange
+}
+
+func (f *fakeTargetSource) ScanRange() model.ScanRange {
+	return model.ScanRange{BoardMin: 1, BoardMax: 2, PonMin: 1, PonMax: 16}
+}
+
+func (f *fakeTargetSource) TargetCollectors(target string, scanRange model.ScanRange) ([]prometheus.Collector, bool) {
+	if !f.targets[target] {
+		return nil, false
+	}
+	f.requested = append(f.requested, scanRange)
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{Name: "zte_target_up", ConstLabels: prometheus.Labels{"olt": target}})
+	gauge.Set(1)
+	return []prometheus.Collector{gauge}, true
+}
+
+func TestTargetGetMetrics(t *testing.T) {
+	source := &fakeTargetSource{targets: map[string]bool{"10.0.0.1": true, "10.0.0.2": true}}
+	targetHandler := NewTargetHandler(source)
+
+	for _, target := range []string{"10.0.0.1", "10.0.0.2"} {
+		rr := httptest.NewRecorder()
+		targetHandler.GetMetrics(rr, httptest.NewRequest(http.MethodGet, "/metrics?target="+target+"&board_min=2&pon_max=8", nil))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `zte_target_up{olt="`+target+`"} 1`)
+	}
+	assert.Equal(t, []model.ScanRange{
+		{BoardMin: 2, BoardMax: 2, PonMin: 1, PonMax: 8},
+		{BoardMin: 2, BoardMax: 2, PonMin: 1, PonMax: 8},
+	}, source.requested, "missing bounds keep the default scan range")
+}
+
+func TestTargetGetMetricsInvalid(t *testing.T) {
+	targetHandler := NewTargetHandler(&fakeTargetSource{targets: map[string]bool{"10.0.0.1": true}})
+
+	tests := []struct {
+		query string
+		code  int
+	}{
+		{"target=", http.StatusBadRequest},
+		{"target=10.0.0.1&board_max=3", http.StatusBadRequest},
+		{"target=10.0.0.1&pon_min=x", http.StatusBadRequest},
+		{"target=10.0.0.1&pon_min=9&pon_max=8", http.StatusBadRequest},
+		{"target=10.0.0.9", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		rr := httptest.NewRecorder()
+		targetHandler.GetMetrics(rr, httptest.NewRequest(http.MethodGet, "/metrics?"+tt.query, nil))
+		assert.Equal(t, tt.code, rr.Code, tt.query)
+	}
+}
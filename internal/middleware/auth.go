@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/utils"
+)
+
+// BearerToken is a middleware function that only lets through requests carrying the given token
+// in an "Authorization: Bearer <token>" header. An empty token rejects every request.
+func BearerToken(token string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			provided, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if token == "" || !found || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+				utils.ErrorUnauthorized(w, errors.New("missing or invalid token"))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -1,20 +1,60 @@
 package model
 
-// OltConfig struct is a struct that represent the OLT configuration
+// OltConfig struct is a struct that represent the OLT configuration, the OIDs of one board and PON
 type OltConfig struct {
-	BaseOID                   string
-	OnuIDNameOID              string
-	OnuTypeOID                string
-	OnuSerialNumberOID        string
-	OnuRxPowerOID             string
-	OnuTxPowerOID             string
-	OnuStatusOID              string
-	OnuIPAddressOID           string
-	OnuDescriptionOID         string
-	OnuLastOnlineOID          string
-	OnuLastOfflineOID         string
-	OnuLastOfflineReasonOID   string
-	OnuGponOpticalDistanceOID string
+	BaseOID                        string `mapstructure:"-"` // Filled from OltCfg.BaseOID1 on lookup
+	OnuIDNameOID                   string `mapstructure:"onu_id_name"`
+	OnuTypeOID                     string `mapstructure:"onu_type"`
+	OnuSerialNumberOID             string `mapstructure:"onu_serial_number"`
+	OnuRxPowerOID                  string `mapstructure:"onu_rx_power"`
+	OnuTxPowerOID                  string `mapstructure:"onu_tx_power"`
+	OnuStatusOID                   string `mapstructure:"onu_status_id"`
+	OnuIPAddressOID                string `mapstructure:"onu_ip_address"`
+	OnuDescriptionOID              string `mapstructure:"onu_description"`
+	OnuLastOnlineOID               string `mapstructure:"onu_last_online_time"`
+	OnuLastOfflineOID              string `mapstructure:"onu_last_offline_time"`
+	OnuLastOfflineReasonOID        string `mapstructure:"onu_last_offline_reason"`
+	OnuGponOpticalDistanceOID      string `mapstructure:"onu_gpon_optical_distance"`
+	OnuDownstreamOctetsOID         string `mapstructure:"onu_downstream_octets"`
+	OnuUpstreamOctetsOID           string `mapstructure:"onu_upstream_octets"`
+	OnuRegisteredTimeOID           string `mapstructure:"onu_registered_time"`
+	OnuMACAddressOID               string `mapstructure:"onu_mac_address"`
+	OnuRxDroppedOID                string `mapstructure:"onu_rx_dropped"`
+	OnuTxDroppedOID                string `mapstructure:"onu_tx_dropped"`
+	OnuEquipmentIDOID              string `mapstructure:"onu_equipment_id"`
+	OnuQosProfileOID               string `mapstructure:"onu_qos_profile"`
+	OnuSignalQualityOID            string `mapstructure:"onu_signal_quality"`
+	OnuMulticastEnabledOID         string `mapstructure:"onu_multicast_enabled"`
+	OnuMulticastGroupsOID          string `mapstructure:"onu_multicast_groups"`
+	PonTxPowerOID                  string `mapstructure:"pon_tx_power"`
+	PonPortTxPowerOID              string `mapstructure:"pon_port_tx_power"`
+	PonPortStatusOID               string `mapstructure:"pon_port_status"`
+	OnuUpgradeStateOID             string `mapstructure:"onu_upgrade_state"`
+	OnuLoopDetectedOID             string `mapstructure:"onu_loop_detected"`
+	OnuIPGatewayOID                string `mapstructure:"onu_ip_gateway"`
+	OnuIPMaskOID                   string `mapstructure:"onu_ip_mask"`
+	OnuLosFlagOID                  string `mapstructure:"onu_los_flag"`
+	OnuCatvStatusOID               string `mapstructure:"onu_catv_status"`
+	OnuCatvLevelOID                string `mapstructure:"onu_catv_level"`
+	OnuProfileTemplateOID          string `mapstructure:"onu_profile_template"`
+	OnuRegistrationFailReasonOID   string `mapstructure:"onu_registration_fail_reason"`
+	OnuNegotiatedDownstreamRateOID string `mapstructure:"onu_negotiated_downstream_rate"`
+	OnuNegotiatedUpstreamRateOID   string `mapstructure:"onu_negotiated_upstream_rate"`
+	OnuBatteryStatusOID            string `mapstructure:"onu_battery_status"`
+	OnuTcontAllocationOID          string `mapstructure:"onu_tcont_allocation"`
+	OnuUniSpeedOID                 string `mapstructure:"onu_uni_speed"`
+	OnuUniDuplexOID                string `mapstructure:"onu_uni_duplex"`
+	PonTransceiverTemperatureOID   string `mapstructure:"pon_transceiver_temperature"`
+	PonTransceiverTxPowerOID       string `mapstructure:"pon_transceiver_tx_power"`
+	PonTransceiverRxPowerOID       string `mapstructure:"pon_transceiver_rx_power"`
+	PonTransceiverBiasOID          string `mapstructure:"pon_transceiver_bias"`
+	PonTransceiverVoltageOID       string `mapstructure:"pon_transceiver_voltage"`
+	OnuTemperatureOID              string `mapstructure:"onu_temperature"`
+	OnuVoltageOID                  string `mapstructure:"onu_voltage"`
+	OnuBiasCurrentOID              string `mapstructure:"onu_bias_current"`
+	OnuAlarmCountOID               string `mapstructure:"onu_alarm_count"`
+	OnuTransceiverTypeOID          string `mapstructure:"onu_transceiver_type"`
+	OnuEncryptionOID               string `mapstructure:"onu_encryption"`
 }
 
 // ONUInfo struct is a struct that represent the ONU information
@@ -33,27 +73,59 @@ type ONUInfoPerBoard struct {
 	SerialNumber string `json:"serial_number"`
 	RXPower      string `json:"rx_power"`
 	Status       string `json:"status"`
+	MACAddress   string `json:"mac_address,omitempty"`
 }
 
 // ONUCustomerInfo struct is a struct that represent the detailed ONU information for customer
 type ONUCustomerInfo struct {
-	Board                int    `json:"board"`
-	PON                  int    `json:"pon"`
-	ID                   int    `json:"onu_id"`
-	Name                 string `json:"name"`
-	Description          string `json:"description"`
-	OnuType              string `json:"onu_type"`
-	SerialNumber         string `json:"serial_number"`
-	RXPower              string `json:"rx_power"`
-	TXPower              string `json:"tx_power"`
-	Status               string `json:"status"`
-	IPAddress            string `json:"ip_address"`
-	LastOnline           string `json:"last_online"`
-	LastOffline          string `json:"last_offline"`
-	Uptime               string `json:"uptime"`
-	LastDownTimeDuration string `json:"last_down_time_duration"`
-	LastOfflineReason    string `json:"offline_reason"`
-	GponOpticalDistance  string `json:"gpon_optical_distance"`
+	Board                    int               `json:"board"`
+	PON                      int               `json:"pon"`
+	ID                       int               `json:"onu_id"`
+	Name                     string            `json:"name"`
+	Description              string            `json:"description"`
+	OnuType                  string            `json:"onu_type"`
+	SerialNumber             string            `json:"serial_number"`
+	RXPower                  string            `json:"rx_power"`
+	TXPower                  string            `json:"tx_power"`
+	Status                   string            `json:"status"`
+	IPAddress                string            `json:"ip_address"`
+	IPGateway                string            `json:"ip_gateway,omitempty"`
+	IPMask                   string            `json:"ip_mask,omitempty"`
+	MACAddress               string            `json:"mac_address,omitempty"`
+	EquipmentID              string            `json:"equipment_id,omitempty"`
+	TransceiverType          string            `json:"transceiver_type,omitempty"` // Optical module of the ONU, e.g. "GPON Class B+"
+	ProfileTemplate          string            `json:"profile_template,omitempty"`
+	QosProfile               string            `json:"qos_profile,omitempty"`
+	SignalQuality            string            `json:"signal_quality,omitempty"`
+	Temperature              string            `json:"temperature,omitempty"`  // Degrees Celsius
+	Voltage                  string            `json:"voltage,omitempty"`      // Volts
+	BiasCurrent              string            `json:"bias_current,omitempty"` // Milliamperes
+	MulticastEnabled         string            `json:"multicast_enabled,omitempty"`
+	MulticastGroups          string            `json:"multicast_groups,omitempty"`
+	LoopDetected             string            `json:"loop_detected,omitempty"`
+	EncryptionEnabled        string            `json:"encryption_enabled,omitempty"`
+	AlarmCount               string            `json:"alarm_count,omitempty"`
+	CatvEnabled              string            `json:"catv_enabled,omitempty"`
+	CatvLevel                string            `json:"catv_level,omitempty"`
+	Tconts                   []TcontAllocation `json:"tconts,omitempty"`
+	UniPorts                 []UniPort         `json:"uni_ports,omitempty"`
+	NegotiatedDownstreamRate string            `json:"negotiated_downstream_mbps,omitempty"`
+	NegotiatedUpstreamRate   string            `json:"negotiated_upstream_mbps,omitempty"`
+	LastOnline               string            `json:"last_online"`
+	LastOffline              string            `json:"last_offline"`
+	Uptime                   string            `json:"uptime"`
+	LastDownTimeDuration     string            `json:"last_down_time_duration"`
+	LastOfflineReason        string            `json:"offline_reason"`
+	GponOpticalDistance      string            `json:"gpon_optical_distance"`
+	DownstreamOctets         string            `json:"downstream_octets,omitempty"`
+	UpstreamOctets           string            `json:"upstream_octets,omitempty"`
+	RxDropped                string            `json:"rx_dropped,omitempty"`
+	TxDropped                string            `json:"tx_dropped,omitempty"`
+	RegisteredAt             string            `json:"registered_at,omitempty"`
+	UpgradeState             string            `json:"upgrade_state,omitempty"`
+	BatteryStatus            string            `json:"battery_status,omitempty"`
+	RegistrationFailCode     string            `json:"registration_fail_code,omitempty"`
+	RegistrationFailReason   string            `json:"registration_fail_reason,omitempty"`
 }
 
 // OnuID struct is a struct that represent the ONU ID
@@ -89,3 +161,141 @@ type PaginationResult struct {
 	OnuInformationList []ONUInfoPerBoard
 	Count              int
 }
+
+// OnuAlarm struct is a struct that represent an ONU in an alarm condition
+type OnuAlarm struct {
+	Board               int      `json:"board"`
+	PON                 int      `json:"pon"`
+	ID                  int      `json:"onu_id"`
+	Name                string   `json:"name"`
+	SerialNumber        string   `json:"serial_number"`
+	Status              string   `json:"status"`
+	RXPower             string   `json:"rx_power"`
+	TXPower             string   `json:"tx_power"`
+	GponOpticalDistance string   `json:"gpon_optical_distance"`
+	Severity            string   `json:"severity"`
+	Reasons             []string `json:"reasons"`
+}
+
+// OnuAlarmList struct is a struct that represent the alarms derived from the last scrape
+type OnuAlarmList struct {
+	ScrapedAt string     `json:"scraped_at"`
+	Alarms    []OnuAlarm `json:"alarms"`
+}
+
+// SnmpWalkEntry struct is a struct that represent a single OID returned by a debug SNMP walk
+type SnmpWalkEntry struct {
+	OID   string `json:"oid"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// SnmpWalkResult struct is a struct that represent the OIDs returned by a debug SNMP walk, capped
+// in count. Truncated is true when the walk was stopped at the cap.
+type SnmpWalkResult struct {
+	OID       string          `json:"oid"`
+	Count     int             `json:"count"`
+	Truncated bool            `json:"truncated"`
+	Entries   []SnmpWalkEntry `json:"entries"`
+}
+
+// TcontAllocation struct is a struct that represent an upstream T-CONT allocated to an ONU
+type TcontAllocation struct {
+	Index    int    `json:"index"`
+	AllocID  int    `json:"alloc_id"`
+	Profile  string `json:"profile"`             // DBA (bandwidth) profile of the T-CONT
+	GemPorts int    `json:"gem_ports,omitempty"` // Number of GEM ports mapped to the T-CONT, 0 when not reported
+}
+
+// UniPort struct is a struct that represent the negotiated link of an Ethernet (UNI) port of an ONU
+type UniPort struct {
+	Port      int     `json:"port"`
+	SpeedMbps float64 `json:"speed_mbps,omitempty"` // 0 when the port is down or the speed is not reported
+	Duplex    string  `json:"duplex,omitempty"`     // "full" or "half", empty when not reported
+}
+
+// ScanRange struct is a struct that represent the boards and PONs scanned by the Prometheus collector
+type ScanRange struct {
+	BoardMin int `json:"board_min"`
+	BoardMax int `json:"board_max"`
+	PonMin   int `json:"pon_min"`
+	PonMax   int `json:"pon_max"`
+}
+
+// SnmpSettings struct is a struct that represent the SNMP connection settings, with the community
+// redacted and the SNMPv3 passphrases left out
+type SnmpSettings struct {
+	IP          string `json:"ip"`
+	Port        uint16 `json:"port"`
+	Version     string `json:"version,omitempty"`
+	Community   string `json:"community"`
+	V3Username  string `json:"v3_username,omitempty"`
+	ContextName string `json:"context_name,omitempty"`
+	WalkMethod  string `json:"walk_method"`
+	LocalAddr   string `json:"local_addr,omitempty"`
+}
+
+// EffectiveConfig struct is a struct that represent the configuration in use for a board and PON,
+// returned by the debug API. OIDs holds the full OID of every configured key of the PON, without
+// the ONU index.
+type EffectiveConfig struct {
+	Board     int               `json:"board"`
+	PON       int               `json:"pon"`
+	ScanRange ScanRange         `json:"scan_range"`
+	Snmp      SnmpSettings      `json:"snmp"`
+	OIDs      map[string]string `json:"oids"`
+}
+
+// PonTxPower struct is a struct that represent the downstream transmit power of an OLT PON port
+type PonTxPower struct {
+	Board   int    `json:"board"`
+	PON     int    `json:"pon"`
+	TxPower string `json:"tx_power"`
+}
+
+// PonPort struct is a struct that represent the readings of an OLT PON port: its transmit power
+// and its operational status, as an IF-MIB ifOperStatus (1=up, 2=down, ...). A reading that is not
+// configured or could not be read is empty, or 0 for the status.
+type PonPort struct {
+	Board   int    `json:"board"`
+	PON     int    `json:"pon"`
+	TxPower string `json:"tx_power"`
+	Status  int    `json:"status"`
+}
+
+// PonTransceiver struct is a struct that represent the digital diagnostics (DDM) of the optical
+// module of an OLT PON port. Readings that are not configured or not available are empty.
+type PonTransceiver struct {
+	Board       int    `json:"board"`
+	PON         int    `json:"pon"`
+	Temperature string `json:"temperature,omitempty"` // Degrees Celsius
+	TxPower     string `json:"tx_power,omitempty"`    // dBm
+	RxPower     string `json:"rx_power,omitempty"`    // dBm
+	Bias        string `json:"bias,omitempty"`        // Milliamperes
+	Voltage     string `json:"voltage,omitempty"`     // Volts
+}
+
+// PowerSupply struct is a struct that represent a power supply unit of the OLT chassis
+type PowerSupply struct {
+	Index  int    `json:"index"`
+	Status string `json:"status"`
+}
+
+// ChassisHealth struct is a struct that represent the system readings of the OLT chassis.
+// Readings that are not configured or not available are empty.
+type ChassisHealth struct {
+	Uptime      string `json:"uptime,omitempty"`       // Seconds
+	CPUUsage    string `json:"cpu_usage,omitempty"`    // Percent
+	MemoryUsage string `json:"memory_usage,omitempty"` // Percent
+	Temperature string `json:"temperature,omitempty"`  // Degrees Celsius
+}
+
+// UplinkPort struct is a struct that represent an OLT uplink (NNI) port
+type UplinkPort struct {
+	IfIndex     int    `json:"if_index"`
+	Name        string `json:"name"`
+	AdminStatus int    `json:"admin_status"`
+	OperStatus  int    `json:"oper_status"`
+	InOctets    string `json:"in_octets"`
+	OutOctets   string `json:"out_octets"`
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrCacheMiss is returned by CacheRepository when nothing is cached for the requested key
+var ErrCacheMiss = errors.New("cache miss")
+
+// CacheRepository is an interface that represents the cache of ONU lists and empty ONU IDs per board and PON
+type CacheRepository interface {
+	GetONUList(boardID, ponID int) ([]model.ONUInfoPerBoard, error)                              // Get the cached ONUs of a PON, ErrCacheMiss if none
+	SetONUList(boardID, ponID int, onuList []model.ONUInfoPerBoard, ttl time.Duration) error     // Cache the ONUs of a PON for ttl
+	GetEmptyOnuIDList(boardID, ponID int) ([]model.OnuID, error)                                 // Get the cached empty ONU IDs of a PON, ErrCacheMiss if none
+	SetEmptyOnuIDList(boardID, ponID int, emptyOnuIDList []model.OnuID, ttl time.Duration) error // Cache the empty ONU IDs of a PON for ttl
+}
+
+// redisRepository is a struct that implements CacheRepository on top of a go-redis client. The ONU
+// lists are only used by the paginated API, the empty ONU IDs also by the empty slots of the scrape.
+type redisRepository struct {
+	client *redis.Client
+}
+
+// NewRedisClient returns a go-redis client of the Redis at host and port, selecting db, with the
+// given connection pool. A timeout of zero or less falls back to 2 seconds, it bounds the dial,
+// the read and the write of every call.
+func NewRedisClient(host, port, password string, db, minIdleConns, poolSize int, poolTimeout, timeout time.Duration) *redis.Client {
+	if timeout <= 0 {
+		timeout = 2 * time.Second
+	}
+	return redis.NewClient(&redis.Options{
+		Addr:         net.JoinHostPort(host, port),
+		Password:     password,
+		DB:           db,
+		MinIdleConns: minIdleConns,
+		PoolSize:     poolSize,
+		PoolTimeout:  poolTimeout,
+		DialTimeout:  timeout,
+		ReadTimeout:  timeout,
+		WriteTimeout: timeout,
+	})
+}
+
+// NewRedisRepository is a constructor function to create a new instance of redisRepository
+func NewRedisRepository(client *redis.Client) CacheRepository {
+	return &redisRepository{client: client}
+}
+
+// onuListKey returns the Redis key of the ONU list of a PON
+func onuListKey(boardID, ponID int) string {
+	return fmt.Sprintf("onu_list:%d:%d", boardID, ponID)
+}
+
+// emptyOnuIDKey returns the Redis key of the empty ONU IDs of a PON
+func emptyOnuIDKey(boardID, ponID int) string {
+	return fmt.Sprintf("empty_onu_id:%d:%d", boardID, ponID)
+}
+
+// GetONUList returns the cached ONUs of a PON, or ErrCacheMiss when none are cached
+func (r *redisRepository) GetONUList(boardID, ponID int) ([]model.ONUInfoPerBoard, error) {
+	var onuList []model.ONUInfoPerBoard
+	if err := r.getJSON(onuListKey(boardID, ponID), &onuList); err != nil {
+		return nil, err
+	}
+	return onuList, nil
+}
+
+// SetONUList caches the ONUs of a PON for ttl
+func (r *redisRepository) SetONUList(boardID, ponID int, onuList []model.ONUInfoPerBoard, ttl time.Duration) error {
+	return r.setJSON(onuListKey(boardID, ponID), onuList, ttl)
+}
+
+// GetEmptyOnuIDList returns the cached empty ONU IDs of a PON, or ErrCacheMiss when none are cached
+func (r *redisRepository) GetEmptyOnuIDList(boardID, ponID int) ([]model.OnuID, error) {
+	var emptyOnuIDList []model.OnuID
+	if err := r.getJSON(emptyOnuIDKey(boardID, ponID), &emptyOnuIDList); err != nil {
+		return nil, err
+	}
+	return emptyOnuIDList, nil
+}
+
+// SetEmptyOnuIDList caches the empty ONU IDs of a PON for ttl
+func (r *redisRepository) SetEmptyOnuIDList(boardID, ponID int, emptyOnuIDList []model.OnuID, ttl time.Duration) error {
+	return r.setJSON(emptyOnuIDKey(boardID, ponID), emptyOnuIDList, ttl)
+}
+
+// getJSON decodes the JSON value of key into value, or returns ErrCacheMiss when the key is missing
+func (r *redisRepository) getJSON(key string, value any) error {
+	reply, err := r.client.Get(context.Background(), key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return ErrCacheMiss
+	}
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(reply, value); err != nil {
+		return fmt.Errorf("decode cached %s: %w", key, err)
+	}
+	return nil
+}
+
+// setJSON stores value as JSON under key for ttl
+func (r *redisRepository) setJSON(key string, value any, ttl time.Duration) error {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(context.Background(), key, encoded, max(ttl, time.Millisecond)).Err()
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newMiniredisRepository returns a Redis repository selecting db on a miniredis server requiring
+// password, or no authentication when it is empty.
+func newMiniredisRepository(t *testing.T, password string, db int) (*miniredis.Miniredis, CacheRepository) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	if password != "" {
+		server.RequireAuth(password)
+	}
+	return server, newRedisRepositoryOf(t, server, password, db)
+}
+
+// newRedisRepositoryOf returns a Redis repository authenticating with password and selecting db on
+// server.
+func newRedisRepositoryOf(t *testing.T, server *miniredis.Miniredis, password string, db int) CacheRepository {
+	t.Helper()
+	client := NewRedisClient(server.Host(), server.Port(), password, db, 0, 0, 0, time.Second)
+	t.Cleanup(func() { _ = client.Close() })
+	return NewRedisRepository(client)
+}
+
+func TestRedisRepository(t *testing.T) {
+	server, cache := newMiniredisRepository(t, "s3cret", 2)
+
+	_, err := cache.GetONUList(1, 3)
+	assert.ErrorIs(t, err, ErrCacheMiss)
+
+	onuList := []model.ONUInfoPerBoard{
+		{Board: 1, PON: 3, ID: 1, Name: "customer-1", SerialNumber: "ZTEGC0000001", Status: "Online"},
+		{Board: 1, PON: 3, ID: 2, Name: "customer-2", SerialNumber: "ZTEGC0000002", Status: "LOS"},
+	}
+	require.NoError(t, cache.SetONUList(1, 3, onuList, time.Minute))
+
+	cached, err := cache.GetONUList(1, 3)
+	require.NoError(t, err)
+	assert.Equal(t, onuList, cached)
+	server.Select(2)
+	assert.True(t, server.Exists("onu_list:1:3"), "cached in the selected database")
+	assert.Equal(t, time.Minute, server.TTL("onu_list:1:3"))
+
+	_, err = cache.GetEmptyOnuIDList(1, 3)
+	assert.ErrorIs(t, err, ErrCacheMiss)
+	emptyOnuIDList := []model.OnuID{{Board: 1, PON: 3, ID: 3}, {Board: 1, PON: 3, ID: 4}}
+	require.NoError(t, cache.SetEmptyOnuIDList(1, 3, emptyOnuIDList, time.Minute))
+	cachedIDs, err := cache.GetEmptyOnuIDList(1, 3)
+	require.NoError(t, err)
+	assert.Equal(t, emptyOnuIDList, cachedIDs)
+	assert.True(t, server.Exists("empty_onu_id:1:3"))
+
+	// Expired entries are misses again
+	server.FastForward(time.Minute)
+	_, err = cache.GetONUList(1, 3)
+	assert.ErrorIs(t, err, ErrCacheMiss)
+}
+
+func TestRedisRepositoryWithoutPassword(t *testing.T) {
+	_, cache := newMiniredisRepository(t, "", 0)
+	onuList := []model.ONUInfoPerBoard{{Board: 1, PON: 3, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online"}}
+	require.NoError(t, cache.SetONUList(1, 3, onuList, time.Minute))
+
+	cached, err := cache.GetONUList(1, 3)
+	require.NoError(t, err)
+	assert.Equal(t, onuList, cached)
+}
+
+func TestRedisRepositoryErrors(t *testing.T) {
+	protected := miniredis.RunT(t)
+	protected.RequireAuth("s3cret")
+	_, err := newRedisRepositoryOf(t, protected, "wrong", 0).GetONUList(1, 3)
+	assert.ErrorContains(t, err, "WRONGPASS")
+
+	server, cache := newMiniredisRepository(t, "s3cret", 0)
+	require.NoError(t, server.Set("onu_list:1:3", "not json"))
+	_, err = cache.GetONUList(1, 3)
+	assert.ErrorContains(t, err, "decode cached onu_list:1:3")
+	assert.NotErrorIs(t, err, ErrCacheMiss)
+}
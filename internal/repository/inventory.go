@@ -0,0 +1,246 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	_ "github.com/jackc/pgx/v5/stdlib" // Registers the "pgx" database/sql driver
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
+)
+
+// Databases the inventory can be kept in.
+const (
+	InventoryDriverPostgres = "postgres"
+	InventoryDriverMySQL    = "mysql"
+)
+
+// inventoryColumns are the columns of the inventory table, in the order of the upsert arguments.
+// serial_number is the primary key.
+var inventoryColumns = []string{"serial_number", "board", "pon", "onu_id", "name", "onu_type", "status", "details", "last_seen"}
+
+// InventoryRepository is an interface that represents the database keeping the inventory of the ONUs
+type InventoryRepository interface {
+	UpsertOnus(ctx context.Context, onus []model.ONUCustomerInfo, seenAt time.Time) error // Insert or update the ONUs, keyed by serial number
+}
+
+// inventoryDialect holds the SQL that differs between the supported databases.
+type inventoryDialect struct {
+	quote       func(identifier string) string
+	placeholder func(n int) string // Placeholder of the nth argument, from 1
+	createTable string             // Columns of CREATE TABLE, in the order of inventoryColumns
+	upsert      func(columns []string) string
+}
+
+// inventoryDialects are the dialects of the supported databases, by driver.
+var inventoryDialects = map[string]inventoryDialect{
+	InventoryDriverPostgres: {
+		quote:       func(identifier string) string { return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"` },
+		placeholder: func(n int) string { return fmt.Sprintf("$%d", n) },
+		createTable: "serial_number text PRIMARY KEY, board integer NOT NULL, pon integer NOT NULL, onu_id integer NOT NULL, " +
+			"name text NOT NULL, onu_type text NOT NULL, status text NOT NULL, details jsonb NOT NULL, last_seen timestamptz NOT NULL",
+		upsert: func(columns []string) string {
+			updates := make([]string, 0, len(columns))
+			for _, column := range columns {
+				updates = append(updates, column+" = EXCLUDED."+column)
+			}
+			return " ON CONFLICT (serial_number) DO UPDATE SET " + strings.Join(updates, ", ")
+		},
+	},
+	InventoryDriverMySQL: {
+		quote:       func(identifier string) string { return "`" + strings.ReplaceAll(identifier, "`", "``") + "`" },
+		placeholder: func(int) string { return "?" },
+		createTable: "serial_number varchar(64) PRIMARY KEY, board int NOT NULL, pon int NOT NULL, onu_id int NOT NULL, " +
+			"name text NOT NULL, onu_type text NOT NULL, status text NOT NULL, details json NOT NULL, last_seen datetime(6) NOT NULL",
+		upsert: func(columns []string) string {
+			updates := make([]string, 0, len(columns))
+			for _, column := range columns {
+				updates = append(updates, column+" = VALUES("+column+")")
+			}
+			return " ON DUPLICATE KEY UPDATE " + strings.Join(updates, ", ")
+		},
+	},
+}
+
+// sqlInventoryRepository is a struct that implements InventoryRepository on top of a PostgreSQL or
+// MySQL table, through database/sql.
+type sqlInventoryRepository struct {
+	db        *sql.DB
+	dialect   inventoryDialect
+	table     string        // Inventory table, created on first use when missing
+	batchSize int           // ONUs per INSERT statement
+	timeout   time.Duration // Bounds writing the ONUs of one call
+	mu        sync.Mutex
+	created   bool // Whether the table was created, or found, by a previous call
+}
+
+// OpenInventoryDB returns the database/sql handle of the inventory database of the given driver,
+// postgres or mysql. An empty port falls back to the default port of the driver. TLS is used when
+// the server offers it. The connection is only opened on first use.
+func OpenInventoryDB(driver, host, port, user, password, database string) (*sql.DB, error) {
+	switch driver {
+	case InventoryDriverPostgres:
+		if port == "" {
+			port = "5432"
+		}
+		dsn := url.URL{
+			Scheme:   "postgres",
+			User:     url.UserPassword(user, password),
+			Host:     net.JoinHostPort(host, port),
+			Path:     "/" + database,
+			RawQuery: "sslmode=prefer",
+		}
+		return sql.Open("pgx", dsn.String())
+	case InventoryDriverMySQL:
+		if port == "" {
+			port = "3306"
+		}
+		cfg := mysql.NewConfig()
+		cfg.User = user
+		cfg.Passwd = password
+		cfg.Net = "tcp"
+		cfg.Addr = net.JoinHostPort(host, port)
+		cfg.DBName = database
+		cfg.TLSConfig = "preferred"
+		return sql.Open("mysql", cfg.FormatDSN())
+	default:
+		return nil, fmt.Errorf("unsupported inventory driver %q, expected postgres or mysql", driver)
+	}
+}
+
+// NewSQLInventoryRepository is a constructor function to create a new instance of
+// sqlInventoryRepository writing to table of db, a database of the given driver. A batch size of
+// zero or less falls back to 500 ONUs, and is capped to the 65535 arguments of a statement. A
+// timeout of zero or less falls back to 30 seconds.
+func NewSQLInventoryRepository(db *sql.DB, driver, table string, batchSize int, timeout time.Duration) (InventoryRepository, error) {
+	dialect, ok := inventoryDialects[driver]
+	if !ok {
+		return nil, fmt.Errorf("unsupported inventory driver %q, expected postgres or mysql", driver)
+	}
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+	batchSize = min(batchSize, 65535/len(inventoryColumns))
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	return &sqlInventoryRepository{
+		db:        db,
+		dialect:   dialect,
+		table:     table,
+		batchSize: batchSize,
+		timeout:   timeout,
+	}, nil
+}
+
+// UpsertOnus writes the ONUs in a single transaction, in upsert statements of up to batchSize ONUs
+// each, so the inventory is updated by a whole scrape or not at all. An ONU seen more than once is
+// written once, with its last details. ONUs without a serial number are skipped.
+func (r *sqlInventoryRepository) UpsertOnus(ctx context.Context, onus []model.ONUCustomerInfo, seenAt time.Time) error {
+	rows := make([][]any, 0, len(onus))
+	indexes := make(map[string]int, len(onus)) // Row of every serial number
+	for _, onu := range onus {
+		if onu.SerialNumber == "" {
+			continue
+		}
+		row, err := inventoryRow(onu, seenAt)
+		if err != nil {
+			return err
+		}
+		if i, ok := indexes[onu.SerialNumber]; ok {
+			rows[i] = row // A single statement cannot update the same row twice
+			continue
+		}
+		indexes[onu.SerialNumber] = len(rows)
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	if err := r.createTable(ctx); err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	for start := 0; start < len(rows); start += r.batchSize {
+		batch := rows[start:min(start+r.batchSize, len(rows))]
+		var args []any
+		for _, row := range batch {
+			args = append(args, row...)
+		}
+		if _, err := tx.ExecContext(ctx, r.upsertStatement(len(batch)), args...); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("upsert ONUs: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// inventoryRow returns the upsert arguments of an ONU, in the order of inventoryColumns.
+func inventoryRow(onu model.ONUCustomerInfo, seenAt time.Time) ([]any, error) {
+	details, err := json.Marshal(onu)
+	if err != nil {
+		return nil, fmt.Errorf("encode ONU %s: %w", onu.SerialNumber, err)
+	}
+	return []any{onu.SerialNumber, onu.Board, onu.PON, onu.ID, onu.Name, onu.OnuType, onu.Status, string(details), seenAt.UTC()}, nil
+}
+
+// createTable creates the inventory table unless a previous call created or found it.
+func (r *sqlInventoryRepository) createTable(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.created {
+		return nil
+	}
+
+	if _, err := r.db.ExecContext(ctx, r.createTableStatement()); err != nil {
+		return fmt.Errorf("create table %s: %w", r.table, err)
+	}
+	r.created = true
+	return nil
+}
+
+// createTableStatement returns the CREATE TABLE IF NOT EXISTS statement of the inventory table.
+func (r *sqlInventoryRepository) createTableStatement() string {
+	return "CREATE TABLE IF NOT EXISTS " + r.dialect.quote(r.table) + " (" + r.dialect.createTable + ")"
+}
+
+// upsertStatement returns the upsert statement of the given number of rows.
+func (r *sqlInventoryRepository) upsertStatement(rows int) string {
+	var statement strings.Builder
+	statement.WriteString("INSERT INTO " + r.dialect.quote(r.table) + " (" + strings.Join(inventoryColumns, ", ") + ") VALUES ")
+	arg := 1
+	for row := range rows {
+		if row > 0 {
+			statement.WriteString(", ")
+		}
+		statement.WriteString("(")
+		for column := range inventoryColumns {
+			if column > 0 {
+				statement.WriteString(", ")
+			}
+			statement.WriteString(r.dialect.placeholder(arg))
+			arg++
+		}
+		statement.WriteString(")")
+	}
+	statement.WriteString(r.dialect.upsert(inventoryColumns[1:]))
+	return statement.String()
+}
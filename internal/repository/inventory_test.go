@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newMockInventory returns an inventory repository of the given driver on a sqlmock database
+// matching the statements exactly.
+func newMockInventory(t *testing.T, driver string, batchSize int) (*sqlInventoryRepository, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo, err := NewSQLInventoryRepository(db, driver, "onu_inventory", batchSize, time.Second)
+	require.NoError(t, err)
+	return repo.(*sqlInventoryRepository), mock
+}
+
+// inventoryArgs returns the expected upsert arguments of the ONUs.
+func inventoryArgs(t *testing.T, seenAt time.Time, onus ...model.ONUCustomerInfo) []driver.Value {
+	t.Helper()
+	var args []driver.Value
+	for _, onu := range onus {
+		details, err := json.Marshal(onu)
+		require.NoError(t, err)
+		args = append(args, onu.SerialNumber, int64(onu.Board), int64(onu.PON), int64(onu.ID), onu.Name, onu.OnuType, onu.Status, string(details), seenAt.UTC())
+	}
+	return args
+}
+
+func TestInventoryStatements(t *testing.T) {
+	postgres, _ := newMockInventory(t, InventoryDriverPostgres, 0)
+	assert.Equal(t, `INSERT INTO "onu_inventory" (serial_number, board, pon, onu_id, name, onu_type, status, details, last_seen) `+
+		`VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9), ($10, $11, $12, $13, $14, $15, $16, $17, $18) `+
+		`ON CONFLICT (serial_number) DO UPDATE SET board = EXCLUDED.board, pon = EXCLUDED.pon, onu_id = EXCLUDED.onu_id, `+
+		`name = EXCLUDED.name, onu_type = EXCLUDED.onu_type, status = EXCLUDED.status, details = EXCLUDED.details, last_seen = EXCLUDED.last_seen`,
+		postgres.upsertStatement(2))
+	assert.Equal(t, 500, postgres.batchSize)
+
+	mysql, _ := newMockInventory(t, InventoryDriverMySQL, 100000)
+	assert.Equal(t, "INSERT INTO `onu_inventory` (serial_number, board, pon, onu_id, name, onu_type, status, details, last_seen) "+
+		"VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) "+
+		"ON DUPLICATE KEY UPDATE board = VALUES(board), pon = VALUES(pon), onu_id = VALUES(onu_id), "+
+		"name = VALUES(name), onu_type = VALUES(onu_type), status = VALUES(status), details = VALUES(details), last_seen = VALUES(last_seen)",
+		mysql.upsertStatement(1))
+	assert.Equal(t, 7281, mysql.batchSize, "a statement has at most 65535 arguments")
+
+	quoted, _ := newMockInventory(t, InventoryDriverPostgres, 0)
+	quoted.table = `onu"inventory`
+	assert.Equal(t, `CREATE TABLE IF NOT EXISTS "onu""inventory" (`, quoted.createTableStatement()[:len(`CREATE TABLE IF NOT EXISTS "onu""inventory" (`)])
+
+	_, err := NewSQLInventoryRepository(nil, "sqlite", "onu_inventory", 0, 0)
+	assert.Error(t, err)
+}
+
+func TestInventoryUpsertOnus(t *testing.T) {
+	for _, driver := range []string{InventoryDriverPostgres, InventoryDriverMySQL} {
+		t.Run(driver, func(t *testing.T) {
+			repo, mock := newMockInventory(t, driver, 2)
+			seenAt := time.Date(2026, 10, 16, 8, 30, 0, 0, time.FixedZone("WIB", 7*3600))
+			onus := []model.ONUCustomerInfo{
+				{Board: 1, PON: 1, ID: 1, Name: "customer-1", OnuType: "F660", SerialNumber: "ZTEGC0000001", Status: "Online"},
+				{Board: 1, PON: 1, ID: 2, SerialNumber: "ZTEGC0000002", Status: "LOS"},
+				{Board: 1, PON: 2, ID: 9, SerialNumber: ""}, // Not an inventory item
+				{Board: 2, PON: 4, ID: 3, SerialNumber: "ZTEGC0000003", Status: "Online"},
+				{Board: 1, PON: 3, ID: 2, SerialNumber: "ZTEGC0000002", Status: "Online"}, // Moved during the scrape
+			}
+
+			mock.ExpectExec(repo.createTableStatement()).WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectBegin()
+			mock.ExpectExec(repo.upsertStatement(2)).
+				WithArgs(inventoryArgs(t, seenAt, onus[0], onus[4])...).
+				WillReturnResult(sqlmock.NewResult(0, 2))
+			mock.ExpectExec(repo.upsertStatement(1)).
+				WithArgs(inventoryArgs(t, seenAt, onus[3])...).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+			require.NoError(t, repo.UpsertOnus(context.Background(), onus, seenAt))
+
+			// The table is only created once
+			mock.ExpectBegin()
+			mock.ExpectExec(repo.upsertStatement(1)).
+				WithArgs(inventoryArgs(t, seenAt, onus[0])...).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+			require.NoError(t, repo.UpsertOnus(context.Background(), onus[:1], seenAt))
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestInventoryUpsertOnusRollsBack(t *testing.T) {
+	repo, mock := newMockInventory(t, InventoryDriverPostgres, 0)
+	seenAt := time.Now()
+	onu := model.ONUCustomerInfo{SerialNumber: "ZTEGC0000001"}
+
+	mock.ExpectExec(repo.createTableStatement()).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectBegin()
+	mock.ExpectExec(repo.upsertStatement(1)).WillReturnError(errors.New("deadlock detected"))
+	mock.ExpectRollback()
+
+	err := repo.UpsertOnus(context.Background(), []model.ONUCustomerInfo{onu}, seenAt)
+
+	assert.EqualError(t, err, "upsert ONUs: deadlock detected")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInventoryUpsertOnusCreateTableFails(t *testing.T) {
+	repo, mock := newMockInventory(t, InventoryDriverMySQL, 0)
+	mock.ExpectExec(repo.createTableStatement()).WillReturnError(errors.New("access denied"))
+
+	err := repo.UpsertOnus(context.Background(), []model.ONUCustomerInfo{{SerialNumber: "ZTEGC0000001"}}, time.Now())
+
+	assert.EqualError(t, err, "create table onu_inventory: access denied")
+	assert.NoError(t, mock.ExpectationsWereMet(), "nothing is written without the table")
+	assert.False(t, repo.created, "creating the table is retried by the next call")
+}
+
+func TestInventoryUpsertOnusNothingToWrite(t *testing.T) {
+	repo, mock := newMockInventory(t, InventoryDriverPostgres, 0)
+
+	require.NoError(t, repo.UpsertOnus(context.Background(), []model.ONUCustomerInfo{{ID: 1}}, time.Now()))
+	assert.NoError(t, mock.ExpectationsWereMet(), "nothing is sent without serial numbers")
+}
+
+func TestOpenInventoryDB(t *testing.T) {
+	for _, driver := range []string{InventoryDriverPostgres, InventoryDriverMySQL} {
+		db, err := OpenInventoryDB(driver, "localhost", "", "exporter", "p@ss/word", "inventory")
+		require.NoError(t, err, driver)
+		require.NoError(t, db.Close())
+	}
+
+	_, err := OpenInventoryDB("sqlite", "localhost", "", "exporter", "", "inventory")
+	assert.Error(t, err)
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// ProducerMessage is a message to produce, partitioned by its key
+type ProducerMessage struct {
+	Key   []byte
+	Value []byte
+}
+
+// ProducerRepository is an interface that represents the message stream the ONU details are published to
+type ProducerRepository interface {
+	Produce(ctx context.Context, messages []ProducerMessage) error // Produce the messages, partitioned by key
+}
+
+// kafkaWriter is the part of kafka.Writer used by kafkaRepository
+type kafkaWriter interface {
+	WriteMessages(ctx context.Context, messages ...kafka.Message) error
+}
+
+// kafkaRepository is a struct that implements ProducerRepository on top of a kafka-go writer
+type kafkaRepository struct {
+	writer  kafkaWriter
+	timeout time.Duration // Bounds a whole Produce call, retries included
+}
+
+// NewKafkaWriter returns a kafka-go writer producing to topic on the Kafka cluster of brokers,
+// "host:port". Messages are partitioned like the Java client's default partitioner does, so
+// consumers see the same key on the same partition, and are acknowledged by the partition leaders.
+// A timeout of zero or less falls back to 5 seconds, it bounds the dial, the read and the write of
+// every request.
+func NewKafkaWriter(brokers []string, topic, clientID string, timeout time.Duration) *kafka.Writer {
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	return &kafka.Writer{
+		Addr:         kafka.TCP(brokers...),
+		Topic:        topic,
+		Balancer:     kafka.Murmur2Balancer{},
+		RequiredAcks: kafka.RequireOne,
+		BatchTimeout: 10 * time.Millisecond, // Flush the last partial batch of a Produce right away
+		ReadTimeout:  timeout,
+		WriteTimeout: timeout,
+		Transport: &kafka.Transport{
+			ClientID:    clientID,
+			DialTimeout: timeout,
+		},
+	}
+}
+
+// NewKafkaRepository is a constructor function to create a new instance of kafkaRepository
+// producing with writer. A timeout of zero or less falls back to 5 seconds.
+func NewKafkaRepository(writer *kafka.Writer, timeout time.Duration) ProducerRepository {
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	return &kafkaRepository{
+		writer:  writer,
+		timeout: timeout,
+	}
+}
+
+// Produce produces the messages to the topic and waits for the leaders to acknowledge them
+func (r *kafkaRepository) Produce(ctx context.Context, messages []ProducerMessage) error {
+	if len(messages) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	kafkaMessages := make([]kafka.Message, 0, len(messages))
+	for _, message := range messages {
+		kafkaMessages = append(kafkaMessages, kafka.Message{Key: message.Key, Value: message.Value})
+	}
+	return r.writer.WriteMessages(ctx, kafkaMessages...)
+}
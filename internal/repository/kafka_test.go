@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeKafkaWriter records the messages of every WriteMessages call.
+type fakeKafkaWriter struct {
+	calls    [][]kafka.Message
+	deadline time.Time
+	err      error
+}
+
+func (w *fakeKafkaWriter) WriteMessages(ctx context.Context, messages ...kafka.Message) error {
+	w.calls = append(w.calls, messages)
+	w.deadline, _ = ctx.Deadline()
+	return w.err
+}
+
+func TestNewKafkaWriter(t *testing.T) {
+	writer := NewKafkaWriter([]string{"kafka-1:9092", "kafka-2:9092"}, "onus", "test", 0)
+	t.Cleanup(func() { _ = writer.Close() })
+
+	assert.Equal(t, "onus", writer.Topic)
+	assert.Equal(t, "kafka-1:9092,kafka-2:9092", writer.Addr.String())
+	assert.Equal(t, kafka.RequireOne, writer.RequiredAcks)
+	assert.Equal(t, 5*time.Second, writer.WriteTimeout)
+	require.IsType(t, &kafka.Transport{}, writer.Transport)
+	assert.Equal(t, "test", writer.Transport.(*kafka.Transport).ClientID)
+
+	// Partitions of the Kafka Java client for a topic of 12 partitions
+	partitions := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}
+	testCases := map[string]int{
+		"21":                         0,
+		"foobar":                     6,
+		"a-little-bit-long-string":   8,
+		"a-little-bit-longer-string": 11,
+		"lkjh234lh9fiuh90y23oiuhsafujhadof229phr9h19h89h8": 5,
+		"abc": 3,
+	}
+	for key, expected := range testCases {
+		assert.Equal(t, expected, writer.Balancer.Balance(kafka.Message{Key: []byte(key)}, partitions...), key)
+	}
+}
+
+func TestKafkaRepositoryProduce(t *testing.T) {
+	writer := &fakeKafkaWriter{}
+	repo := &kafkaRepository{writer: writer, timeout: time.Second}
+
+	messages := []ProducerMessage{
+		{Key: []byte("ZTEGC0000001"), Value: []byte(`{"onu_id":1}`)},
+		{Key: []byte("ZTEGC0000002"), Value: []byte(`{"onu_id":2}`)},
+	}
+	require.NoError(t, repo.Produce(context.Background(), messages))
+	require.NoError(t, repo.Produce(context.Background(), nil))
+
+	require.Len(t, writer.calls, 1, "nothing is written without messages")
+	require.Len(t, writer.calls[0], len(messages))
+	for i, message := range writer.calls[0] {
+		assert.Equal(t, messages[i].Key, message.Key)
+		assert.Equal(t, messages[i].Value, message.Value)
+	}
+	assert.WithinDuration(t, time.Now().Add(time.Second), writer.deadline, 100*time.Millisecond, "bounded by the timeout")
+}
+
+func TestKafkaRepositoryProduceError(t *testing.T) {
+	writer := &fakeKafkaWriter{err: errors.New("broker down")}
+	repo := &kafkaRepository{writer: writer, timeout: time.Second}
+
+	err := repo.Produce(context.Background(), []ProducerMessage{{Key: []byte("ZTEGC0000001"), Value: []byte("{}")}})
+	assert.EqualError(t, err, "broker down")
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"strings"
+	"time"
+)
+
+// RetryPolicy retries the SNMP requests failing with a transient error, such as a dropped UDP
+// packet, on top of the retransmissions of gosnmp. The zero policy does not retry.
+type RetryPolicy struct {
+	Retries int           // Number of retries after the first attempt
+	Backoff time.Duration // Delay before the first retry, doubled before every next one
+}
+
+// delay returns the delay before the given retry, from 1: the exponential backoff with up to half
+// of it taken off at random, so the requests of concurrent workers do not retry in lockstep.
+func (p RetryPolicy) delay(retry int) time.Duration {
+	backoff := p.Backoff << min(retry-1, 16)
+	if backoff <= 0 {
+		return 0
+	}
+	return backoff - rand.N(backoff/2+1)
+}
+
+// timeout returns how long the attempts of the policy take at most, each bounded by attempt, with
+// the longest backoffs in between.
+func (p RetryPolicy) timeout(attempt time.Duration) time.Duration {
+	total := attempt
+	for retry := 1; retry <= p.Retries; retry++ {
+		total += max(p.Backoff<<min(retry-1, 16), 0) + attempt
+	}
+	return total
+}
+
+// permanentError marks an error that must not be retried whatever it wraps, e.g. the error of
+// a walk function.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// isTransient reports whether err is worth retrying: a timeout or a network error, the signs of a
+// lost packet or a briefly unreachable OLT. An answer of the OLT, such as an authentication
+// failure, or an expired context is permanent.
+func isTransient(err error) bool {
+	var permanent *permanentError
+	if errors.As(err, &permanent) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	// gosnmp reports an unanswered request as a plain "request timeout" error
+	return strings.Contains(err.Error(), "timeout")
+}
+
+// Retry runs attempt until it succeeds, fails with a permanent error or the retries of policy are
+// exhausted, and returns the error of the last attempt. The backoff is cut short once ctx is done.
+// Besides the requests of the repository, it retries whole operations built on them, such as the
+// discovery of a PON.
+func Retry(ctx context.Context, policy RetryPolicy, attempt func() error) error {
+	for retries := 0; ; retries++ {
+		err := attempt()
+		if err == nil || retries >= policy.Retries || !isTransient(err) {
+			return err
+		}
+
+		timer := time.NewTimer(policy.delay(retries + 1))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		}
+	}
+}
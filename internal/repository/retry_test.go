@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+)
+
+// flakyRequest fails its first failures attempts with err, then succeeds.
+type flakyRequest struct {
+	failures int
+	err      error
+	attempts int
+}
+
+func (f *flakyRequest) attempt() error {
+	f.attempts++
+	if f.attempts <= f.failures {
+		return f.err
+	}
+	return nil
+}
+
+func TestRetry(t *testing.T) {
+	timeout := fmt.Errorf("SNMP Get failed: %w", errors.New("request timeout (after 1 retries)"))
+	refused := fmt.Errorf("SNMP Get failed: %w", &net.OpError{Op: "read", Net: "udp", Err: errors.New("connection refused")})
+
+	tests := []struct {
+		name             string
+		retries          int
+		failures         int
+		err              error
+		expectedAttempts int
+		expectedErr      error
+	}{
+		{name: "Timeouts within the retries", retries: 3, failures: 2, err: timeout, expectedAttempts: 3},
+		{name: "Network errors within the retries", retries: 2, failures: 2, err: refused, expectedAttempts: 3},
+		{name: "Retries exhausted", retries: 2, failures: 5, err: timeout, expectedAttempts: 3, expectedErr: timeout},
+		{name: "Not retried by default", retries: 0, failures: 1, err: timeout, expectedAttempts: 1, expectedErr: timeout},
+		{name: "Permanent error", retries: 3, failures: 1, err: gosnmp.ErrUnknownUsername, expectedAttempts: 1, expectedErr: gosnmp.ErrUnknownUsername},
+		{name: "Walk function error", retries: 3, failures: 1, err: &permanentError{err: timeout}, expectedAttempts: 1, expectedErr: timeout},
+		{name: "Expired context", retries: 3, failures: 1, err: context.DeadlineExceeded, expectedAttempts: 1, expectedErr: context.DeadlineExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := &flakyRequest{failures: tt.failures, err: tt.err}
+
+			err := Retry(context.Background(), RetryPolicy{Retries: tt.retries, Backoff: time.Millisecond}, request.attempt)
+
+			assert.Equal(t, tt.expectedAttempts, request.attempts)
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.expectedErr, "the error of the last attempt is returned")
+			}
+		})
+	}
+}
+
+func TestRetryStopsBackoffWhenContextDone(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	request := &flakyRequest{failures: 5, err: errors.New("request timeout")}
+
+	start := time.Now()
+	err := Retry(ctx, RetryPolicy{Retries: 3, Backoff: time.Second}, request.attempt)
+
+	assert.Less(t, time.Since(start), time.Second)
+	assert.EqualError(t, err, "request timeout")
+	assert.Equal(t, 1, request.attempts)
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := RetryPolicy{Backoff: 100 * time.Millisecond}
+	for retry, backoff := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 3: 400 * time.Millisecond} {
+		for range 20 {
+			delay := policy.delay(retry)
+			assert.GreaterOrEqual(t, delay, backoff/2, "retry %d", retry)
+			assert.LessOrEqual(t, delay, backoff, "retry %d", retry)
+		}
+	}
+	assert.Zero(t, RetryPolicy{}.delay(1))
+}
+
+func TestRetryPolicyTimeout(t *testing.T) {
+	assert.Equal(t, 6*time.Second, RetryPolicy{}.timeout(6*time.Second))
+	assert.Equal(t, 18*time.Second+300*time.Millisecond, RetryPolicy{Retries: 2, Backoff: 100 * time.Millisecond}.timeout(6*time.Second),
+		"every attempt and the longest backoffs in between")
+}
+
+// flakyWalker walks the OIDs .1 to .4 under the root, failing with a timeout after failAfter PDUs
+// on its first failures walks.
+type flakyWalker struct {
+	failures  int
+	failAfter int
+	walks     int
+}
+
+func (w *flakyWalker) Walk(rootOid string, walkFn gosnmp.WalkFunc) error {
+	w.walks++
+	for i := 1; i <= 4; i++ {
+		if w.walks <= w.failures && i > w.failAfter {
+			return errors.New("request timeout (after 1 retries)")
+		}
+		if err := walkFn(gosnmp.SnmpPDU{Name: rootOid + "." + strconv.Itoa(i), Type: gosnmp.Integer, Value: i}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *flakyWalker) BulkWalk(rootOid string, walkFn gosnmp.WalkFunc) error {
+	return w.Walk(rootOid, walkFn)
+}
+
+func TestRetryWalk(t *testing.T) {
+	repo := NewPonRepository("192.168.1.1", "public", 161, "", "", "", "", SnmpV3Params{}, RetryPolicy{Retries: 2, Backoff: time.Millisecond}).(*snmpRepository)
+	walker := &flakyWalker{failures: 2, failAfter: 2}
+
+	var names []string
+	err := repo.retryWalk(context.Background(), func(pdu gosnmp.SnmpPDU) error {
+		names = append(names, pdu.Name)
+		return nil
+	}, func(walkFn func(pdu gosnmp.SnmpPDU) error) error {
+		return repo.walk(context.Background(), walker, ".1.3.6", walkFn)
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, walker.walks)
+	assert.Equal(t, []string{".1.3.6.1", ".1.3.6.2", ".1.3.6.3", ".1.3.6.4"}, names, "the PDUs of the failed walks are not handed over twice")
+}
+
+func TestRetryWalkFunctionError(t *testing.T) {
+	repo := NewPonRepository("192.168.1.1", "public", 161, "", "", "", "", SnmpV3Params{}, RetryPolicy{Retries: 2, Backoff: time.Millisecond}).(*snmpRepository)
+	walker := &flakyWalker{}
+	stop := errors.New("timeout of the caller")
+
+	err := repo.retryWalk(context.Background(), func(gosnmp.SnmpPDU) error {
+		return stop
+	}, func(walkFn func(pdu gosnmp.SnmpPDU) error) error {
+		return repo.walk(context.Background(), walker, ".1.3.6", walkFn)
+	})
+
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, walker.walks, "an error of the walk function is not retried")
+}
@@ -1,45 +1,242 @@
 package repository
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"net"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/gosnmp/gosnmp"
 )
 
-// SnmpRepositoryInterface is an interface that represents the SNMP repository contract
+// SnmpRepositoryInterface is an interface that represents the SNMP repository contract. An expired
+// or canceled context aborts the request in flight.
 type SnmpRepositoryInterface interface {
-	Get(oids []string) (result *gosnmp.SnmpPacket, err error)       // Get SNMP data for the given OIDs
-	Walk(oid string, walkFunc func(pdu gosnmp.SnmpPDU) error) error // Walk SNMP to get all OIDs under the given OID
+	Get(ctx context.Context, oids []string) (result *gosnmp.SnmpPacket, err error)       // Get SNMP data for the given OIDs
+	Walk(ctx context.Context, oid string, walkFunc func(pdu gosnmp.SnmpPDU) error) error // Walk SNMP to get all OIDs under the given OID
+}
+
+// SnmpSessionRepository is an SnmpRepositoryInterface keeping its SNMP sessions open across
+// requests, until Close releases them.
+type SnmpSessionRepository interface {
+	SnmpRepositoryInterface
+	io.Closer
+}
+
+// Timeout of an SNMP request and number of retransmissions of gosnmp before it gives up
+const (
+	snmpTimeout = 3 * time.Second
+	snmpRetries = 1
+)
+
+// maxIdleSessions bounds the connected sessions a repository keeps open between requests. The
+// sessions of concurrent requests beyond it are closed once done.
+const maxIdleSessions = 8
+
+// Walk methods supported by the repository
+const (
+	WalkMethodNext = "next" // GetNext based walk, one request per OID
+	WalkMethodBulk = "bulk" // GetBulk based walk, mishandled by some older OLT firmware
+)
+
+// SNMP versions supported by the repository
+const (
+	SnmpVersion2c = "2c" // Community based, the default
+	SnmpVersion3  = "3"  // User based, with the credentials of SnmpV3Params
+)
+
+// SNMPv3 security levels
+const (
+	SecurityLevelNoAuthNoPriv = "noAuthNoPriv"
+	SecurityLevelAuthNoPriv   = "authNoPriv"
+	SecurityLevelAuthPriv     = "authPriv"
+)
+
+// SnmpV3Params are the user based security credentials of SNMPv3 requests. An empty security
+// level follows the passphrases set, and empty protocols default to SHA and AES.
+type SnmpV3Params struct {
+	SecurityLevel  string // SecurityLevelNoAuthNoPriv, SecurityLevelAuthNoPriv or SecurityLevelAuthPriv
+	Username       string
+	AuthProtocol   string // MD5, SHA, SHA224, SHA256, SHA384 or SHA512
+	AuthPassphrase string
+	PrivProtocol   string // DES, AES, AES192, AES256, AES192C or AES256C
+	PrivPassphrase string
+}
+
+// snmpAuthProtocols are the SNMPv3 authentication protocols, by upper case name
+var snmpAuthProtocols = map[string]gosnmp.SnmpV3AuthProtocol{
+	"MD5":    gosnmp.MD5,
+	"SHA":    gosnmp.SHA,
+	"SHA224": gosnmp.SHA224,
+	"SHA256": gosnmp.SHA256,
+	"SHA384": gosnmp.SHA384,
+	"SHA512": gosnmp.SHA512,
+}
+
+// snmpPrivProtocols are the SNMPv3 privacy protocols, by upper case name
+var snmpPrivProtocols = map[string]gosnmp.SnmpV3PrivProtocol{
+	"DES":     gosnmp.DES,
+	"AES":     gosnmp.AES,
+	"AES192":  gosnmp.AES192,
+	"AES256":  gosnmp.AES256,
+	"AES192C": gosnmp.AES192C,
+	"AES256C": gosnmp.AES256C,
+}
+
+// snmpWalker is the part of gosnmp.GoSNMP used to walk an OID
+type snmpWalker interface {
+	Walk(rootOid string, walkFn gosnmp.WalkFunc) error
+	BulkWalk(rootOid string, walkFn gosnmp.WalkFunc) error
 }
 
 // snmpRepository is a struct that implements SnmpRepositoryInterface
 type snmpRepository struct {
-	target    string // SNMP target IP address
-	community string // SNMP community string
-	port      uint16 // SNMP port number
+	target      string // SNMP target IP address
+	community   string // SNMP community string
+	port        uint16 // SNMP port number
+	contextName string // SNMPv3 context name, ignored by v2c
+	walkMethod  string // WalkMethodNext or WalkMethodBulk
+	localAddr   string // Source "address:port" of the requests, empty to let the OS pick
+	version     string // SnmpVersion2c or SnmpVersion3
+	v3          SnmpV3Params
+	retryPolicy RetryPolicy // Retries of the requests failing with a transient error
+
+	mu     sync.Mutex
+	idle   []*gosnmp.GoSNMP // Connected sessions waiting for the next request
+	closed bool             // Set by Close, sessions are no longer kept
 }
 
-// NewPonRepository is a constructor function to create a new instance of snmpRepository
-func NewPonRepository(target string, community string, port uint16) SnmpRepositoryInterface {
+// NewPonRepository is a constructor function to create a new instance of snmpRepository.
+// An empty or unknown walk method falls back to WalkMethodNext, an empty local address lets
+// the OS pick the source address, and an empty or unknown version falls back to SnmpVersion2c.
+// v3 is only used with SnmpVersion3, community only with SnmpVersion2c. The sessions of the
+// repository are connected on first use and reused by the next requests, Close releases them.
+func NewPonRepository(target string, community string, port uint16, contextName string, walkMethod string, localAddr string, version string, v3 SnmpV3Params, retryPolicy RetryPolicy) SnmpSessionRepository {
 	return &snmpRepository{
-		target:    target,    // SNMP target IP address
-		community: community, // SNMP community string
-		port:      port,      // SNMP port number
+		target:      target,                        // SNMP target IP address
+		community:   community,                     // SNMP community string
+		port:        port,                          // SNMP port number
+		contextName: contextName,                   // SNMPv3 context name
+		walkMethod:  parseWalkMethod(walkMethod),   // SNMP walk method
+		localAddr:   NormalizeLocalAddr(localAddr), // Source address
+		version:     ParseSnmpVersion(version),     // SNMP version
+		v3:          v3,                            // SNMPv3 credentials
+		retryPolicy: retryPolicy,                   // Retries of transient failures
 	}
 }
 
-// buildSNMPInstance for creating a new SNMP instance
-func (r *snmpRepository) buildSNMPInstance() (*gosnmp.GoSNMP, error) {
+// parseWalkMethod parses the configured walk method, falling back to GetNext
+func parseWalkMethod(value string) string {
+	if strings.EqualFold(strings.TrimSpace(value), WalkMethodBulk) {
+		return WalkMethodBulk
+	}
+	return WalkMethodNext
+}
+
+// ParseSnmpVersion parses the configured SNMP version, "3" or "v3" for SNMPv3, falling back to
+// SNMPv2c
+func ParseSnmpVersion(value string) string {
+	value = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(value)), "v")
+	if value == SnmpVersion3 {
+		return SnmpVersion3
+	}
+	return SnmpVersion2c
+}
+
+// ConfigureVersion sets the version and, for SNMPv3, the user based security of params. version
+// falls back to SNMPv2c as in NewPonRepository.
+func ConfigureVersion(params *gosnmp.GoSNMP, version string, v3 SnmpV3Params) {
+	if ParseSnmpVersion(version) != SnmpVersion3 {
+		params.Version = gosnmp.Version2c
+		return
+	}
+
+	params.Version = gosnmp.Version3
+	params.SecurityModel = gosnmp.UserSecurityModel
+	params.MsgFlags = parseSecurityLevel(v3)
+
+	// Every session gets its own parameters, gosnmp keeps the engine state and salts in them
+	securityParameters := &gosnmp.UsmSecurityParameters{
+		UserName:               v3.Username,
+		AuthenticationProtocol: gosnmp.NoAuth,
+		PrivacyProtocol:        gosnmp.NoPriv,
+	}
+	if params.MsgFlags&gosnmp.AuthNoPriv != 0 {
+		securityParameters.AuthenticationProtocol = parseProtocol(snmpAuthProtocols, v3.AuthProtocol, gosnmp.SHA)
+		securityParameters.AuthenticationPassphrase = v3.AuthPassphrase
+	}
+	if params.MsgFlags == gosnmp.AuthPriv {
+		securityParameters.PrivacyProtocol = parseProtocol(snmpPrivProtocols, v3.PrivProtocol, gosnmp.AES)
+		securityParameters.PrivacyPassphrase = v3.PrivPassphrase
+	}
+	params.SecurityParameters = securityParameters
+}
+
+// parseSecurityLevel parses the configured security level, case insensitively. An empty or
+// unknown level follows the passphrases set: both for authPriv, the authentication one only for
+// authNoPriv.
+func parseSecurityLevel(v3 SnmpV3Params) gosnmp.SnmpV3MsgFlags {
+	switch {
+	case strings.EqualFold(v3.SecurityLevel, SecurityLevelNoAuthNoPriv):
+		return gosnmp.NoAuthNoPriv
+	case strings.EqualFold(v3.SecurityLevel, SecurityLevelAuthNoPriv):
+		return gosnmp.AuthNoPriv
+	case strings.EqualFold(v3.SecurityLevel, SecurityLevelAuthPriv):
+		return gosnmp.AuthPriv
+	case v3.AuthPassphrase != "" && v3.PrivPassphrase != "":
+		return gosnmp.AuthPriv
+	case v3.AuthPassphrase != "":
+		return gosnmp.AuthNoPriv
+	default:
+		return gosnmp.NoAuthNoPriv
+	}
+}
+
+// parseProtocol looks up the protocol named value, ignoring case and dashes ("SHA-256"), falling
+// back to fallback when it is empty or unknown
+func parseProtocol[P any](protocols map[string]P, value string, fallback P) P {
+	if protocol, ok := protocols[strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(value), "-", ""))]; ok {
+		return protocol
+	}
+	return fallback
+}
+
+// NormalizeLocalAddr turns a configured source address into the "address:port" form expected by
+// gosnmp, using an ephemeral port when none is given. An empty address stays empty.
+func NormalizeLocalAddr(value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return ""
+	}
+	if _, _, err := net.SplitHostPort(value); err == nil {
+		return value
+	}
+	return net.JoinHostPort(strings.Trim(value, "[]"), "0")
+}
+
+// newSNMPParams for creating the SNMP session parameters, without connecting
+func (r *snmpRepository) newSNMPParams() *gosnmp.GoSNMP {
 	params := &gosnmp.GoSNMP{
-		Target:    r.target,                       // SNMP target IP address
-		Port:      r.port,                         // SNMP port number
-		Community: r.community,                    // SNMP community string
-		Version:   gosnmp.Version2c,               // SNMP version
-		Timeout:   time.Duration(3) * time.Second, // SNMP timeout
-		Retries:   1,                              // Number of retries for SNMP requests
+		Target:      r.target,      // SNMP target IP address
+		Port:        r.port,        // SNMP port number
+		Community:   r.community,   // SNMP community string
+		ContextName: r.contextName, // SNMPv3 context name
+		LocalAddr:   r.localAddr,   // Source address of the requests
+		Timeout:     snmpTimeout,   // SNMP timeout
+		Retries:     snmpRetries,   // Number of retries for SNMP requests
 	}
+	ConfigureVersion(params, r.version, r.v3) // SNMP version and SNMPv3 credentials
+	return params
+}
+
+// buildSNMPInstance for creating a new SNMP instance whose requests are bounded by ctx
+func (r *snmpRepository) buildSNMPInstance(ctx context.Context) (*gosnmp.GoSNMP, error) {
+	params := r.newSNMPParams()
+	params.Context = ctx // gosnmp gives up on a request once the context is done
 
 	// Set logger to nil to disable logging
 	if err := params.Connect(); err != nil {
@@ -48,40 +245,133 @@ func (r *snmpRepository) buildSNMPInstance() (*gosnmp.GoSNMP, error) {
 	return params, nil // Return the SNMP instance
 }
 
-// Get to get SNMP data for the given OIDs
-func (r *snmpRepository) Get(oids []string) (*gosnmp.SnmpPacket, error) {
-	snmp, err := r.buildSNMPInstance() // Create a new SNMP instance
-	if err != nil {
-		return nil, err
+// acquire returns a connected session whose requests are bounded by ctx, reusing an idle one when
+// there is any. gosnmp matches the responses by request ID, so a late response to an earlier request
+// of the session is skipped.
+func (r *snmpRepository) acquire(ctx context.Context) (*gosnmp.GoSNMP, error) {
+	r.mu.Lock()
+	if n := len(r.idle); n > 0 {
+		snmp := r.idle[n-1]
+		r.idle = r.idle[:n-1]
+		r.mu.Unlock()
+		snmp.Context = ctx // gosnmp checks the context of the session on every request
+		return snmp, nil
+	}
+	r.mu.Unlock()
+	return r.buildSNMPInstance(ctx)
+}
+
+// release hands a session back once its request is done. A session whose request failed is
+// closed rather than reused, as are the ones beyond maxIdleSessions and all of them once the
+// repository is closed.
+func (r *snmpRepository) release(snmp *gosnmp.GoSNMP, requestErr error) {
+	snmp.Context = context.Background() // Do not keep the context of the request alive
+
+	r.mu.Lock()
+	if requestErr == nil && !r.closed && len(r.idle) < maxIdleSessions {
+		r.idle = append(r.idle, snmp)
+		r.mu.Unlock()
+		return
+	}
+	r.mu.Unlock()
+
+	if err := snmp.Conn.Close(); err != nil {
+		fmt.Printf("Error closing SNMP connection: %v\n", err)
+	}
+}
+
+// Close closes the idle sessions of the repository, the sessions of the requests in flight are
+// closed once they are done
+func (r *snmpRepository) Close() error {
+	r.mu.Lock()
+	idle := r.idle
+	r.idle, r.closed = nil, true
+	r.mu.Unlock()
+
+	var errs []error
+	for _, snmp := range idle {
+		if err := snmp.Conn.Close(); err != nil {
+			errs = append(errs, err)
+		}
 	}
-	defer func(Conn net.Conn) {
-		err := Conn.Close()
+	return errors.Join(errs...)
+}
+
+// Get to get SNMP data for the given OIDs, retrying transient failures with the retry policy. A
+// Get gives up once every attempt timed out, also with a context without deadline.
+func (r *snmpRepository) Get(ctx context.Context, oids []string) (*gosnmp.SnmpPacket, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.retryPolicy.timeout(snmpTimeout*(snmpRetries+1)))
+	defer cancel()
+
+	var result *gosnmp.SnmpPacket
+	err := Retry(ctx, r.retryPolicy, func() error {
+		snmp, err := r.acquire(ctx)
 		if err != nil {
-			fmt.Printf("Error closing SNMP connection: %v\n", err)
+			return err
 		}
-	}(snmp.Conn)
 
-	result, err := snmp.Get(oids)
+		result, err = snmp.Get(oids)
+		r.release(snmp, err)
+		if err != nil {
+			return fmt.Errorf("SNMP Get failed: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("SNMP Get failed: %w", err)
+		return nil, err
 	}
 	return result, nil
 }
 
-// Walk for SNMP Walk to get all OIDs under the given OID
-func (r *snmpRepository) Walk(oid string, walkFunc func(pdu gosnmp.SnmpPDU) error) error {
-	snmp, err := r.buildSNMPInstance()
-	if err != nil {
-		return err
-	}
-	defer func(Conn net.Conn) {
-		err := Conn.Close()
+// Walk for SNMP Walk to get all OIDs under the given OID, retrying transient failures with the
+// retry policy
+func (r *snmpRepository) Walk(ctx context.Context, oid string, walkFunc func(pdu gosnmp.SnmpPDU) error) error {
+	return r.retryWalk(ctx, walkFunc, func(walkFn func(pdu gosnmp.SnmpPDU) error) error {
+		snmp, err := r.acquire(ctx)
 		if err != nil {
-			fmt.Printf("Error closing SNMP connection: %v\n", err)
+			return err
 		}
-	}(snmp.Conn)
 
-	err = snmp.Walk(oid, walkFunc)
+		err = r.walk(ctx, snmp, oid, walkFn)
+		r.release(snmp, err)
+		return err
+	})
+}
+
+// retryWalk runs walkOnce with the retry policy. A retried walk starts over, so the PDUs already
+// handed to walkFunc by a failed attempt are skipped. An error of walkFunc is never retried.
+func (r *snmpRepository) retryWalk(ctx context.Context, walkFunc func(pdu gosnmp.SnmpPDU) error, walkOnce func(walkFn func(pdu gosnmp.SnmpPDU) error) error) error {
+	delivered := make(map[string]bool)
+	return Retry(ctx, r.retryPolicy, func() error {
+		return walkOnce(func(pdu gosnmp.SnmpPDU) error {
+			if delivered[pdu.Name] {
+				return nil
+			}
+			delivered[pdu.Name] = true
+			if err := walkFunc(pdu); err != nil {
+				return &permanentError{err: err}
+			}
+			return nil
+		})
+	})
+}
+
+// walk walks the given OID with the configured walk method, stopping at the next PDU once ctx is
+// done
+func (r *snmpRepository) walk(ctx context.Context, snmp snmpWalker, oid string, walkFunc func(pdu gosnmp.SnmpPDU) error) error {
+	walkFn := func(pdu gosnmp.SnmpPDU) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return walkFunc(pdu)
+	}
+
+	var err error
+	if r.walkMethod == WalkMethodBulk {
+		err = snmp.BulkWalk(oid, walkFn)
+	} else {
+		err = snmp.Walk(oid, walkFn)
+	}
 	if err != nil {
 		return fmt.Errorf("SNMP Walk failed: %w", err)
 	}
@@ -0,0 +1,280 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSNMPParamsContextName(t *testing.T) {
+	tests := []struct {
+		name        string
+		contextName string
+	}{
+		{name: "Without context name", contextName: ""},
+		{name: "With context name", contextName: "board-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewPonRepository("192.168.1.1", "public", 161, tt.contextName, "", "", "", SnmpV3Params{}, RetryPolicy{}).(*snmpRepository)
+			params := repo.newSNMPParams()
+
+			assert.Equal(t, tt.contextName, params.ContextName)
+			assert.Equal(t, "192.168.1.1", params.Target)
+			assert.Equal(t, "public", params.Community)
+			assert.Equal(t, uint16(161), params.Port)
+			assert.Equal(t, gosnmp.Version2c, params.Version)
+		})
+	}
+}
+
+func TestNewSNMPParamsLocalAddr(t *testing.T) {
+	tests := []struct {
+		name      string
+		localAddr string
+		expected  string
+	}{
+		{name: "Not configured", localAddr: "", expected: ""},
+		{name: "Address only", localAddr: "10.10.0.5", expected: "10.10.0.5:0"},
+		{name: "Address and port", localAddr: "10.10.0.5:16100", expected: "10.10.0.5:16100"},
+		{name: "IPv6 address only", localAddr: "fd00::5", expected: "[fd00::5]:0"},
+		{name: "Bracketed IPv6 address", localAddr: "[fd00::5]", expected: "[fd00::5]:0"},
+		{name: "Surrounding spaces", localAddr: " 10.10.0.5 ", expected: "10.10.0.5:0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewPonRepository("192.168.1.1", "public", 161, "", "", tt.localAddr, "", SnmpV3Params{}, RetryPolicy{}).(*snmpRepository)
+			params := repo.newSNMPParams()
+
+			assert.Equal(t, tt.expected, params.LocalAddr)
+		})
+	}
+}
+
+func TestNewSNMPParamsVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  string
+		v3       SnmpV3Params
+		expected gosnmp.SnmpVersion
+	}{
+		{name: "Not configured", version: "", expected: gosnmp.Version2c},
+		{name: "SNMPv2c", version: "2c", expected: gosnmp.Version2c},
+		{name: "Unknown version", version: "1", expected: gosnmp.Version2c},
+		{name: "SNMPv3", version: "3", expected: gosnmp.Version3},
+		{name: "SNMPv3 with prefix", version: " V3 ", expected: gosnmp.Version3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewPonRepository("192.168.1.1", "public", 161, "", "", "", tt.version, tt.v3, RetryPolicy{}).(*snmpRepository)
+			params := repo.newSNMPParams()
+
+			assert.Equal(t, tt.expected, params.Version)
+			if tt.expected == gosnmp.Version2c {
+				assert.Nil(t, params.SecurityParameters)
+				assert.Equal(t, "public", params.Community)
+			}
+		})
+	}
+}
+
+func TestNewSNMPParamsSecurityLevel(t *testing.T) {
+	tests := []struct {
+		name     string
+		v3       SnmpV3Params
+		flags    gosnmp.SnmpV3MsgFlags
+		expected *gosnmp.UsmSecurityParameters
+	}{
+		{
+			name:     "noAuthNoPriv",
+			v3:       SnmpV3Params{SecurityLevel: "noAuthNoPriv", Username: "monitor", AuthPassphrase: "ignored", PrivPassphrase: "ignored"},
+			flags:    gosnmp.NoAuthNoPriv,
+			expected: &gosnmp.UsmSecurityParameters{UserName: "monitor", AuthenticationProtocol: gosnmp.NoAuth, PrivacyProtocol: gosnmp.NoPriv},
+		},
+		{
+			name:  "authNoPriv",
+			v3:    SnmpV3Params{SecurityLevel: "authNoPriv", Username: "monitor", AuthProtocol: "SHA-256", AuthPassphrase: "authpass", PrivPassphrase: "ignored"},
+			flags: gosnmp.AuthNoPriv,
+			expected: &gosnmp.UsmSecurityParameters{
+				UserName: "monitor", AuthenticationProtocol: gosnmp.SHA256, AuthenticationPassphrase: "authpass", PrivacyProtocol: gosnmp.NoPriv,
+			},
+		},
+		{
+			name:  "authPriv",
+			v3:    SnmpV3Params{SecurityLevel: "AUTHPRIV", Username: "monitor", AuthProtocol: "md5", AuthPassphrase: "authpass", PrivProtocol: "aes256", PrivPassphrase: "privpass"},
+			flags: gosnmp.AuthPriv,
+			expected: &gosnmp.UsmSecurityParameters{
+				UserName: "monitor", AuthenticationProtocol: gosnmp.MD5, AuthenticationPassphrase: "authpass", PrivacyProtocol: gosnmp.AES256, PrivacyPassphrase: "privpass",
+			},
+		},
+		{
+			name:  "authPriv from the passphrases, default protocols",
+			v3:    SnmpV3Params{Username: "monitor", AuthPassphrase: "authpass", PrivPassphrase: "privpass"},
+			flags: gosnmp.AuthPriv,
+			expected: &gosnmp.UsmSecurityParameters{
+				UserName: "monitor", AuthenticationProtocol: gosnmp.SHA, AuthenticationPassphrase: "authpass", PrivacyProtocol: gosnmp.AES, PrivacyPassphrase: "privpass",
+			},
+		},
+		{
+			name:  "authNoPriv from the passphrases",
+			v3:    SnmpV3Params{Username: "monitor", AuthProtocol: "unknown", AuthPassphrase: "authpass"},
+			flags: gosnmp.AuthNoPriv,
+			expected: &gosnmp.UsmSecurityParameters{
+				UserName: "monitor", AuthenticationProtocol: gosnmp.SHA, AuthenticationPassphrase: "authpass", PrivacyProtocol: gosnmp.NoPriv,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewPonRepository("192.168.1.1", "", 161, "board-1", "", "", "3", tt.v3, RetryPolicy{}).(*snmpRepository)
+			params := repo.newSNMPParams()
+
+			assert.Equal(t, gosnmp.Version3, params.Version)
+			assert.Equal(t, gosnmp.UserSecurityModel, params.SecurityModel)
+			assert.Equal(t, tt.flags, params.MsgFlags)
+			assert.Equal(t, "board-1", params.ContextName)
+			securityParameters, ok := params.SecurityParameters.(*gosnmp.UsmSecurityParameters)
+			if assert.True(t, ok) {
+				assert.Equal(t, tt.expected.UserName, securityParameters.UserName)
+				assert.Equal(t, tt.expected.AuthenticationProtocol, securityParameters.AuthenticationProtocol)
+				assert.Equal(t, tt.expected.AuthenticationPassphrase, securityParameters.AuthenticationPassphrase)
+				assert.Equal(t, tt.expected.PrivacyProtocol, securityParameters.PrivacyProtocol)
+				assert.Equal(t, tt.expected.PrivacyPassphrase, securityParameters.PrivacyPassphrase)
+			}
+			assert.NotSame(t, params.SecurityParameters, repo.newSNMPParams().SecurityParameters, "every session gets its own parameters")
+		})
+	}
+}
+
+// recordingWalker records which walk method was used
+type recordingWalker struct {
+	called []string
+}
+
+func (w *recordingWalker) Walk(rootOid string, walkFn gosnmp.WalkFunc) error {
+	w.called = append(w.called, WalkMethodNext)
+	return walkFn(gosnmp.SnmpPDU{Name: rootOid + ".1"})
+}
+
+func (w *recordingWalker) BulkWalk(rootOid string, walkFn gosnmp.WalkFunc) error {
+	w.called = append(w.called, WalkMethodBulk)
+	return walkFn(gosnmp.SnmpPDU{Name: rootOid + ".1"})
+}
+
+func TestWalkMethod(t *testing.T) {
+	tests := []struct {
+		name       string
+		walkMethod string
+		expected   string
+	}{
+		{name: "Default", walkMethod: "", expected: WalkMethodNext},
+		{name: "GetNext", walkMethod: "next", expected: WalkMethodNext},
+		{name: "GetBulk", walkMethod: "bulk", expected: WalkMethodBulk},
+		{name: "GetBulk uppercase", walkMethod: " BULK ", expected: WalkMethodBulk},
+		{name: "Unknown", walkMethod: "parallel", expected: WalkMethodNext},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewPonRepository("192.168.1.1", "public", 161, "", tt.walkMethod, "", "", SnmpV3Params{}, RetryPolicy{}).(*snmpRepository)
+			walker := &recordingWalker{}
+
+			var walked []string
+			err := repo.walk(context.Background(), walker, ".1.3.6", func(pdu gosnmp.SnmpPDU) error {
+				walked = append(walked, pdu.Name)
+				return nil
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, []string{tt.expected}, walker.called)
+			assert.Equal(t, []string{".1.3.6.1"}, walked)
+		})
+	}
+}
+
+// tableWalker walks the given number of rows under the root OID
+type tableWalker struct {
+	rows int
+}
+
+func (w *tableWalker) Walk(rootOid string, walkFn gosnmp.WalkFunc) error {
+	for i := 1; i <= w.rows; i++ {
+		if err := walkFn(gosnmp.SnmpPDU{Name: rootOid + "." + strconv.Itoa(i)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *tableWalker) BulkWalk(rootOid string, walkFn gosnmp.WalkFunc) error {
+	return w.Walk(rootOid, walkFn)
+}
+
+func TestWalkStopsWhenContextCanceled(t *testing.T) {
+	repo := NewPonRepository("192.168.1.1", "public", 161, "", "", "", "", SnmpV3Params{}, RetryPolicy{}).(*snmpRepository)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var walked []string
+	err := repo.walk(ctx, &tableWalker{rows: 10}, ".1.3.6", func(pdu gosnmp.SnmpPDU) error {
+		walked = append(walked, pdu.Name)
+		if len(walked) == 3 {
+			cancel() // E.g. the scrape timed out
+		}
+		return nil
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, []string{".1.3.6.1", ".1.3.6.2", ".1.3.6.3"}, walked, "no row is handled after the cancellation")
+}
+
+func TestSessionsAreReused(t *testing.T) {
+	repo := NewPonRepository("127.0.0.1", "public", 16161, "", "", "", "", SnmpV3Params{}, RetryPolicy{}).(*snmpRepository)
+	ctx := context.Background()
+
+	first, err := repo.acquire(ctx)
+	require.NoError(t, err)
+	repo.release(first, nil)
+	second, err := repo.acquire(ctx)
+	require.NoError(t, err)
+	assert.Same(t, first, second, "a session is reused once its request succeeded")
+
+	repo.release(second, errors.New("request timeout"))
+	third, err := repo.acquire(ctx)
+	require.NoError(t, err)
+	assert.NotSame(t, second, third, "a session whose request failed is not reused")
+
+	repo.release(third, nil)
+	assert.NoError(t, repo.Close())
+	assert.Empty(t, repo.idle)
+
+	fourth, err := repo.acquire(ctx)
+	require.NoError(t, err)
+	repo.release(fourth, nil)
+	assert.Empty(t, repo.idle, "no session is kept once the repository is closed")
+}
+
+func TestIdleSessionsAreBounded(t *testing.T) {
+	repo := NewPonRepository("127.0.0.1", "public", 16161, "", "", "", "", SnmpV3Params{}, RetryPolicy{}).(*snmpRepository)
+	defer func() { _ = repo.Close() }()
+
+	var sessions []*gosnmp.GoSNMP
+	for range maxIdleSessions + 2 {
+		snmp, err := repo.acquire(context.Background())
+		require.NoError(t, err)
+		sessions = append(sessions, snmp)
+	}
+	for _, snmp := range sessions {
+		repo.release(snmp, nil)
+	}
+	assert.Len(t, repo.idle, maxIdleSessions)
+}
@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/config"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
+)
+
+// Alarm severities, ordered from least to most severe.
+const (
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+)
+
+// OnuSnapshotSource provides the detailed ONU information of the last Prometheus scrape.
+type OnuSnapshotSource interface {
+	LastScrape() (onus []model.ONUCustomerInfo, scrapedAt time.Time)
+}
+
+// AlarmUseCaseInterface is an interface that represent the alarm's usecase contract
+type AlarmUseCaseInterface interface {
+	GetAlarms() (model.OnuAlarmList, bool)
+}
+
+// alarmUsecase represent the alarm's usecase
+type alarmUsecase struct {
+	source OnuSnapshotSource
+	cfg    *config.Config
+}
+
+// NewAlarmUsecase will create an object that represent the alarm usecase
+func NewAlarmUsecase(source OnuSnapshotSource, cfg *config.Config) AlarmUseCaseInterface {
+	return &alarmUsecase{
+		source: source,
+		cfg:    cfg,
+	}
+}
+
+// GetAlarms returns every ONU of the last scrape that is not Online or whose optical levels
+// are out of range, most severe first. ok is false if no scrape has completed yet.
+func (u *alarmUsecase) GetAlarms() (model.OnuAlarmList, bool) {
+	onus, scrapedAt := u.source.LastScrape()
+	if scrapedAt.IsZero() {
+		return model.OnuAlarmList{}, false
+	}
+
+	alarms := make([]model.OnuAlarm, 0)
+	for _, onu := range onus {
+		severity, reasons := u.evaluate(onu)
+		if len(reasons) == 0 {
+			continue
+		}
+		alarms = append(alarms, model.OnuAlarm{
+			Board:               onu.Board,
+			PON:                 onu.PON,
+			ID:                  onu.ID,
+			Name:                onu.Name,
+			SerialNumber:        onu.SerialNumber,
+			Status:              onu.Status,
+			RXPower:             onu.RXPower,
+			TXPower:             onu.TXPower,
+			GponOpticalDistance: onu.GponOpticalDistance,
+			Severity:            severity,
+			Reasons:             reasons,
+		})
+	}
+
+	// Sort critical alarms first, then by location
+	sort.Slice(alarms, func(i, j int) bool {
+		if alarms[i].Severity != alarms[j].Severity {
+			return alarms[i].Severity == SeverityCritical
+		}
+		if alarms[i].Board != alarms[j].Board {
+			return alarms[i].Board < alarms[j].Board
+		}
+		if alarms[i].PON != alarms[j].PON {
+			return alarms[i].PON < alarms[j].PON
+		}
+		return alarms[i].ID < alarms[j].ID
+	})
+
+	return model.OnuAlarmList{
+		ScrapedAt: scrapedAt.Format(time.RFC3339),
+		Alarms:    alarms,
+	}, true
+}
+
+// evaluate returns the highest severity and the reasons of every alarm condition of the ONU.
+func (u *alarmUsecase) evaluate(onu model.ONUCustomerInfo) (string, []string) {
+	thresholds := u.cfg.AlarmCfg
+	var reasons []string
+	severity := ""
+
+	raise := func(level, reason string) {
+		reasons = append(reasons, reason)
+		if severity != SeverityCritical {
+			severity = level
+		}
+	}
+
+	// Optical levels are only meaningful while the ONU is Online
+	if onu.Status != "Online" {
+		level := SeverityCritical
+		if onu.Status == "Power-Off" {
+			level = SeverityWarning // Switched off by the customer, not a fault in the network
+		}
+		raise(level, fmt.Sprintf("status is %s", onu.Status))
+		return severity, reasons
+	}
+
+	if rxPower, ok := parseOpticalValue(onu.RXPower); ok {
+		switch {
+		case rxPower < thresholds.RxPowerCritical:
+			raise(SeverityCritical, fmt.Sprintf("rx power %.2f dBm below %.2f dBm", rxPower, thresholds.RxPowerCritical))
+		case rxPower < thresholds.RxPowerWarning:
+			raise(SeverityWarning, fmt.Sprintf("rx power %.2f dBm below %.2f dBm", rxPower, thresholds.RxPowerWarning))
+		case rxPower > thresholds.RxPowerMax:
+			raise(SeverityWarning, fmt.Sprintf("rx power %.2f dBm above %.2f dBm", rxPower, thresholds.RxPowerMax))
+		}
+	}
+
+	if txPower, ok := parseOpticalValue(onu.TXPower); ok {
+		switch {
+		case txPower < thresholds.TxPowerMin:
+			raise(SeverityWarning, fmt.Sprintf("tx power %.2f dBm below %.2f dBm", txPower, thresholds.TxPowerMin))
+		case txPower > thresholds.TxPowerMax:
+			raise(SeverityWarning, fmt.Sprintf("tx power %.2f dBm above %.2f dBm", txPower, thresholds.TxPowerMax))
+		}
+	}
+
+	if distance, err := strconv.ParseFloat(onu.GponOpticalDistance, 64); err == nil && thresholds.OpticalDistanceMax > 0 && distance > thresholds.OpticalDistanceMax {
+		raise(SeverityWarning, fmt.Sprintf("optical distance %.0f m above %.0f m", distance, thresholds.OpticalDistanceMax))
+	}
+
+	return severity, reasons
+}
+
+// parseOpticalValue parses a power reading, rejecting the placeholder values the OLT reports
+// for missing readings, the same way the exporter filters them.
+func parseOpticalValue(value string) (float64, bool) {
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil || parsed >= 100 {
+		return 0, false
+	}
+	return parsed, true
+}
@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/config"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/repository"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/utils"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/singleflight"
+)
+
+// ChassisUseCaseInterface is an interface that represent the OLT chassis's usecase contract
+type ChassisUseCaseInterface interface {
+	GetPowerSupplies(ctx context.Context) ([]model.PowerSupply, error)
+	GetSystemHealth(ctx context.Context) (model.ChassisHealth, error)
+}
+
+// chassisUsecase represent the OLT chassis's usecase
+type chassisUsecase struct {
+	snmpRepository repository.SnmpRepositoryInterface
+	cfg            *config.Config
+	sg             singleflight.Group
+}
+
+// NewChassisUsecase will create an object that represent the OLT chassis usecase
+func NewChassisUsecase(
+	snmpRepository repository.SnmpRepositoryInterface,
+	cfg *config.Config,
+) ChassisUseCaseInterface {
+	return &chassisUsecase{
+		snmpRepository: snmpRepository,
+		cfg:            cfg,
+		sg:             singleflight.Group{},
+	}
+}
+
+// GetPowerSupplies returns the status of every power supply unit, sorted by index. It returns
+// nothing when the PSU status OID is not configured.
+func (u *chassisUsecase) GetPowerSupplies(ctx context.Context) ([]model.PowerSupply, error) {
+	psuStatusOID := u.cfg.ChassisCfg.PsuStatusOID
+	if psuStatusOID == "" {
+		return nil, nil
+	}
+
+	result, err, _ := u.sg.Do("power_supplies", func() (interface{}, error) {
+		var powerSupplies []model.PowerSupply
+		err := u.snmpRepository.Walk(ctx, psuStatusOID, func(pdu gosnmp.SnmpPDU) error {
+			powerSupplies = append(powerSupplies, model.PowerSupply{
+				Index:  utils.ExtractIDOnuID(pdu.Name),
+				Status: utils.ExtractPsuStatus(pdu.Value),
+			})
+			return nil
+		})
+		if err != nil {
+			log.Error().Msg("Failed to walk PSU status: " + err.Error())
+			return nil, err
+		}
+
+		// Sort power supplies based on index ascending
+		sort.Slice(powerSupplies, func(i, j int) bool {
+			return powerSupplies[i].Index < powerSupplies[j].Index
+		})
+
+		return powerSupplies, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.([]model.PowerSupply), nil
+}
+
+// GetSystemHealth returns the uptime, CPU and memory usage and temperature of the OLT, reading
+// every configured OID in a single Get. Readings that are not configured, unknown to the OLT or
+// out of range are left empty.
+func (u *chassisUsecase) GetSystemHealth(ctx context.Context) (model.ChassisHealth, error) {
+	result, err, _ := u.sg.Do("system_health", func() (interface{}, error) {
+		var health model.ChassisHealth
+		readings := []chassisReading{
+			{u.cfg.ChassisCfg.SysUptimeOID, utils.ExtractSysUptime, &health.Uptime},
+			{u.cfg.ChassisCfg.CPUUsageOID, utils.ExtractUsagePercent, &health.CPUUsage},
+			{u.cfg.ChassisCfg.MemoryUsageOID, utils.ExtractUsagePercent, &health.MemoryUsage},
+			{u.cfg.ChassisCfg.TemperatureOID, utils.ExtractChassisTemperature, &health.Temperature},
+		}
+
+		var oids []string
+		byOID := make(map[string]chassisReading)
+		for _, reading := range readings {
+			if reading.oid == "" {
+				continue
+			}
+			oids = append(oids, reading.oid)
+			byOID[utils.NormalizeOID(reading.oid)] = reading
+		}
+		if len(oids) == 0 {
+			return health, nil
+		}
+
+		packet, err := u.snmpRepository.Get(ctx, oids)
+		if err != nil {
+			log.Error().Msg("Failed to get system health: " + err.Error())
+			return nil, err
+		}
+
+		for _, pdu := range packet.Variables {
+			reading, ok := byOID[utils.NormalizeOID(pdu.Name)]
+			if !ok || pdu.Type == gosnmp.NoSuchObject || pdu.Type == gosnmp.NoSuchInstance {
+				continue
+			}
+			value, err := reading.extract(pdu.Value)
+			if err != nil {
+				log.Debug().Err(err).Str("oid", pdu.Name).Msg("Failed to extract system health reading")
+				continue
+			}
+			*reading.value = value
+		}
+		return health, nil
+	})
+	if err != nil {
+		return model.ChassisHealth{}, err
+	}
+
+	return result.(model.ChassisHealth), nil
+}
+
+// chassisReading is a system reading of the OLT, extracted from its OID into value.
+type chassisReading struct {
+	oid     string
+	extract func(oidValue interface{}) (string, error)
+	value   *string
+}
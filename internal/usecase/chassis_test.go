@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/config"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPowerSupplies(t *testing.T) {
+	psuStatusOID := ".1.3.6.1.4.1.3902.1082.10.1.2.4.11.1.2"
+	cfg := &config.Config{ChassisCfg: config.ChassisConfig{PsuStatusOID: psuStatusOID}}
+	repo := &walkOnlyRepository{walks: map[string][]gosnmp.SnmpPDU{
+		psuStatusOID: {
+			{Name: psuStatusOID + ".2", Value: 2},
+			{Name: psuStatusOID + ".1", Value: 1},
+			{Name: psuStatusOID + ".3", Value: 3},
+		},
+	}}
+
+	powerSupplies, err := NewChassisUsecase(repo, cfg).GetPowerSupplies(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []model.PowerSupply{
+		{Index: 1, Status: "ok"},
+		{Index: 2, Status: "fault"},
+		{Index: 3, Status: "absent"},
+	}, powerSupplies)
+}
+
+func TestGetPowerSuppliesNotConfiguredOrFailing(t *testing.T) {
+	powerSupplies, err := NewChassisUsecase(&walkOnlyRepository{err: errors.New("request timeout")}, &config.Config{}).GetPowerSupplies(context.Background())
+	require.NoError(t, err, "nothing is walked without a PSU status OID")
+	assert.Empty(t, powerSupplies)
+
+	cfg := &config.Config{ChassisCfg: config.ChassisConfig{PsuStatusOID: ".1.3.6.1.4.1.3902.1082.10.1.2.4.11.1.2"}}
+	_, err = NewChassisUsecase(&walkOnlyRepository{err: errors.New("request timeout")}, cfg).GetPowerSupplies(context.Background())
+	require.Error(t, err)
+}
+
+func TestGetSystemHealth(t *testing.T) {
+	cfg := &config.Config{ChassisCfg: config.ChassisConfig{
+		SysUptimeOID:   ".1.3.6.1.2.1.1.3.0",
+		CPUUsageOID:    ".1.3.6.1.4.1.3902.1082.10.1.1.1.0",
+		MemoryUsageOID: ".1.3.6.1.4.1.3902.1082.10.1.1.2.0",
+		TemperatureOID: ".1.3.6.1.4.1.3902.1082.10.1.1.3.0",
+	}}
+	repo := &tableRepository{}
+	repo.add(".1.3.6.1.2.1.1.3.0", gosnmp.TimeTicks, uint32(864001234))
+	repo.add(".1.3.6.1.4.1.3902.1082.10.1.1.1.0", gosnmp.Integer, 27)
+	repo.add(".1.3.6.1.4.1.3902.1082.10.1.1.2.0", gosnmp.OctetString, []byte("64.5%"))
+
+	health, err := NewChassisUsecase(repo, cfg).GetSystemHealth(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, model.ChassisHealth{Uptime: "8640012.34", CPUUsage: "27.00", MemoryUsage: "64.50"}, health, "the temperature is unknown to the OLT")
+	assert.Equal(t, 1, repo.gets, "every reading is read in a single Get")
+}
+
+func TestGetSystemHealthNotConfiguredOrFailing(t *testing.T) {
+	health, err := NewChassisUsecase(&walkOnlyRepository{}, &config.Config{}).GetSystemHealth(context.Background())
+	require.NoError(t, err, "nothing is read without OIDs")
+	assert.Equal(t, model.ChassisHealth{}, health)
+
+	cfg := &config.Config{ChassisCfg: config.ChassisConfig{SysUptimeOID: ".1.3.6.1.2.1.1.3.0"}}
+	_, err = NewChassisUsecase(&walkOnlyRepository{}, cfg).GetSystemHealth(context.Background())
+	require.Error(t, err)
+}
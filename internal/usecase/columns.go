@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/repository"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/utils"
+)
+
+// columnRepository answers the Gets and walks of the ONUs of a PON from whole columns, walking
+// every column once on its first use, so the attribute by attribute path reads every ONU of the PON
+// in a single pass. Requests outside of the columns go to the OLT as they are.
+type columnRepository struct {
+	snmpRepository repository.SnmpRepositoryInterface
+	columns        []string // Normalized OIDs of the columns, longest first
+	mu             sync.Mutex
+	walked         map[string]*walkedColumn // keyed by column
+}
+
+// walkedColumn is the outcome of the walk of a column.
+type walkedColumn struct {
+	pdus  []gosnmp.SnmpPDU          // In walk order
+	byOID map[string]gosnmp.SnmpPDU // keyed by normalized OID
+	err   error
+}
+
+// newColumnRepository creates a repository reading the given columns through snmpRepository.
+func newColumnRepository(snmpRepository repository.SnmpRepositoryInterface, columns []string) *columnRepository {
+	normalized := make([]string, 0, len(columns))
+	for _, column := range columns {
+		if column = utils.NormalizeOID(column); column != "" && !slices.Contains(normalized, column) {
+			normalized = append(normalized, column)
+		}
+	}
+	// A column nested in another is matched before it
+	slices.SortFunc(normalized, func(a, b string) int {
+		return cmp.Compare(len(b), len(a))
+	})
+
+	return &columnRepository{
+		snmpRepository: snmpRepository,
+		columns:        normalized,
+		walked:         make(map[string]*walkedColumn),
+	}
+}
+
+// column returns the column holding oid, or "" when it is outside of every column.
+func (r *columnRepository) column(oid string) string {
+	oid = utils.NormalizeOID(oid)
+	for _, column := range r.columns {
+		if oid == column || strings.HasPrefix(oid, column+".") {
+			return column
+		}
+	}
+	return ""
+}
+
+// walk returns the walked column, walking it on its first use. A failed walk is not retried.
+func (r *columnRepository) walk(ctx context.Context, column string) *walkedColumn {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if walked, ok := r.walked[column]; ok {
+		return walked
+	}
+
+	walked := &walkedColumn{byOID: make(map[string]gosnmp.SnmpPDU)}
+	walked.err = r.snmpRepository.Walk(ctx, column, func(pdu gosnmp.SnmpPDU) error {
+		walked.pdus = append(walked.pdus, pdu)
+		walked.byOID[utils.NormalizeOID(pdu.Name)] = pdu
+		return nil
+	})
+	r.walked[column] = walked
+	return walked
+}
+
+// Get answers a single OID from its column, with a noSuchInstance exception when the walk of the
+// column did not return it, like the OLT answers an OID it does not know.
+func (r *columnRepository) Get(ctx context.Context, oids []string) (*gosnmp.SnmpPacket, error) {
+	column := ""
+	if len(oids) == 1 {
+		column = r.column(oids[0])
+	}
+	if column == "" {
+		return r.snmpRepository.Get(ctx, oids)
+	}
+
+	walked := r.walk(ctx, column)
+	if walked.err != nil {
+		return nil, walked.err
+	}
+	pdu, ok := walked.byOID[utils.NormalizeOID(oids[0])]
+	if !ok {
+		pdu = gosnmp.SnmpPDU{Name: oids[0], Type: gosnmp.NoSuchInstance}
+	}
+	return &gosnmp.SnmpPacket{Variables: []gosnmp.SnmpPDU{pdu}}, nil
+}
+
+// Walk hands over the OIDs under oid from the walk of its column.
+func (r *columnRepository) Walk(ctx context.Context, oid string, walkFunc func(pdu gosnmp.SnmpPDU) error) error {
+	column := r.column(oid)
+	if column == "" {
+		return r.snmpRepository.Walk(ctx, oid, walkFunc)
+	}
+
+	walked := r.walk(ctx, column)
+	if walked.err != nil {
+		return walked.err
+	}
+	root := utils.NormalizeOID(oid)
+	for _, pdu := range walked.pdus {
+		if name := utils.NormalizeOID(pdu.Name); name != root && !strings.HasPrefix(name, root+".") {
+			continue
+		}
+		if err := walkFunc(pdu); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"reflect"
+	"unicode/utf8"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/config"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/repository"
+)
+
+// errWalkLimitReached stops a debug walk once it returned the maximum number of OIDs
+var errWalkLimitReached = errors.New("walk limit reached")
+
+// redacted replaces the credentials returned by the debug API
+const redacted = "REDACTED"
+
+// baseOID2Keys are the PON config keys read under BaseOID2, all others are read under BaseOID1
+var baseOID2Keys = map[string]bool{
+	"onu_type":       true,
+	"onu_tx_power":   true,
+	"onu_ip_address": true,
+	"onu_ip_gateway": true,
+	"onu_ip_mask":    true,
+}
+
+// ScanRangeSource provides the boards and PONs scanned by the Prometheus collector.
+type ScanRangeSource interface {
+	ScanRange() model.ScanRange
+}
+
+// DebugUseCaseInterface is an interface that represent the debug's usecase contract
+type DebugUseCaseInterface interface {
+	Walk(ctx context.Context, oid string) (model.SnmpWalkResult, error)
+	GetConfig(boardID, ponID int) (model.EffectiveConfig, error)
+}
+
+// debugUsecase represent the debug's usecase
+type debugUsecase struct {
+	snmpRepository repository.SnmpRepositoryInterface
+	cfg            *config.Config
+	scanRange      ScanRangeSource
+}
+
+// NewDebugUsecase will create an object that represent the debug usecase
+func NewDebugUsecase(snmpRepository repository.SnmpRepositoryInterface, cfg *config.Config, scanRange ScanRangeSource) DebugUseCaseInterface {
+	return &debugUsecase{
+		snmpRepository: snmpRepository,
+		cfg:            cfg,
+		scanRange:      scanRange,
+	}
+}
+
+// Walk walks the given OID and returns the raw OID-value pairs, stopping after
+// cfg.DebugCfg.WalkMaxResults entries so a walk of a large subtree cannot overload the OLT
+func (u *debugUsecase) Walk(ctx context.Context, oid string) (model.SnmpWalkResult, error) {
+	maxResults := u.cfg.DebugCfg.WalkMaxResults
+	if maxResults < 1 {
+		maxResults = 1000
+	}
+
+	result := model.SnmpWalkResult{OID: oid, Entries: []model.SnmpWalkEntry{}}
+	err := u.snmpRepository.Walk(ctx, oid, func(pdu gosnmp.SnmpPDU) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if len(result.Entries) >= maxResults {
+			result.Truncated = true
+			return errWalkLimitReached
+		}
+		result.Entries = append(result.Entries, model.SnmpWalkEntry{
+			OID:   pdu.Name,
+			Type:  pdu.Type.String(),
+			Value: formatPDUValue(pdu.Value),
+		})
+		return nil
+	})
+	if err != nil && !errors.Is(err, errWalkLimitReached) {
+		return model.SnmpWalkResult{}, err
+	}
+
+	result.Count = len(result.Entries)
+	return result, nil
+}
+
+// ponOIDs returns the full OIDs of the configured keys of a PON, keyed by config key
+func ponOIDs(cfg *config.Config, oltConfig model.OltConfig) map[string]string {
+	ponCfg := reflect.ValueOf(oltConfig)
+	oids := make(map[string]string)
+	for i := 0; i < ponCfg.NumField(); i++ {
+		key := ponCfg.Type().Field(i).Tag.Get("mapstructure")
+		oid := ponCfg.Field(i).String()
+		if key == "" || key == "-" || oid == "" {
+			continue // Optional OIDs left empty are not read
+		}
+		if baseOID2Keys[key] {
+			oids[key] = cfg.OltCfg.BaseOID2 + oid
+		} else {
+			oids[key] = cfg.OltCfg.BaseOID1 + oid
+		}
+	}
+	return oids
+}
+
+// GetConfig returns the configuration in use for the given board and PON: the full OIDs of its
+// configured keys, the SNMP settings with the community redacted and the collector scan range
+func (u *debugUsecase) GetConfig(boardID, ponID int) (model.EffectiveConfig, error) {
+	oltConfig, ok := u.cfg.Pons[boardID][ponID]
+	if !ok {
+		return model.EffectiveConfig{}, fmt.Errorf("no config for board %d and pon %d", boardID, ponID)
+	}
+
+	oids := ponOIDs(u.cfg, oltConfig)
+
+	community := u.cfg.SnmpCfg.Community
+	if community != "" {
+		community = redacted
+	}
+
+	return model.EffectiveConfig{
+		Board:     boardID,
+		PON:       ponID,
+		ScanRange: u.scanRange.ScanRange(),
+		Snmp: model.SnmpSettings{
+			IP:          u.cfg.SnmpCfg.IP,
+			Port:        u.cfg.SnmpCfg.Port,
+			Version:     u.cfg.SnmpCfg.Version,
+			Community:   community,
+			V3Username:  u.cfg.SnmpCfg.V3.Username,
+			ContextName: u.cfg.SnmpCfg.ContextName,
+			WalkMethod:  u.cfg.SnmpCfg.WalkMethod,
+			LocalAddr:   u.cfg.SnmpCfg.LocalAddr,
+		},
+		OIDs: oids,
+	}, nil
+}
+
+// formatPDUValue renders a PDU value as text. Octet strings that are not valid UTF-8, such as MAC
+// addresses or binary serial numbers, are rendered as hex.
+func formatPDUValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []byte:
+		if utf8.Valid(v) {
+			return string(v)
+		}
+		return "0x" + hex.EncodeToString(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
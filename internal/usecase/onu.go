@@ -4,8 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"sort"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/gosnmp/gosnmp"
@@ -13,6 +16,7 @@ import (
 	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
 	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/repository"
 	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/utils"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/pkg/pagination"
 	"github.com/rs/zerolog/log"
 	"golang.org/x/sync/singleflight"
 )
@@ -20,10 +24,13 @@ import (
 // OnuUseCaseInterface is an interface that represent the auth's usecase contract
 type OnuUseCaseInterface interface {
 	GetByBoardIDAndPonID(ctx context.Context, boardID, ponID int) ([]model.ONUInfoPerBoard, error)
-	GetByBoardIDPonIDAndOnuID(boardID, ponID, onuID int) (model.ONUCustomerInfo, error)
+	GetByBoardIDPonIDAndOnuID(ctx context.Context, boardID, ponID, onuID int) (model.ONUCustomerInfo, error)
+	GetAllDetailsByBoardPon(ctx context.Context, boardID, ponID int) ([]model.ONUCustomerInfo, error)
+	GetStatusesByBoardPon(ctx context.Context, boardID, ponID int) (map[int]string, error)
 	GetEmptyOnuID(ctx context.Context, boardID, ponID int) ([]model.OnuID, error)
 	GetOnuIDAndSerialNumber(boardID, ponID int) ([]model.OnuSerialNumber, error)
 	UpdateEmptyOnuID(ctx context.Context, boardID, ponID int) error
+	MaxOnuPerPon() int
 	GetByBoardIDAndPonIDWithPagination(boardID, ponID, page, pageSize int) (
 		[]model.ONUInfoPerBoard, int,
 	)
@@ -31,20 +38,24 @@ type OnuUseCaseInterface interface {
 
 // onuUsecase represent the auth's usecase
 type onuUsecase struct {
-	snmpRepository repository.SnmpRepositoryInterface
-	cfg            *config.Config
-	sg             singleflight.Group
+	snmpRepository  repository.SnmpRepositoryInterface
+	cacheRepository repository.CacheRepository // ONU lists of the paginated API, nil when the cache is disabled
+	cfg             *config.Config
+	sg              singleflight.Group
 }
 
-// NewOnuUsecase will create an object that represent the auth usecase
+// NewOnuUsecase will create an object that represent the auth usecase. cacheRepository may be nil
+// to read every paginated ONU list from the OLT.
 func NewOnuUsecase(
 	snmpRepository repository.SnmpRepositoryInterface,
+	cacheRepository repository.CacheRepository,
 	cfg *config.Config,
 ) OnuUseCaseInterface {
 	return &onuUsecase{
-		snmpRepository: snmpRepository,
-		cfg:            cfg,
-		sg:             singleflight.Group{},
+		snmpRepository:  snmpRepository,
+		cacheRepository: cacheRepository,
+		cfg:             cfg,
+		sg:              singleflight.Group{},
 	}
 }
 
@@ -58,552 +69,18 @@ func (u *onuUsecase) getOltConfig(boardID, ponID int) (*model.OltConfig, error)
 	return cfg, nil
 }
 
-// getBoardConfig is a function to get board configuration
+// getBoardConfig returns the OIDs configured for the given board and PON
 func (u *onuUsecase) getBoardConfig(boardID, ponID int) (*model.OltConfig, error) {
-	switch boardID {
-	case 1:
-		return u.getBoard1Config(ponID), nil
-	case 2:
-		return u.getBoard2Config(ponID), nil
-	default:
-		return nil, errors.New("invalid Board ID")
-	}
-}
-
-// getBoard1Config is a function to get board 1 configuration
-func (u *onuUsecase) getBoard1Config(ponID int) *model.OltConfig {
-	// Define the configuration for Board 1
-	switch ponID {
-	case 1:
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board1Pon1.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board1Pon1.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board1Pon1.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board1Pon1.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board1Pon1.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board1Pon1.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board1Pon1.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board1Pon1.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board1Pon1.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board1Pon1.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board1Pon1.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board1Pon1.OnuGponOpticalDistanceOID,
-		}
-	case 2:
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board1Pon2.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board1Pon2.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board1Pon2.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board1Pon2.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board1Pon2.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board1Pon2.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board1Pon2.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board1Pon2.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board1Pon2.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board1Pon2.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board1Pon2.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board1Pon2.OnuGponOpticalDistanceOID,
-		}
-	case 3: // PON 3
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board1Pon3.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board1Pon3.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board1Pon3.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board1Pon3.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board1Pon3.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board1Pon3.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board1Pon3.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board1Pon3.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board1Pon3.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board1Pon3.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board1Pon3.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board1Pon3.OnuGponOpticalDistanceOID,
-		}
-	case 4: // PON 4
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board1Pon4.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board1Pon4.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board1Pon4.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board1Pon4.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board1Pon4.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board1Pon4.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board1Pon4.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board1Pon4.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board1Pon4.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board1Pon4.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board1Pon4.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board1Pon4.OnuGponOpticalDistanceOID,
-		}
-	case 5: // PON 5
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board1Pon5.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board1Pon5.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board1Pon5.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board1Pon5.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board1Pon5.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board1Pon5.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board1Pon5.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board1Pon5.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board1Pon5.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board1Pon5.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board1Pon5.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board1Pon5.OnuGponOpticalDistanceOID,
-		}
-	case 6: // PON 6
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board1Pon6.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board1Pon6.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board1Pon6.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board1Pon6.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board1Pon6.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board1Pon6.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board1Pon6.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board1Pon6.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board1Pon6.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board1Pon6.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board1Pon6.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board1Pon6.OnuGponOpticalDistanceOID,
-		}
-	case 7: // PON 7
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board1Pon7.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board1Pon7.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board1Pon7.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board1Pon7.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board1Pon7.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board1Pon7.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board1Pon7.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board1Pon7.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board1Pon7.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board1Pon7.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board1Pon7.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board1Pon7.OnuGponOpticalDistanceOID,
-		}
-	case 8: // PON 8
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board1Pon8.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board1Pon8.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board1Pon8.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board1Pon8.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board1Pon8.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board1Pon8.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board1Pon8.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board1Pon8.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board1Pon8.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board1Pon8.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board1Pon8.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board1Pon8.OnuGponOpticalDistanceOID,
-		}
-	case 9: // PON 9
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board1Pon9.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board1Pon9.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board1Pon9.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board1Pon9.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board1Pon9.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board1Pon9.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board1Pon9.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board1Pon9.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board1Pon9.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board1Pon9.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board1Pon9.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board1Pon9.OnuGponOpticalDistanceOID,
-		}
-	case 10: // PON 10
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board1Pon10.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board1Pon10.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board1Pon10.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board1Pon10.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board1Pon10.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board1Pon10.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board1Pon10.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board1Pon10.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board1Pon10.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board1Pon10.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board1Pon10.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board1Pon10.OnuGponOpticalDistanceOID,
-		}
-	case 11: // PON 11
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board1Pon11.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board1Pon11.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board1Pon11.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board1Pon11.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board1Pon11.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board1Pon11.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board1Pon11.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board1Pon11.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board1Pon11.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board1Pon11.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board1Pon11.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board1Pon11.OnuGponOpticalDistanceOID,
-		}
-	case 12: // PON 12
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board1Pon12.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board1Pon12.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board1Pon12.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board1Pon12.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board1Pon12.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board1Pon12.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board1Pon12.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board1Pon12.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board1Pon12.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board1Pon12.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board1Pon12.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board1Pon12.OnuGponOpticalDistanceOID,
-		}
-	case 13: // PON 13
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board1Pon13.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board1Pon13.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board1Pon13.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board1Pon13.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board1Pon13.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board1Pon13.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board1Pon13.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board1Pon13.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board1Pon13.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board1Pon13.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board1Pon13.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board1Pon13.OnuGponOpticalDistanceOID,
-		}
-	case 14: // PON 14
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board1Pon14.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board1Pon14.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board1Pon14.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board1Pon14.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board1Pon14.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board1Pon14.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board1Pon14.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board1Pon14.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board1Pon14.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board1Pon14.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board1Pon14.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board1Pon14.OnuGponOpticalDistanceOID,
-		}
-	case 15: // PON 15
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board1Pon15.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board1Pon15.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board1Pon15.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board1Pon15.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board1Pon15.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board1Pon15.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board1Pon15.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board1Pon15.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board1Pon15.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board1Pon15.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board1Pon15.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board1Pon15.OnuGponOpticalDistanceOID,
-		}
-
-	case 16: // PON 16
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board1Pon16.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board1Pon16.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board1Pon16.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board1Pon16.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board1Pon16.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board1Pon16.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board1Pon16.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board1Pon16.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board1Pon16.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board1Pon16.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board1Pon16.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board1Pon16.OnuGponOpticalDistanceOID,
-		}
-
-	default:
-		log.Error().Msg("Invalid PON ID") // Log error message
-		return nil
+	pons, ok := u.cfg.Pons[boardID]
+	if !ok {
+		return nil, fmt.Errorf("invalid Board ID %d", boardID)
 	}
-}
-
-// getBoard2Config is a function to get board 2 configuration
-func (u *onuUsecase) getBoard2Config(ponID int) *model.OltConfig {
-	// Define the configuration for Board 2
-	switch ponID {
-	case 1: // PON 1
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board2Pon1.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board2Pon1.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board2Pon1.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board2Pon1.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board2Pon1.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board2Pon1.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board2Pon1.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board2Pon1.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board2Pon1.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board2Pon1.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board2Pon1.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board2Pon1.OnuGponOpticalDistanceOID,
-		}
-	case 2: // PON 2
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board2Pon2.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board2Pon2.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board2Pon2.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board2Pon2.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board2Pon2.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board2Pon2.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board2Pon2.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board2Pon2.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board2Pon2.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board2Pon2.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board2Pon2.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board2Pon2.OnuGponOpticalDistanceOID,
-		}
-	case 3: // PON 3
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board2Pon3.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board2Pon3.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board2Pon3.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board2Pon3.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board2Pon3.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board2Pon3.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board2Pon3.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board2Pon3.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board2Pon3.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board2Pon3.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board2Pon3.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board2Pon3.OnuGponOpticalDistanceOID,
-		}
-	case 4: // PON 4
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board2Pon4.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board2Pon4.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board2Pon4.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board2Pon4.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board2Pon4.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board2Pon4.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board2Pon4.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board2Pon4.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board2Pon4.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board2Pon4.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board2Pon4.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board2Pon4.OnuGponOpticalDistanceOID,
-		}
-	case 5: // PON 5
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board2Pon5.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board2Pon5.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board2Pon5.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board2Pon5.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board2Pon5.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board2Pon5.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board2Pon5.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board2Pon5.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board2Pon5.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board2Pon5.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board2Pon5.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board2Pon5.OnuGponOpticalDistanceOID,
-		}
-	case 6: // PON 6
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board2Pon6.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board2Pon6.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board2Pon6.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board2Pon6.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board2Pon6.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board2Pon6.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board2Pon6.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board2Pon6.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board2Pon6.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board2Pon6.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board2Pon6.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board2Pon6.OnuGponOpticalDistanceOID,
-		}
-	case 7: // PON 7
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board2Pon7.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board2Pon7.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board2Pon7.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board2Pon7.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board2Pon7.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board2Pon7.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board2Pon7.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board2Pon7.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board2Pon7.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board2Pon7.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board2Pon7.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board2Pon7.OnuGponOpticalDistanceOID,
-		}
-	case 8: // PON 8
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board2Pon8.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board2Pon8.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board2Pon8.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board2Pon8.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board2Pon8.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board2Pon8.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board2Pon8.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board2Pon8.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board2Pon8.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board2Pon8.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board2Pon8.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board2Pon8.OnuGponOpticalDistanceOID,
-		}
-	case 9: // PON 9
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board2Pon9.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board2Pon9.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board2Pon9.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board2Pon9.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board2Pon9.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board2Pon9.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board2Pon9.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board2Pon9.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board2Pon9.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board2Pon9.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board2Pon9.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board2Pon9.OnuGponOpticalDistanceOID,
-		}
-	case 10: // PON 10
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board2Pon10.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board2Pon10.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board2Pon10.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board2Pon10.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board2Pon10.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board2Pon10.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board2Pon10.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board2Pon10.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board2Pon10.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board2Pon10.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board2Pon10.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board2Pon10.OnuGponOpticalDistanceOID,
-		}
-	case 11: // PON 11
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board2Pon11.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board2Pon11.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board2Pon11.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board2Pon11.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board2Pon11.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board2Pon11.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board2Pon11.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board2Pon11.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board2Pon11.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board2Pon11.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board2Pon11.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board2Pon11.OnuGponOpticalDistanceOID,
-		}
-
-	case 12: // PON 12
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board2Pon12.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board2Pon12.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board2Pon12.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board2Pon12.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board2Pon12.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board2Pon12.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board2Pon12.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board2Pon12.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board2Pon12.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board2Pon12.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board2Pon12.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board2Pon12.OnuGponOpticalDistanceOID,
-		}
-
-	case 13: // PON 13
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board2Pon13.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board2Pon13.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board2Pon13.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board2Pon13.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board2Pon13.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board2Pon13.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board2Pon13.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board2Pon13.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board2Pon13.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board2Pon13.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board2Pon13.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board2Pon13.OnuGponOpticalDistanceOID,
-		}
-	case 14: // PON 14
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board2Pon14.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board2Pon14.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board2Pon14.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board2Pon14.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board2Pon14.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board2Pon14.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board2Pon14.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board2Pon14.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board2Pon14.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board2Pon14.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board2Pon14.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board2Pon14.OnuGponOpticalDistanceOID,
-		}
-	case 15: // PON 15
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board2Pon15.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board2Pon15.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board2Pon15.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board2Pon15.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board2Pon15.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board2Pon15.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board2Pon15.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board2Pon15.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board2Pon15.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board2Pon15.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board2Pon15.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board2Pon15.OnuGponOpticalDistanceOID,
-		}
-	case 16: // PON 16
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board2Pon16.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board2Pon16.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board2Pon16.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board2Pon16.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board2Pon16.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board2Pon16.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board2Pon16.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board2Pon16.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board2Pon16.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board2Pon16.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board2Pon16.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board2Pon16.OnuGponOpticalDistanceOID,
-		}
-	default:
-		log.Error().Msg("Invalid PON ID") // Log error message
-		return nil
+	oltConfig, ok := pons[ponID]
+	if !ok {
+		return nil, fmt.Errorf("invalid PON ID %d for Board ID %d", ponID, boardID)
 	}
+	oltConfig.BaseOID = u.cfg.OltCfg.BaseOID1
+	return &oltConfig, nil
 }
 
 func (u *onuUsecase) GetByBoardIDAndPonID(ctx context.Context, boardID, ponID int) ([]model.ONUInfoPerBoard, error) {
@@ -620,21 +97,36 @@ func (u *onuUsecase) GetByBoardIDAndPonID(ctx context.Context, boardID, ponID in
 			return nil, err
 		}
 
-
 		// SNMP Walk to get Information from OLT Board and PON
 		log.Info().Msg("Get All ONU Information from SNMP Walk Board ID: " + strconv.Itoa(boardID) + " and PON ID: " + strconv.Itoa(ponID))
 		// Create a map to store SNMP Walk results
-		snmpDataMap := make(map[string]gosnmp.SnmpPDU)
-		// Perform SNMP Walk to get ONU ID and Name using snmpRepository Walk method with timeout context parameter
-		err = u.snmpRepository.Walk(oltConfig.BaseOID+oltConfig.OnuIDNameOID, func(pdu gosnmp.SnmpPDU) error {
-			snmpDataMap[utils.ExtractONUID(pdu.Name)] = pdu
-			return nil
+		var snmpDataMap map[string]gosnmp.SnmpPDU
+		// Perform SNMP Walk to get ONU ID and Name using snmpRepository Walk method with timeout context
+		// parameter, retried from scratch on a transient failure as it is all the PON is discovered from
+		attempts := 0
+		err = repository.Retry(ctx, u.discoveryRetryPolicy(), func() error {
+			if attempts++; attempts > 1 {
+				log.Warn().Int("board", boardID).Int("pon", ponID).Int("attempt", attempts).Msg("Retrying the ONU walk of the PON")
+			}
+			snmpDataMap = make(map[string]gosnmp.SnmpPDU)
+			return u.snmpRepository.Walk(ctx, oltConfig.BaseOID+oltConfig.OnuIDNameOID, func(pdu gosnmp.SnmpPDU) error {
+				snmpDataMap[utils.ExtractONUID(pdu.Name)] = pdu
+				return nil
+			})
 		})
 
 		if err != nil {
 			return nil, err
 		}
 
+		// Read the status of every ONU with a single walk when enabled, instead of one Get per ONU
+		var statuses map[int]string
+		if u.cfg.OltCfg.StatusWalk {
+			if statuses, err = u.GetStatusesByBoardPon(ctx, boardID, ponID); err != nil {
+				log.Warn().Msg("Failed to walk ONU status, falling back to per-ONU requests: " + err.Error())
+			}
+		}
+
 		var onuInformationList []model.ONUInfoPerBoard // Create a slice of ONUInfoPerBoard
 
 		// Loop through SNMP data map to get ONU information based on ONU ID and ONU Name stored in map before and store
@@ -647,20 +139,28 @@ func (u *onuUsecase) GetByBoardIDAndPonID(ctx context.Context, boardID, ponID in
 			}
 
 			// Get Data ONU Type from SNMP Walk using getONUType method
-			if onuType, err := u.getONUType(oltConfig.OnuTypeOID, strconv.Itoa(onuInfo.ID)); err == nil {
+			if onuType, err := u.getONUType(ctx, oltConfig.OnuTypeOID, strconv.Itoa(onuInfo.ID)); err == nil {
 				onuInfo.OnuType = onuType
 			}
 			// Get Data ONU Serial Number from SNMP Walk using getSerialNumber method
-			if sn, err := u.getSerialNumber(oltConfig.OnuSerialNumberOID, strconv.Itoa(onuInfo.ID)); err == nil {
+			if sn, err := u.getSerialNumber(ctx, oltConfig.OnuSerialNumberOID, strconv.Itoa(onuInfo.ID)); err == nil {
 				onuInfo.SerialNumber = sn
 			}
 			// Get Data ONU RX Power from SNMP Walk using getRxPower method
-			if rx, err := u.getRxPower(oltConfig.OnuRxPowerOID, strconv.Itoa(onuInfo.ID)); err == nil {
+			if rx, err := u.getRxPower(ctx, oltConfig.OnuRxPowerOID, strconv.Itoa(onuInfo.ID)); err == nil {
 				onuInfo.RXPower = rx
 			}
-			// Get Data ONU TX Power from SNMP Walk using getTxPower method
-			if status, err := u.getStatus(oltConfig.OnuStatusOID, strconv.Itoa(onuInfo.ID)); err == nil {
-				onuInfo.Status = status
+			// Get Data ONU status from the status walk, or from SNMP Get using getStatus method
+			if status, ok := statuses[onuInfo.ID]; ok {
+				onuInfo.Status = u.reconcileLosFlag(ctx, oltConfig, strconv.Itoa(onuInfo.ID), status)
+			} else if status, err := u.getStatus(ctx, oltConfig.OnuStatusOID, strconv.Itoa(onuInfo.ID)); err == nil {
+				onuInfo.Status = u.reconcileLosFlag(ctx, oltConfig, strconv.Itoa(onuInfo.ID), status)
+			}
+			// Get Data ONU MAC address only when its OID is configured, e.g. to deduplicate ONUs by MAC
+			if oltConfig.OnuMACAddressOID != "" {
+				if macAddress, err := u.getMACAddress(ctx, oltConfig.OnuMACAddressOID, strconv.Itoa(onuInfo.ID)); err == nil {
+					onuInfo.MACAddress = macAddress
+				}
 			}
 
 			// Get Data ONU IP Address from SNMP Walk using getIPAddress method
@@ -684,7 +184,44 @@ func (u *onuUsecase) GetByBoardIDAndPonID(ctx context.Context, boardID, ponID in
 	return result.([]model.ONUInfoPerBoard), nil // Return the result from the cache or SNMP Walk
 }
 
-func (u *onuUsecase) GetByBoardIDPonIDAndOnuID(boardID, ponID, onuID int) (
+// discoveryRetryPolicy returns the retries of the ONU walk of a PON during discovery
+func (u *onuUsecase) discoveryRetryPolicy() repository.RetryPolicy {
+	return repository.RetryPolicy{Retries: u.cfg.OltCfg.DiscoveryRetries, Backoff: u.cfg.OltCfg.DiscoveryRetryBackoff}
+}
+
+// GetStatusesByBoardPon returns the status of every ONU of a PON keyed by ONU ID, read with a single
+// SNMP walk of the status OID. It is a cheap way to detect status changes without per-ONU requests.
+func (u *onuUsecase) GetStatusesByBoardPon(ctx context.Context, boardID, ponID int) (map[int]string, error) {
+	key := fmt.Sprintf("onu_status:%d:%d", boardID, ponID)
+
+	// Using simple flight to prevent duplicate SNMP requests
+	result, err, _ := u.sg.Do(key, func() (interface{}, error) {
+		oltConfig, err := u.getOltConfig(boardID, ponID)
+		if err != nil {
+			log.Error().Msg("Failed to get OLT Config for Get ONU Status: " + err.Error())
+			return nil, err
+		}
+
+		statuses := make(map[int]string)
+		err = u.snmpRepository.Walk(ctx, u.cfg.OltCfg.BaseOID1+oltConfig.OnuStatusOID, func(pdu gosnmp.SnmpPDU) error {
+			statuses[utils.ExtractIDOnuID(pdu.Name)] = utils.ExtractAndGetStatus(pdu.Value)
+			return nil
+		})
+		if err != nil {
+			log.Error().Msg("Failed to perform SNMP Walk get ONU status: " + err.Error())
+			return nil, err
+		}
+
+		return statuses, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.(map[int]string), nil
+}
+
+func (u *onuUsecase) GetByBoardIDPonIDAndOnuID(ctx context.Context, boardID, ponID, onuID int) (
 	model.ONUCustomerInfo, error,
 ) {
 	// Set key for simple flight
@@ -698,171 +235,395 @@ func (u *onuUsecase) GetByBoardIDPonIDAndOnuID(boardID, ponID, onuID int) (
 			return model.ONUCustomerInfo{}, err
 		}
 
-		var onuInformationList model.ONUCustomerInfo   // Create a variable to store ONU information
-		snmpDataMap := make(map[string]gosnmp.SnmpPDU) // Create a map to store SNMP Walk results
+		return u.getOnuDetails(ctx, oltConfig, boardID, ponID, onuID)
+	})
 
-		log.Info().Msg("Get Detail ONU Information with SNMP Walk from Board ID: " +
-			strconv.Itoa(boardID) + " PON ID: " + strconv.Itoa(ponID) +
-			" ONU ID: " + strconv.Itoa(onuID))
+	if err != nil {
+		return model.ONUCustomerInfo{}, err
+	}
 
-		// Get ONU ID and Name using snmpRepository Walk method with timeout context parameter
-		err = u.snmpRepository.Walk(oltConfig.BaseOID+oltConfig.OnuIDNameOID+"."+strconv.Itoa(onuID),
-			func(pdu gosnmp.SnmpPDU) error {
-				snmpDataMap[utils.ExtractONUID(pdu.Name)] = pdu
-				return nil
-			})
-		if err != nil {
-			log.Error().Msg("Failed to walk OID: " + err.Error())
-			return model.ONUCustomerInfo{}, errors.New("failed to walk OID")
+	return result.(model.ONUCustomerInfo), nil // Return the result from the cache or SNMP Walk
+}
+
+// getOnuDetails reads the details of a single ONU of the PON of oltConfig, attribute by attribute.
+// An ONU that is not registered is returned empty.
+func (u *onuUsecase) getOnuDetails(ctx context.Context, oltConfig *model.OltConfig, boardID, ponID, onuID int) (model.ONUCustomerInfo, error) {
+	var onuInformationList model.ONUCustomerInfo   // Create a variable to store ONU information
+	snmpDataMap := make(map[string]gosnmp.SnmpPDU) // Create a map to store SNMP Walk results
+
+	log.Info().Msg("Get Detail ONU Information with SNMP Walk from Board ID: " +
+		strconv.Itoa(boardID) + " PON ID: " + strconv.Itoa(ponID) +
+		" ONU ID: " + strconv.Itoa(onuID))
+
+	// Get ONU ID and Name using snmpRepository Walk method with timeout context parameter
+	err := u.snmpRepository.Walk(ctx, oltConfig.BaseOID+oltConfig.OnuIDNameOID+"."+strconv.Itoa(onuID),
+		func(pdu gosnmp.SnmpPDU) error {
+			snmpDataMap[utils.ExtractONUID(pdu.Name)] = pdu
+			return nil
+		})
+	if err != nil {
+		log.Error().Msg("Failed to walk OID: " + err.Error())
+		return model.ONUCustomerInfo{}, errors.New("failed to walk OID")
+	}
+
+	// Loop through SNMP data map to get ONU information based on ONU ID and ONU Name stored in map before and store
+	for _, pdu := range snmpDataMap {
+		onuInfo := model.ONUCustomerInfo{
+			Board: boardID,
+			PON:   ponID,
+			ID:    utils.ExtractIDOnuID(pdu.Name),
+			Name:  utils.ExtractName(pdu.Value),
 		}
 
-		// Loop through SNMP data map to get ONU information based on ONU ID and ONU Name stored in map before and store
-		for _, pdu := range snmpDataMap {
-			onuInfo := model.ONUCustomerInfo{
-				Board: boardID,
-				PON:   ponID,
-				ID:    utils.ExtractIDOnuID(pdu.Name),
-				Name:  utils.ExtractName(pdu.Value),
+		// Get Data ONU Type from SNMP Walk using getONUType method
+		if onuType, err := u.getONUType(ctx, oltConfig.OnuTypeOID, strconv.Itoa(onuInfo.ID)); err == nil {
+			onuInfo.OnuType = onuType
+		}
+
+		// Get Data ONU Serial Number from SNMP Walk using getSerialNumber method
+		if serial, err := u.getSerialNumber(ctx, oltConfig.OnuSerialNumberOID, strconv.Itoa(onuInfo.ID)); err == nil {
+			onuInfo.SerialNumber = serial
+		}
+
+		// Get Data ONU RX Power from SNMP Walk using getRxPower method
+		if rx, err := u.getRxPower(ctx, oltConfig.OnuRxPowerOID, strconv.Itoa(onuInfo.ID)); err == nil {
+			onuInfo.RXPower = rx
+		}
+
+		// Get Data ONU TX Power from SNMP Walk using getTxPower method
+		if tx, err := u.getTxPower(ctx, oltConfig.OnuTxPowerOID, strconv.Itoa(onuInfo.ID)); err == nil {
+			onuInfo.TXPower = tx
+		}
+
+		// Get Data ONU Status from SNMP Walk using getStatus method
+		if status, err := u.getStatus(ctx, oltConfig.OnuStatusOID, strconv.Itoa(onuInfo.ID)); err == nil {
+			onuInfo.Status = u.reconcileLosFlag(ctx, oltConfig, strconv.Itoa(onuInfo.ID), status)
+		}
+
+		// Get Data ONU IP Address from SNMP Walk using getIPAddress method
+		if ip, err := u.getIPAddress(ctx, oltConfig.OnuIPAddressOID, strconv.Itoa(onuInfo.ID)); err == nil {
+			onuInfo.IPAddress = ip
+		}
+
+		// Get Data ONU management gateway and subnet mask only when their OIDs are configured
+		if oltConfig.OnuIPGatewayOID != "" {
+			if gateway, err := u.getIPGateway(ctx, oltConfig.OnuIPGatewayOID, strconv.Itoa(onuInfo.ID)); err == nil {
+				onuInfo.IPGateway = gateway
+			}
+		}
+		if oltConfig.OnuIPMaskOID != "" {
+			if mask, err := u.getIPMask(ctx, oltConfig.OnuIPMaskOID, strconv.Itoa(onuInfo.ID)); err == nil {
+				onuInfo.IPMask = mask
 			}
+		}
 
-			// Get Data ONU Type from SNMP Walk using getONUType method
-			if onuType, err := u.getONUType(oltConfig.OnuTypeOID, strconv.Itoa(onuInfo.ID)); err == nil {
-				onuInfo.OnuType = onuType
+		// Get Data ONU Description from SNMP Walk using getDescription method
+		if desc, err := u.getDescription(ctx, oltConfig.OnuDescriptionOID, strconv.Itoa(onuInfo.ID)); err == nil {
+			onuInfo.Description = desc
+		}
+
+		// Get Data ONU Last Online from SNMP Walk using getLastOnline method
+		if lastOnline, err := u.getLastOnline(ctx, oltConfig.OnuLastOnlineOID, strconv.Itoa(onuInfo.ID)); err == nil {
+			onuInfo.LastOnline = lastOnline
+		}
+
+		// Get Data ONU Last Offline from SNMP Walk using getLastOffline method
+		if lastOffline, err := u.getLastOffline(ctx, oltConfig.OnuLastOfflineOID, strconv.Itoa(onuInfo.ID)); err == nil {
+			onuInfo.LastOffline = lastOffline
+		}
+
+		// Get Data ONU Last Offline Reason from SNMP Walk using getLastOfflineReason method
+		if uptime, err := u.getUptimeDuration(onuInfo.LastOnline); err == nil {
+			onuInfo.Uptime = uptime
+		}
+
+		// Get Data ONU Last Downtime Duration from SNMP Walk using getLastDownDuration method
+		if downtime, err := u.getLastDownDuration(onuInfo.LastOffline, onuInfo.LastOnline); err == nil {
+			onuInfo.LastDownTimeDuration = downtime
+		}
+
+		// Get Data ONU Last Offline Reason from SNMP Walk using getLastOfflineReason method
+		if reason, err := u.getLastOfflineReason(ctx, oltConfig.OnuLastOfflineReasonOID, strconv.Itoa(onuInfo.ID)); err == nil {
+			onuInfo.LastOfflineReason = reason
+		}
+
+		// Get Data ONU GPON Optical Distance from SNMP Walk using getOnuGponOpticalDistance method
+		if dist, err := u.getOnuGponOpticalDistance(ctx, oltConfig.OnuGponOpticalDistanceOID, strconv.Itoa(onuInfo.ID)); err == nil {
+			onuInfo.GponOpticalDistance = dist
+		}
+
+		// Get Data ONU signal quality index only when its OID is configured
+		if oltConfig.OnuSignalQualityOID != "" {
+			if quality, err := u.getSignalQuality(ctx, oltConfig.OnuSignalQualityOID, strconv.Itoa(onuInfo.ID)); err == nil {
+				onuInfo.SignalQuality = quality
 			}
+		}
 
-			// Get Data ONU Serial Number from SNMP Walk using getSerialNumber method
-			if serial, err := u.getSerialNumber(oltConfig.OnuSerialNumberOID, strconv.Itoa(onuInfo.ID)); err == nil {
-				onuInfo.SerialNumber = serial
+		// Get Data ONU transceiver diagnostics only when their OIDs are configured
+		if oltConfig.OnuTemperatureOID != "" {
+			if temperature, err := u.getTransceiverReading(ctx, oltConfig.OnuTemperatureOID, strconv.Itoa(onuInfo.ID), utils.ConvertOnuTemperature); err == nil {
+				onuInfo.Temperature = temperature
+			}
+		}
+		if oltConfig.OnuVoltageOID != "" {
+			if voltage, err := u.getTransceiverReading(ctx, oltConfig.OnuVoltageOID, strconv.Itoa(onuInfo.ID), utils.ConvertOnuVoltage); err == nil {
+				onuInfo.Voltage = voltage
 			}
+		}
+		if oltConfig.OnuBiasCurrentOID != "" {
+			if bias, err := u.getTransceiverReading(ctx, oltConfig.OnuBiasCurrentOID, strconv.Itoa(onuInfo.ID), utils.ConvertOnuBiasCurrent); err == nil {
+				onuInfo.BiasCurrent = bias
+			}
+		}
 
-			// Get Data ONU RX Power from SNMP Walk using getRxPower method
-			if rx, err := u.getRxPower(oltConfig.OnuRxPowerOID, strconv.Itoa(onuInfo.ID)); err == nil {
-				onuInfo.RXPower = rx
+		// Get Data ONU traffic counters only when their OIDs are configured
+		if oltConfig.OnuDownstreamOctetsOID != "" {
+			if octets, err := u.getCounter(ctx, oltConfig.OnuDownstreamOctetsOID, strconv.Itoa(onuInfo.ID)); err == nil {
+				onuInfo.DownstreamOctets = octets
 			}
+		}
+		if oltConfig.OnuUpstreamOctetsOID != "" {
+			if octets, err := u.getCounter(ctx, oltConfig.OnuUpstreamOctetsOID, strconv.Itoa(onuInfo.ID)); err == nil {
+				onuInfo.UpstreamOctets = octets
+			}
+		}
 
-			// Get Data ONU TX Power from SNMP Walk using getTxPower method
-			if tx, err := u.getTxPower(oltConfig.OnuTxPowerOID, strconv.Itoa(onuInfo.ID)); err == nil {
-				onuInfo.TXPower = tx
+		// Get Data ONU dropped frame counters only when their OIDs are configured
+		if oltConfig.OnuRxDroppedOID != "" {
+			if dropped, err := u.getCounter(ctx, oltConfig.OnuRxDroppedOID, strconv.Itoa(onuInfo.ID)); err == nil {
+				onuInfo.RxDropped = dropped
+			}
+		}
+		if oltConfig.OnuTxDroppedOID != "" {
+			if dropped, err := u.getCounter(ctx, oltConfig.OnuTxDroppedOID, strconv.Itoa(onuInfo.ID)); err == nil {
+				onuInfo.TxDropped = dropped
 			}
+		}
 
-			// Get Data ONU Status from SNMP Walk using getStatus method
-			if status, err := u.getStatus(oltConfig.OnuStatusOID, strconv.Itoa(onuInfo.ID)); err == nil {
-				onuInfo.Status = status
+		// Get Data ONU MAC address only when its OID is configured
+		if oltConfig.OnuMACAddressOID != "" {
+			if macAddress, err := u.getMACAddress(ctx, oltConfig.OnuMACAddressOID, strconv.Itoa(onuInfo.ID)); err == nil {
+				onuInfo.MACAddress = macAddress
 			}
+		}
 
-			// Get Data ONU IP Address from SNMP Walk using getIPAddress method
-			if ip, err := u.getIPAddress(oltConfig.OnuIPAddressOID, strconv.Itoa(onuInfo.ID)); err == nil {
-				onuInfo.IPAddress = ip
+		// Get Data ONU equipment ID (ESN) only when its OID is configured
+		if oltConfig.OnuEquipmentIDOID != "" {
+			if equipmentID, err := u.getEquipmentID(ctx, oltConfig.OnuEquipmentIDOID, strconv.Itoa(onuInfo.ID)); err == nil {
+				onuInfo.EquipmentID = equipmentID
 			}
+		}
 
-			// Get Data ONU Description from SNMP Walk using getDescription method
-			if desc, err := u.getDescription(oltConfig.OnuDescriptionOID, strconv.Itoa(onuInfo.ID)); err == nil {
-				onuInfo.Description = desc
+		// Get Data ONU transceiver type only when its OID is configured
+		if oltConfig.OnuTransceiverTypeOID != "" {
+			if transceiverType, err := u.getTransceiverType(ctx, oltConfig.OnuTransceiverTypeOID, strconv.Itoa(onuInfo.ID)); err == nil {
+				onuInfo.TransceiverType = transceiverType
 			}
+		}
 
-			// Get Data ONU Last Online from SNMP Walk using getLastOnline method
-			if lastOnline, err := u.getLastOnline(oltConfig.OnuLastOnlineOID, strconv.Itoa(onuInfo.ID)); err == nil {
-				onuInfo.LastOnline = lastOnline
+		// Get Data ONU profile template only when its OID is configured
+		if oltConfig.OnuProfileTemplateOID != "" {
+			if template, err := u.getProfileTemplate(ctx, oltConfig.OnuProfileTemplateOID, strconv.Itoa(onuInfo.ID)); err == nil {
+				onuInfo.ProfileTemplate = template
 			}
+		}
 
-			// Get Data ONU Last Offline from SNMP Walk using getLastOffline method
-			if lastOffline, err := u.getLastOffline(oltConfig.OnuLastOfflineOID, strconv.Itoa(onuInfo.ID)); err == nil {
-				onuInfo.LastOffline = lastOffline
+		// Get Data ONU QoS profile only when enabled, as it costs one more request per ONU
+		if u.cfg.OltCfg.CollectQosProfile && oltConfig.OnuQosProfileOID != "" {
+			if qosProfile, err := u.getQosProfile(ctx, oltConfig.OnuQosProfileOID, strconv.Itoa(onuInfo.ID)); err == nil {
+				onuInfo.QosProfile = qosProfile
 			}
+		}
 
-			// Get Data ONU Last Offline Reason from SNMP Walk using getLastOfflineReason method
-			if uptime, err := u.getUptimeDuration(onuInfo.LastOnline); err == nil {
-				onuInfo.Uptime = uptime
+		// Get Data ONU multicast state only when enabled, as it costs two more requests per ONU
+		if u.cfg.OltCfg.CollectMulticast && oltConfig.OnuMulticastEnabledOID != "" {
+			if enabled, err := u.getMulticastEnabled(ctx, oltConfig.OnuMulticastEnabledOID, strconv.Itoa(onuInfo.ID)); err == nil {
+				onuInfo.MulticastEnabled = enabled
 			}
+		}
+		if u.cfg.OltCfg.CollectMulticast && oltConfig.OnuMulticastGroupsOID != "" {
+			if groups, err := u.getMulticastGroups(ctx, oltConfig.OnuMulticastGroupsOID, strconv.Itoa(onuInfo.ID)); err == nil {
+				onuInfo.MulticastGroups = groups
+			}
+		}
 
-			// Get Data ONU Last Downtime Duration from SNMP Walk using getLastDownDuration method
-			if downtime, err := u.getLastDownDuration(onuInfo.LastOffline, onuInfo.LastOnline); err == nil {
-				onuInfo.LastDownTimeDuration = downtime
+		// Get Data ONU CATV port state only when enabled, as it costs two more requests per ONU
+		if u.cfg.OltCfg.CollectCatv && oltConfig.OnuCatvStatusOID != "" {
+			if enabled, err := u.getCatvStatus(ctx, oltConfig.OnuCatvStatusOID, strconv.Itoa(onuInfo.ID)); err == nil {
+				onuInfo.CatvEnabled = enabled
+			}
+		}
+		if u.cfg.OltCfg.CollectCatv && oltConfig.OnuCatvLevelOID != "" {
+			if level, err := u.getCatvLevel(ctx, oltConfig.OnuCatvLevelOID, strconv.Itoa(onuInfo.ID)); err == nil {
+				onuInfo.CatvLevel = level
 			}
+		}
 
-			// Get Data ONU Last Offline Reason from SNMP Walk using getLastOfflineReason method
-			if reason, err := u.getLastOfflineReason(oltConfig.OnuLastOfflineReasonOID, strconv.Itoa(onuInfo.ID)); err == nil {
-				onuInfo.LastOfflineReason = reason
+		// Get Data ONU T-CONT allocation only when enabled, as it costs one more request per ONU
+		if u.cfg.OltCfg.CollectTcont && oltConfig.OnuTcontAllocationOID != "" {
+			if tconts, err := u.getTcontAllocation(ctx, oltConfig.OnuTcontAllocationOID, strconv.Itoa(onuInfo.ID)); err == nil {
+				onuInfo.Tconts = tconts
 			}
+		}
 
-			// Get Data ONU GPON Optical Distance from SNMP Walk using getOnuGponOpticalDistance method
-			if dist, err := u.getOnuGponOpticalDistance(oltConfig.OnuGponOpticalDistanceOID, strconv.Itoa(onuInfo.ID)); err == nil {
-				onuInfo.GponOpticalDistance = dist
+		// Get Data ONU Ethernet port links only when enabled, as it costs one or two walks per ONU
+		if u.cfg.OltCfg.CollectUniPorts && oltConfig.OnuUniSpeedOID != "" {
+			if uniPorts, err := u.getUniPorts(ctx, oltConfig.OnuUniSpeedOID, oltConfig.OnuUniDuplexOID, strconv.Itoa(onuInfo.ID)); err == nil {
+				onuInfo.UniPorts = uniPorts
 			}
+		}
 
-			onuInformationList = onuInfo // Append ONU information to the onuInformationList
+		// Get Data ONU negotiated line rates only when their OIDs are configured
+		if oltConfig.OnuNegotiatedDownstreamRateOID != "" {
+			if rate, err := u.getLineRate(ctx, oltConfig.OnuNegotiatedDownstreamRateOID, strconv.Itoa(onuInfo.ID)); err == nil {
+				onuInfo.NegotiatedDownstreamRate = rate
+			}
+		}
+		if oltConfig.OnuNegotiatedUpstreamRateOID != "" {
+			if rate, err := u.getLineRate(ctx, oltConfig.OnuNegotiatedUpstreamRateOID, strconv.Itoa(onuInfo.ID)); err == nil {
+				onuInfo.NegotiatedUpstreamRate = rate
+			}
 		}
 
-		return onuInformationList, nil // Return the ONU information list
-	})
+		// Get Data ONU alarm count recorded by the OLT only when its OID is configured
+		if oltConfig.OnuAlarmCountOID != "" {
+			if count, err := u.getAlarmCount(ctx, oltConfig.OnuAlarmCountOID, strconv.Itoa(onuInfo.ID)); err == nil {
+				onuInfo.AlarmCount = count
+			}
+		}
 
-	if err != nil {
-		return model.ONUCustomerInfo{}, err
+		// Get Data ONU loop detection alarm only when its OID is configured
+		if oltConfig.OnuLoopDetectedOID != "" {
+			if loopDetected, err := u.getLoopDetected(ctx, oltConfig.OnuLoopDetectedOID, strconv.Itoa(onuInfo.ID)); err == nil {
+				onuInfo.LoopDetected = loopDetected
+			}
+		}
+
+		// Get Data ONU downstream encryption state only when its OID is configured
+		if oltConfig.OnuEncryptionOID != "" {
+			if encryptionEnabled, err := u.getEncryptionEnabled(ctx, oltConfig.OnuEncryptionOID, strconv.Itoa(onuInfo.ID)); err == nil {
+				onuInfo.EncryptionEnabled = encryptionEnabled
+			}
+		}
+
+		// Get Data ONU software upgrade state only when its OID is configured
+		if oltConfig.OnuUpgradeStateOID != "" {
+			if upgradeState, err := u.getUpgradeState(ctx, oltConfig.OnuUpgradeStateOID, strconv.Itoa(onuInfo.ID)); err == nil {
+				onuInfo.UpgradeState = upgradeState
+			}
+		}
+
+		// Get Data ONU backup battery state only when its OID is configured
+		if oltConfig.OnuBatteryStatusOID != "" {
+			if batteryStatus, err := u.getBatteryStatus(ctx, oltConfig.OnuBatteryStatusOID, strconv.Itoa(onuInfo.ID)); err == nil {
+				onuInfo.BatteryStatus = batteryStatus
+			}
+		}
+
+		// Get Data ONU last registration failure only when its OID is configured
+		if oltConfig.OnuRegistrationFailReasonOID != "" {
+			if code, reason, err := u.getRegistrationFailReason(ctx, oltConfig.OnuRegistrationFailReasonOID, strconv.Itoa(onuInfo.ID)); err == nil {
+				onuInfo.RegistrationFailCode = strconv.Itoa(code)
+				onuInfo.RegistrationFailReason = reason
+			}
+		}
+
+		// Get Data ONU first registration time only when its OID is configured
+		if oltConfig.OnuRegisteredTimeOID != "" {
+			if registeredAt, err := u.getRegisteredTime(ctx, oltConfig.OnuRegisteredTimeOID, strconv.Itoa(onuInfo.ID)); err == nil {
+				onuInfo.RegisteredAt = registeredAt
+			}
+		}
+
+		onuInformationList = onuInfo // Append ONU information to the onuInformationList
 	}
 
-	return result.(model.ONUCustomerInfo), nil // Return the result from the cache or SNMP Walk
+	return onuInformationList, nil // Return the ONU information list
 }
 
-func (u *onuUsecase) GetEmptyOnuID(ctx context.Context, boardID, ponID int) ([]model.OnuID, error) {
+// GetAllDetailsByBoardPon returns the details of every ONU of the given PON, sorted by ONU ID, like
+// GetByBoardIDPonIDAndOnuID returns those of a single ONU. Every attribute OID is walked once for the
+// whole PON instead of read ONU by ONU, which takes far fewer requests on a populated PON.
+func (u *onuUsecase) GetAllDetailsByBoardPon(ctx context.Context, boardID, ponID int) ([]model.ONUCustomerInfo, error) {
 	// Set key for simple flight
-	key := fmt.Sprintf("empty_onu_id:%d:%d", boardID, ponID)
+	key := fmt.Sprintf("onu_details:%d:%d", boardID, ponID)
 
-	// Using simple flight to prevent duplicate requests for the same data
+	// Using simple flight to prevent duplicate SNMP requests
 	result, err, _ := u.sg.Do(key, func() (interface{}, error) {
-		// Get OLT config based on Board ID and PON ID
-		oltConfig, err := u.getOltConfig(boardID, ponID)
+		oltConfig, err := u.getOltConfig(boardID, ponID) // Get OLT config based on Board ID and PON ID
 		if err != nil {
-			log.Error().Msg("Failed to get OLT Config for Get Empty ONU ID: " + err.Error())
+			log.Error().Msg("Failed to get OLT Config: " + err.Error())
 			return nil, err
 		}
 
-
-		// Perform SNMP Walk to get ONU ID and ONU Name
-		snmpOID := oltConfig.BaseOID + oltConfig.OnuIDNameOID
-		emptyOnuIDList := make([]model.OnuID, 0)
-
-		log.Info().Msg("Get Empty ONU ID with SNMP Walk from Board ID: " + strconv.Itoa(boardID) + " and PON ID: " + strconv.Itoa(ponID))
-
-		// Perform SNMP Walk to get ONU ID and Name
-		err = u.snmpRepository.Walk(snmpOID, func(pdu gosnmp.SnmpPDU) error {
-			idOnuID := utils.ExtractIDOnuID(pdu.Name)
-			emptyOnuIDList = append(emptyOnuIDList, model.OnuID{
-				Board: boardID,
-				PON:   ponID,
-				ID:    idOnuID,
-			})
+		log.Info().Msg("Get Detail ONU Information with SNMP Walk from Board ID: " +
+			strconv.Itoa(boardID) + " PON ID: " + strconv.Itoa(ponID))
+
+		// The ONUs are read attribute by attribute like a single ONU, from columns walked once. A walk
+		// reads a whole column, so it is bounded by the SNMP timeout rather than the attribute timeout.
+		columns := newColumnRepository(u.snmpRepository, slices.Collect(maps.Values(ponOIDs(u.cfg, *oltConfig))))
+		cfg := *u.cfg
+		cfg.OltCfg.AttributeTimeout = 0
+		reader := &onuUsecase{snmpRepository: columns, cfg: &cfg}
+
+		var onuIDs []int
+		err = columns.Walk(ctx, oltConfig.BaseOID+oltConfig.OnuIDNameOID, func(pdu gosnmp.SnmpPDU) error {
+			onuIDs = append(onuIDs, utils.ExtractIDOnuID(pdu.Name))
 			return nil
 		})
 		if err != nil {
-			log.Error().Msg("Failed to perform SNMP Walk get empty ONU ID: " + err.Error())
-			return nil, err
+			log.Error().Msg("Failed to walk OID: " + err.Error())
+			return nil, errors.New("failed to walk OID")
 		}
+		slices.Sort(onuIDs)
 
-		// Create a map to store numbers to be deleted
-		numbersToRemove := make(map[int]bool)
-
-		for _, onuInfo := range emptyOnuIDList {
-			numbersToRemove[onuInfo.ID] = true
+		onuInformationList := make([]model.ONUCustomerInfo, 0, len(onuIDs))
+		for _, onuID := range onuIDs {
+			onuInfo, err := reader.getOnuDetails(ctx, oltConfig, boardID, ponID, onuID)
+			if err != nil {
+				return nil, err
+			}
+			onuInformationList = append(onuInformationList, onuInfo)
 		}
 
-		// Remove the numbers that should not be added to the emptyOnuIDList
-		emptyOnuIDList = emptyOnuIDList[:0]
+		return onuInformationList, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
 
-		// Loop through 128 numbers to get the numbers to be deleted
-		for i := 1; i <= 128; i++ {
-			if _, ok := numbersToRemove[i]; !ok {
-				emptyOnuIDList = append(emptyOnuIDList, model.OnuID{
-					Board: boardID,
-					PON:   ponID,
-					ID:    i,
-				})
+	return result.([]model.ONUCustomerInfo), nil
+}
+
+// GetEmptyOnuID returns the free ONU IDs of a PON. With the cache enabled they are served from it
+// while cached, and cached for the next requests otherwise.
+func (u *onuUsecase) GetEmptyOnuID(ctx context.Context, boardID, ponID int) ([]model.OnuID, error) {
+	// Set key for simple flight
+	key := fmt.Sprintf("empty_onu_id:%d:%d", boardID, ponID)
+
+	// Using simple flight to prevent duplicate requests for the same data
+	result, err, _ := u.sg.Do(key, func() (interface{}, error) {
+		if u.cacheRepository != nil {
+			emptyOnuIDList, err := u.cacheRepository.GetEmptyOnuIDList(boardID, ponID)
+			if err == nil {
+				return emptyOnuIDList, nil
+			}
+			if !errors.Is(err, repository.ErrCacheMiss) {
+				log.Warn().Err(err).Int("board", boardID).Int("pon", ponID).Msg("Failed to read the cached empty ONU IDs")
 			}
 		}
 
-		// Sort by ID ascending
-		sort.Slice(emptyOnuIDList, func(i, j int) bool {
-			return emptyOnuIDList[i].ID < emptyOnuIDList[j].ID
-		})
+		emptyOnuIDList, err := u.readEmptyOnuIDs(ctx, boardID, ponID)
+		if err != nil {
+			return nil, err
+		}
+
+		if u.cacheRepository != nil {
+			if err := u.cacheRepository.SetEmptyOnuIDList(boardID, ponID, emptyOnuIDList, u.cfg.RedisCfg.TTL); err != nil {
+				log.Warn().Err(err).Int("board", boardID).Int("pon", ponID).Msg("Failed to cache the empty ONU IDs")
+			}
+		}
 
 		return emptyOnuIDList, nil
 	})
@@ -875,7 +636,53 @@ func (u *onuUsecase) GetEmptyOnuID(ctx context.Context, boardID, ponID int) ([]m
 	return result.([]model.OnuID), nil
 }
 
+// readEmptyOnuIDs reads the free ONU IDs of a PON from the OLT, in ascending order: the IDs from 1 to
+// the maximum number of ONUs per PON that the walk of the ONU names did not return.
+func (u *onuUsecase) readEmptyOnuIDs(ctx context.Context, boardID, ponID int) ([]model.OnuID, error) {
+	// Get OLT config based on Board ID and PON ID
+	oltConfig, err := u.getOltConfig(boardID, ponID)
+	if err != nil {
+		log.Error().Msg("Failed to get OLT Config for Get Empty ONU ID: " + err.Error())
+		return nil, err
+	}
+
+	// Perform SNMP Walk to get ONU ID and ONU Name
+	snmpOID := oltConfig.BaseOID + oltConfig.OnuIDNameOID
+
+	log.Info().Msg("Get Empty ONU ID with SNMP Walk from Board ID: " + strconv.Itoa(boardID) + " and PON ID: " + strconv.Itoa(ponID))
+
+	// Create a map to store numbers to be deleted
+	numbersToRemove := make(map[int]bool)
+
+	// Perform SNMP Walk to get ONU ID and Name
+	err = u.snmpRepository.Walk(ctx, snmpOID, func(pdu gosnmp.SnmpPDU) error {
+		numbersToRemove[utils.ExtractIDOnuID(pdu.Name)] = true
+		return nil
+	})
+	if err != nil {
+		log.Error().Msg("Failed to perform SNMP Walk get empty ONU ID: " + err.Error())
+		return nil, err
+	}
+
+	// Loop through every ONU ID of the PON in ascending order, keeping the ones not in use
+	emptyOnuIDList := make([]model.OnuID, 0)
+	for i := 1; i <= u.maxOnuPerPon(); i++ {
+		if _, ok := numbersToRemove[i]; !ok {
+			emptyOnuIDList = append(emptyOnuIDList, model.OnuID{
+				Board: boardID,
+				PON:   ponID,
+				ID:    i,
+			})
+		}
+	}
+
+	return emptyOnuIDList, nil
+}
+
 func (u *onuUsecase) GetOnuIDAndSerialNumber(boardID, ponID int) ([]model.OnuSerialNumber, error) {
+	// The API requests are only bounded by the SNMP timeout
+	ctx := context.Background()
+
 	// Set key for simple flight
 	key := fmt.Sprintf("onu_id_and_serial_number:%d:%d", boardID, ponID)
 
@@ -895,7 +702,7 @@ func (u *onuUsecase) GetOnuIDAndSerialNumber(boardID, ponID int) ([]model.OnuSer
 		log.Info().Msg("Get ONU ID with SNMP Walk from Board ID: " + strconv.Itoa(boardID) + " and PON ID: " + strconv.Itoa(ponID))
 
 		// Perform SNMP BulkWalk to get ONU ID and Name
-		err = u.snmpRepository.Walk(snmpOID, func(pdu gosnmp.SnmpPDU) error {
+		err = u.snmpRepository.Walk(ctx, snmpOID, func(pdu gosnmp.SnmpPDU) error {
 			idOnuID := utils.ExtractIDOnuID(pdu.Name)
 			onuIDList = append(onuIDList, model.OnuID{
 				Board: boardID,
@@ -909,22 +716,8 @@ func (u *onuUsecase) GetOnuIDAndSerialNumber(boardID, ponID int) ([]model.OnuSer
 			return nil, err
 		}
 
-		// Create a slice of ONU Serial Number
-		var onuSerialNumberList []model.OnuSerialNumber
-
-		// Loop through onuIDList to get ONU Serial Number
-		for _, onuInfo := range onuIDList {
-			// Get Data ONU Serial Number from SNMP Walk using getSerialNumber method
-			onuSerialNumber, err := u.getSerialNumber(oltConfig.OnuSerialNumberOID, strconv.Itoa(onuInfo.ID))
-			if err == nil {
-				onuSerialNumberList = append(onuSerialNumberList, model.OnuSerialNumber{
-					Board:        boardID,
-					PON:          ponID,
-					ID:           onuInfo.ID,
-					SerialNumber: onuSerialNumber,
-				})
-			}
-		}
+		// Read the serial number of every ONU, bounded by the configured concurrency
+		onuSerialNumberList := u.getSerialNumbers(ctx, oltConfig.OnuSerialNumberOID, boardID, ponID, onuIDList)
 
 		// Sort ONU Serial Number list based on ONU ID ascending
 		sort.Slice(onuSerialNumberList, func(i, j int) bool {
@@ -942,62 +735,85 @@ func (u *onuUsecase) GetOnuIDAndSerialNumber(boardID, ponID int) ([]model.OnuSer
 	return result.([]model.OnuSerialNumber), nil
 }
 
-func (u *onuUsecase) UpdateEmptyOnuID(ctx context.Context, boardID, ponID int) error {
-	// Set key for simple flight
-	key := fmt.Sprintf("update_empty_onu_id:%d:%d", boardID, ponID)
+// MaxOnuPerPon returns the number of ONU IDs a PON is provisioned for
+func (u *onuUsecase) MaxOnuPerPon() int {
+	return u.maxOnuPerPon()
+}
 
-	// Using simple flight to prevent duplicate requests for the same data
-	_, err, _ := u.sg.Do(key, func() (interface{}, error) {
-		// Get OLT config based on Board ID and PON ID
-		oltConfig, err := u.getOltConfig(boardID, ponID)
-		if err != nil {
-			log.Error().Msg("Failed to get OLT Config: " + err.Error())
-			return nil, err
-		}
+// maxOnuPerPon returns the number of ONU IDs a PON is provisioned for, the default of LoadConfig
+// when unset
+func (u *onuUsecase) maxOnuPerPon() int {
+	if u.cfg.OltCfg.MaxOnuPerPon < 1 {
+		return config.DefaultMaxOnuPerPon
+	}
+	return u.cfg.OltCfg.MaxOnuPerPon
+}
 
-		// Perform SNMP Walk to get ONU ID and ONU Name
-		snmpOID := oltConfig.BaseOID + oltConfig.OnuIDNameOID
-		emptyOnuIDList := make([]model.OnuID, 0)
+// getSerialNumbers reads the serial numbers of the given ONUs concurrently. ONUs whose serial number
+// cannot be read are logged and left out, the order of the result is unspecified.
+func (u *onuUsecase) getSerialNumbers(ctx context.Context, OnuSerialNumberOID string, boardID, ponID int, onuIDList []model.OnuID) []model.OnuSerialNumber {
+	concurrency := u.cfg.OltCfg.SerialNumberConcurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
 
-		log.Info().Msg("Get Empty ONU ID with SNMP Walk from Board ID: " + strconv.Itoa(boardID) + " and PON ID: " + strconv.Itoa(ponID))
+	var (
+		mu                  sync.Mutex
+		wg                  sync.WaitGroup
+		onuSerialNumberList []model.OnuSerialNumber
+	)
+	semaphore := make(chan struct{}, concurrency)
 
-		// Perform SNMP BulkWalk to get ONU ID and Name
-		err = u.snmpRepository.Walk(snmpOID, func(pdu gosnmp.SnmpPDU) error {
-			idOnuID := utils.ExtractIDOnuID(pdu.Name)
-			emptyOnuIDList = append(emptyOnuIDList, model.OnuID{
-				Board: boardID,
-				PON:   ponID,
-				ID:    idOnuID,
+	for _, onuInfo := range onuIDList {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(onuID int) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			// Get Data ONU Serial Number from SNMP Get using getSerialNumber method
+			onuSerialNumber, err := u.getSerialNumber(ctx, OnuSerialNumberOID, strconv.Itoa(onuID))
+			if err != nil {
+				log.Warn().Err(err).Int("board", boardID).Int("pon", ponID).Int("onu_id", onuID).Msg("Failed to get ONU serial number, leaving the ONU out")
+				return
+			}
+
+			mu.Lock()
+			onuSerialNumberList = append(onuSerialNumberList, model.OnuSerialNumber{
+				Board:        boardID,
+				PON:          ponID,
+				ID:           onuID,
+				SerialNumber: onuSerialNumber,
 			})
-			return nil
-		})
-		if err != nil {
-			return nil, errors.New("failed to perform SNMP Walk")
-		}
+			mu.Unlock()
+		}(onuInfo.ID)
+	}
+	wg.Wait()
+
+	return onuSerialNumberList
+}
+
+// UpdateEmptyOnuID reads the free ONU IDs of a PON from the OLT and stores them in the cache, where
+// GetEmptyOnuID serves them from. Without the cache GetEmptyOnuID always reads the OLT, so there is
+// nothing to update and the OLT is not queried.
+func (u *onuUsecase) UpdateEmptyOnuID(ctx context.Context, boardID, ponID int) error {
+	if u.cacheRepository == nil {
+		return nil
+	}
+
+	// Set key for simple flight
+	key := fmt.Sprintf("update_empty_onu_id:%d:%d", boardID, ponID)
 
-		// Create a map to store numbers to be deleted
-		numbersToRemove := make(map[int]bool)
-		for _, onuInfo := range emptyOnuIDList {
-			numbersToRemove[onuInfo.ID] = true
+	// Using simple flight to prevent duplicate requests for the same data
+	_, err, _ := u.sg.Do(key, func() (interface{}, error) {
+		emptyOnuIDList, err := u.readEmptyOnuIDs(ctx, boardID, ponID)
+		if err != nil {
+			return nil, err
 		}
 
-		// Filter out ONU IDs that are not empty
-		emptyOnuIDList = emptyOnuIDList[:0]
-		for i := 1; i <= 128; i++ {
-			if _, ok := numbersToRemove[i]; !ok {
-				emptyOnuIDList = append(emptyOnuIDList, model.OnuID{
-					Board: boardID,
-					PON:   ponID,
-					ID:    i,
-				})
-			}
+		if err := u.cacheRepository.SetEmptyOnuIDList(boardID, ponID, emptyOnuIDList, u.cfg.RedisCfg.TTL); err != nil {
+			return nil, fmt.Errorf("cache the empty ONU IDs: %w", err)
 		}
-
-		// Sort ONU IDs by ID ascending
-		sort.Slice(emptyOnuIDList, func(i, j int) bool {
-			return emptyOnuIDList[i].ID < emptyOnuIDList[j].ID
-		})
-
 		return nil, nil
 	})
 
@@ -1007,6 +823,14 @@ func (u *onuUsecase) UpdateEmptyOnuID(ctx context.Context, boardID, ponID int) e
 func (u *onuUsecase) GetByBoardIDAndPonIDWithPagination(
 	boardID, ponID, pageIndex, pageSize int,
 ) ([]model.ONUInfoPerBoard, int) {
+	// The API requests are only bounded by the SNMP timeout
+	ctx := context.Background()
+
+	// Never fetch more than one page worth of ONU details, whatever the caller asked for
+	pageSize = pagination.ClampPageSize(pageSize)
+	if pageIndex < 1 {
+		pageIndex = 1
+	}
 
 	// Create a unique key for this request based on the parameters
 	key := fmt.Sprintf("get_onu_info:%d:%d:%d:%d", boardID, ponID, pageIndex, pageSize)
@@ -1019,84 +843,77 @@ func (u *onuUsecase) GetByBoardIDAndPonIDWithPagination(
 			return nil, err
 		}
 
+		// Serve the page from the cached ONU list of the PON when the cache is enabled
+		if u.cacheRepository != nil {
+			onuList, err := u.getCachedOnuList(ctx, boardID, ponID)
+			if err != nil {
+				return nil, err
+			}
+			startIndex, endIndex := pageBounds(len(onuList), pageIndex, pageSize)
+			return model.PaginationResult{
+				OnuInformationList: onuList[startIndex:endIndex],
+				Count:              len(onuList),
+			}, nil
+		}
+
 		// SNMP OID variable
 		snmpOID := oltConfig.BaseOID + oltConfig.OnuIDNameOID
 
+		// Walk the ONU IDs of the PON, only the ONUs of the requested page are read in detail
 		var onlyOnuIDList []model.OnuOnlyID
-		var count int
-
-		// If data does not exist in Redis, then get data from SNMP
-		if len(onlyOnuIDList) == 0 {
-			err := u.snmpRepository.Walk(snmpOID, func(pdu gosnmp.SnmpPDU) error {
-				onlyOnuIDList = append(onlyOnuIDList, model.OnuOnlyID{
-					ID: utils.ExtractIDOnuID(pdu.Name),
-				})
-				return nil
+		err = u.snmpRepository.Walk(ctx, snmpOID, func(pdu gosnmp.SnmpPDU) error {
+			onlyOnuIDList = append(onlyOnuIDList, model.OnuOnlyID{
+				ID: utils.ExtractIDOnuID(pdu.Name),
 			})
-
-			if err != nil {
-				return nil, err
-			}
-		} else {
-			// Optionally, handle Redis case here
-			log.Error().Msg("Failed to get data from Redis")
+			return nil
+		})
+		if err != nil {
+			return nil, err
 		}
 
 		// Calculate total count
-		count = len(onlyOnuIDList)
-
-		// Calculate the index of the first item to be retrieved
-		startIndex := (pageIndex - 1) * pageSize
-
-		// Calculate the index of the last item to be retrieved
-		endIndex := startIndex + pageSize
-
-		// If the index of the last item to be retrieved is greater than the number of items, set it to the number of items
-		if endIndex > len(onlyOnuIDList) {
-			endIndex = len(onlyOnuIDList)
-		}
+		count := len(onlyOnuIDList)
 
 		// Slice the data for pagination
+		startIndex, endIndex := pageBounds(count, pageIndex, pageSize)
 		onlyOnuIDList = onlyOnuIDList[startIndex:endIndex]
 
+		// Read the attributes of every ONU of the page together, in a few Gets instead of five per ONU
+		pageOIDs := make([]pageOnuOIDs, len(onlyOnuIDList))
+		var oids []string
+		for i, onuID := range onlyOnuIDList {
+			pageOIDs[i] = u.pageOnuOIDs(oltConfig, strconv.Itoa(onuID.ID))
+			oids = append(oids, pageOIDs[i].name, pageOIDs[i].onuType, pageOIDs[i].serialNumber, pageOIDs[i].rxPower, pageOIDs[i].status)
+		}
+		values := u.getBatch(ctx, oids)
+
 		var onuInformationList []model.ONUInfoPerBoard
 
 		// Loop through onlyOnuIDList to get ONU information based on ONU ID
-		for _, onuID := range onlyOnuIDList {
+		for i, onuID := range onlyOnuIDList {
 			onuInfo := model.ONUInfoPerBoard{
 				Board: boardID,  // Set Board ID to ONUInfo struct Board field
 				PON:   ponID,    // Set PON ID to ONUInfo struct PON field
 				ID:    onuID.ID, // Set ONU ID to ONUInfo struct ID field
 			}
 
-			// Get Name based on ONU ID and ONU Name OID and store it to ONU onuInfo struct
-			onuName, err := u.getName(oltConfig.OnuIDNameOID, strconv.Itoa(onuInfo.ID))
-			if err == nil {
-				onuInfo.Name = onuName // Set ONU Name to ONU onuInfo struct Name field
+			// Set the attributes the OLT answered, the others stay empty
+			if pdu, ok := values[pageOIDs[i].name]; ok {
+				onuInfo.Name = utils.ExtractName(pdu.Value)
 			}
-
-			// Get ONU Type based on ONU ID and ONU Type OID and store it to ONU onuInfo struct
-			onuType, err := u.getONUType(oltConfig.OnuTypeOID, strconv.Itoa(onuInfo.ID))
-			if err == nil {
-				onuInfo.OnuType = onuType // Set ONU Type to ONU onuInfo struct OnuType field
+			if pdu, ok := values[pageOIDs[i].onuType]; ok {
+				onuInfo.OnuType = utils.ExtractName(pdu.Value)
 			}
-
-			// Get ONU Serial Number based on ONU ID and ONU Serial Number OID and store it to ONU onuInfo struct
-			onuSerialNumber, err := u.getSerialNumber(oltConfig.OnuSerialNumberOID, strconv.Itoa(onuInfo.ID))
-			if err == nil {
-				onuInfo.SerialNumber = onuSerialNumber // Set ONU Serial Number to ONU onuInfo struct SerialNumber field
+			if pdu, ok := values[pageOIDs[i].serialNumber]; ok {
+				onuInfo.SerialNumber = utils.ExtractSerialNumber(pdu.Value)
 			}
-
-			// Get ONU RX Power based on ONU ID and ONU RX Power OID and store it to ONU onuInfo struct
-			onuRXPower, err := u.getRxPower(oltConfig.OnuRxPowerOID, strconv.Itoa(onuInfo.ID))
-			if err == nil {
-				onuInfo.RXPower = onuRXPower // Set ONU RX Power to ONU onuInfo struct RXPower field
+			if pdu, ok := values[pageOIDs[i].rxPower]; ok {
+				if rxPower, err := utils.ConvertAndMultiply(pdu.Value); err == nil {
+					onuInfo.RXPower = rxPower
+				}
 			}
-
-			// Get ONU Status based on ONU ID and ONU Status OID and store it to ONU onuInfo struct
-			onuStatus, err := u.getStatus(oltConfig.OnuStatusOID, strconv.Itoa(onuInfo.ID))
-			if err == nil {
-				onuInfo.Status = onuStatus // Set ONU Status to ONU onuInfo struct Status field
+			if pdu, ok := values[pageOIDs[i].status]; ok {
+				onuInfo.Status = utils.ExtractAndGetStatus(pdu.Value)
 			}
 
 			// Append ONU information to the onuInformationList
@@ -1126,117 +943,211 @@ func (u *onuUsecase) GetByBoardIDAndPonIDWithPagination(
 
 }
 
-func (u *onuUsecase) getName(OnuIDNameOID, onuID string) (string, error) {
+// pageOnuOIDs are the OIDs of the attributes of an ONU listed by the paginated API
+type pageOnuOIDs struct {
+	name, onuType, serialNumber, rxPower, status string
+}
+
+// pageOnuOIDs returns the OIDs of the attributes of an ONU listed by the paginated API, the OIDs
+// read by getName, getONUType, getSerialNumber, getRxPower and getStatus
+func (u *onuUsecase) pageOnuOIDs(oltConfig *model.OltConfig, onuID string) pageOnuOIDs {
+	return pageOnuOIDs{
+		name:         u.cfg.OltCfg.BaseOID1 + oltConfig.OnuIDNameOID + "." + onuID,
+		onuType:      u.cfg.OltCfg.BaseOID2 + oltConfig.OnuTypeOID + "." + onuID,
+		serialNumber: u.cfg.OltCfg.BaseOID1 + oltConfig.OnuSerialNumberOID + "." + onuID,
+		rxPower:      u.cfg.OltCfg.BaseOID1 + oltConfig.OnuRxPowerOID + "." + onuID + ".1",
+		status:       u.cfg.OltCfg.BaseOID1 + oltConfig.OnuStatusOID + "." + onuID,
+	}
+}
+
+// getBatch reads the given OIDs with as few Gets as possible, gosnmp.MaxOids OIDs each, and returns
+// the variables by requested OID. OIDs unknown to the OLT are left out. The OIDs of a Get that
+// fails, or that the OLT left out of its response, are read one at a time instead.
+func (u *onuUsecase) getBatch(ctx context.Context, oids []string) map[string]gosnmp.SnmpPDU {
+	values := make(map[string]gosnmp.SnmpPDU, len(oids))
+	for batch := range slices.Chunk(oids, gosnmp.MaxOids) {
+		packet, err := u.snmpRepository.Get(ctx, batch)
+		if err != nil {
+			log.Warn().Err(err).Int("oids", len(batch)).Msg("Failed to perform batched SNMP Get, reading the OIDs one at a time")
+		}
+
+		// Some firmware return the variables in a different order than requested, or with a
+		// trailing dot appended to their name, so the variables are matched by normalized OID
+		answered := make(map[string]gosnmp.SnmpPDU)
+		if err == nil && packet != nil {
+			for _, variable := range packet.Variables {
+				answered[utils.NormalizeOID(variable.Name)] = variable
+			}
+		}
+
+		for _, oid := range batch {
+			variable, ok := answered[utils.NormalizeOID(oid)]
+			if !ok {
+				result, err := u.getFromSNMPWithSingleflight(ctx, oid)
+				if err != nil {
+					continue
+				}
+				variable = result.Variables[0]
+			}
+
+			// The agent answers unknown OIDs with an exception instead of an error
+			switch variable.Type {
+			case gosnmp.NoSuchObject, gosnmp.NoSuchInstance, gosnmp.EndOfMibView:
+				continue
+			}
+			values[oid] = variable
+		}
+	}
+	return values
+}
+
+// getCachedOnuList returns the ONUs of a PON from the cache while their serial numbers still match
+// the OLT. Otherwise, e.g. after an ONU was replaced, it reads them from the OLT and refreshes the
+// cache. A failing cache only costs the SNMP requests it would have saved.
+func (u *onuUsecase) getCachedOnuList(ctx context.Context, boardID, ponID int) ([]model.ONUInfoPerBoard, error) {
+	cachedList, err := u.cacheRepository.GetONUList(boardID, ponID)
+	switch {
+	case err == nil:
+		liveSerials, err := u.GetOnuIDAndSerialNumber(boardID, ponID)
+		if err == nil && serialsMatch(cachedList, liveSerials) {
+			return cachedList, nil
+		}
+		log.Info().Int("board", boardID).Int("pon", ponID).Msg("Cached ONU list is stale, reading it from the OLT")
+	case !errors.Is(err, repository.ErrCacheMiss):
+		log.Warn().Err(err).Int("board", boardID).Int("pon", ponID).Msg("Failed to read the cached ONU list")
+	}
+
+	onuList, err := u.GetByBoardIDAndPonID(ctx, boardID, ponID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.cacheRepository.SetONUList(boardID, ponID, onuList, u.cfg.RedisCfg.TTL); err != nil {
+		log.Warn().Err(err).Int("board", boardID).Int("pon", ponID).Msg("Failed to cache the ONU list")
+	}
+	return onuList, nil
+}
+
+// pageBounds returns the slice bounds of page pageIndex of pageSize items out of total. A page past
+// the last item is empty.
+func pageBounds(total, pageIndex, pageSize int) (startIndex, endIndex int) {
+	startIndex = min((pageIndex-1)*pageSize, total)
+	endIndex = min(startIndex+pageSize, total)
+	return startIndex, endIndex
+}
+
+func (u *onuUsecase) getName(ctx context.Context, OnuIDNameOID, onuID string) (string, error) {
 	oid := u.cfg.OltCfg.BaseOID1 + OnuIDNameOID + "." + onuID
-	result, err := u.getFromSNMPWithSingleflight(oid)
+	result, err := u.getFromSNMPWithSingleflight(ctx, oid)
 	if err != nil {
 		return "", err
 	}
 	return utils.ExtractName(result.Variables[0].Value), nil
 }
 
-func (u *onuUsecase) getONUType(OnuTypeOID, onuID string) (string, error) {
+func (u *onuUsecase) getONUType(ctx context.Context, OnuTypeOID, onuID string) (string, error) {
 	oid := u.cfg.OltCfg.BaseOID2 + OnuTypeOID + "." + onuID
-	result, err := u.getFromSNMPWithSingleflight(oid)
+	result, err := u.getFromSNMPWithSingleflight(ctx, oid)
 	if err != nil {
 		return "", err
 	}
 	return utils.ExtractName(result.Variables[0].Value), nil
 }
 
-func (u *onuUsecase) getSerialNumber(OnuSerialNumberOID, onuID string) (string, error) {
+func (u *onuUsecase) getSerialNumber(ctx context.Context, OnuSerialNumberOID, onuID string) (string, error) {
 	oid := u.cfg.OltCfg.BaseOID1 + OnuSerialNumberOID + "." + onuID
-	result, err := u.getFromSNMPWithSingleflight(oid)
+	result, err := u.getFromSNMPWithSingleflight(ctx, oid)
 	if err != nil {
 		return "", err
 	}
 	return utils.ExtractSerialNumber(result.Variables[0].Value), nil
 }
 
-func (u *onuUsecase) getTxPower(OnuTxPowerOID, onuID string) (string, error) {
+func (u *onuUsecase) getTxPower(ctx context.Context, OnuTxPowerOID, onuID string) (string, error) {
 	oid := u.cfg.OltCfg.BaseOID2 + OnuTxPowerOID + "." + onuID + ".1"
-	result, err := u.getFromSNMPWithSingleflight(oid)
+	result, err := u.getFromSNMPWithSingleflight(ctx, oid)
 	if err != nil {
 		return "", err
 	}
-	power, _ := utils.ConvertAndMultiply(result.Variables[0].Value)
-	return power, nil
+	return utils.ConvertAndMultiply(result.Variables[0].Value)
 }
 
-func (u *onuUsecase) getRxPower(OnuRxPowerOID, onuID string) (string, error) {
+func (u *onuUsecase) getRxPower(ctx context.Context, OnuRxPowerOID, onuID string) (string, error) {
 	oid := u.cfg.OltCfg.BaseOID1 + OnuRxPowerOID + "." + onuID + ".1"
-	result, err := u.getFromSNMPWithSingleflight(oid)
+	result, err := u.getFromSNMPWithSingleflight(ctx, oid)
 	if err != nil {
 		return "", err
 	}
-	power, _ := utils.ConvertAndMultiply(result.Variables[0].Value)
-	return power, nil
+	return utils.ConvertAndMultiply(result.Variables[0].Value)
 }
 
-func (u *onuUsecase) getStatus(OnuStatusOID, onuID string) (string, error) {
+func (u *onuUsecase) getStatus(ctx context.Context, OnuStatusOID, onuID string) (string, error) {
 	oid := u.cfg.OltCfg.BaseOID1 + OnuStatusOID + "." + onuID
-	result, err := u.getFromSNMPWithSingleflight(oid)
+	result, err := u.getFromSNMPWithSingleflight(ctx, oid)
 	if err != nil {
 		return "", err
 	}
 	return utils.ExtractAndGetStatus(result.Variables[0].Value), nil
 }
 
-func (u *onuUsecase) getIPAddress(OnuIPAddressOID, onuID string) (string, error) {
+func (u *onuUsecase) getIPAddress(ctx context.Context, OnuIPAddressOID, onuID string) (string, error) {
 	oid := u.cfg.OltCfg.BaseOID2 + OnuIPAddressOID + "." + onuID + ".1"
-	result, err := u.getFromSNMPWithSingleflight(oid)
+	result, err := u.getFromSNMPWithSingleflight(ctx, oid)
 	if err != nil {
 		return "", err
 	}
 	return utils.ExtractName(result.Variables[0].Value), nil
 }
 
-func (u *onuUsecase) getDescription(OnuDescriptionOID, onuID string) (string, error) {
+func (u *onuUsecase) getIPGateway(ctx context.Context, OnuIPGatewayOID, onuID string) (string, error) {
+	oid := u.cfg.OltCfg.BaseOID2 + OnuIPGatewayOID + "." + onuID + ".1"
+	result, err := u.getFromSNMPWithSingleflight(ctx, oid)
+	if err != nil {
+		return "", err
+	}
+	return utils.ExtractIPv4Address(result.Variables[0].Value), nil
+}
+
+func (u *onuUsecase) getIPMask(ctx context.Context, OnuIPMaskOID, onuID string) (string, error) {
+	oid := u.cfg.OltCfg.BaseOID2 + OnuIPMaskOID + "." + onuID + ".1"
+	result, err := u.getFromSNMPWithSingleflight(ctx, oid)
+	if err != nil {
+		return "", err
+	}
+	return utils.ExtractSubnetMask(result.Variables[0].Value), nil
+}
+
+func (u *onuUsecase) getDescription(ctx context.Context, OnuDescriptionOID, onuID string) (string, error) {
 	oid := u.cfg.OltCfg.BaseOID1 + OnuDescriptionOID + "." + onuID
-	result, err := u.getFromSNMPWithSingleflight(oid)
+	result, err := u.getFromSNMPWithSingleflight(ctx, oid)
 	if err != nil {
 		return "", err
 	}
 	return utils.ExtractName(result.Variables[0].Value), nil
 }
 
-func (u *onuUsecase) getLastOnline(OnuLastOnlineOID, onuID string) (string, error) {
+func (u *onuUsecase) getLastOnline(ctx context.Context, OnuLastOnlineOID, onuID string) (string, error) {
 	oid := u.cfg.OltCfg.BaseOID1 + OnuLastOnlineOID + "." + onuID
-	result, err := u.getFromSNMPWithSingleflight(oid)
+	result, err := u.getFromSNMPWithSingleflight(ctx, oid)
 	if err != nil {
 		return "", err
 	}
 
-	value := result.Variables[0].Value.([]byte)
-	return utils.ConvertByteArrayToDateTime(value)
+	return utils.ExtractDateTime(result.Variables[0].Value)
 }
 
-func (u *onuUsecase) getLastOffline(OnuLastOfflineOID, onuID string) (string, error) {
-	baseOID := u.cfg.OltCfg.BaseOID1
-	oid := baseOID + OnuLastOfflineOID + "." + onuID
-	oids := []string{oid}
-
-	result, err, _ := u.sg.Do(oid, func() (interface{}, error) {
-		return u.snmpRepository.Get(oids)
-	})
+func (u *onuUsecase) getLastOffline(ctx context.Context, OnuLastOfflineOID, onuID string) (string, error) {
+	oid := u.cfg.OltCfg.BaseOID1 + OnuLastOfflineOID + "." + onuID
+	result, err := u.getFromSNMPWithSingleflight(ctx, oid)
 	if err != nil {
-		log.Error().Msg("Failed to perform SNMP Get for last offline: " + err.Error())
-		return "", errors.New("failed to perform SNMP Get")
-	}
-
-	resultData := result.(*gosnmp.SnmpPacket)
-	if len(resultData.Variables) > 0 {
-		value := resultData.Variables[0].Value.([]byte)
-		return utils.ConvertByteArrayToDateTime(value)
+		return "", err
 	}
 
-	log.Error().Msg("Failed to get ONU Last Offline: No variables in the response")
-	return "", errors.New("no variables in the response")
+	return utils.ExtractDateTime(result.Variables[0].Value)
 }
 
-func (u *onuUsecase) getLastOfflineReason(OnuLastOfflineReasonOID, onuID string) (string, error) {
+func (u *onuUsecase) getLastOfflineReason(ctx context.Context, OnuLastOfflineReasonOID, onuID string) (string, error) {
 	oid := u.cfg.OltCfg.BaseOID1 + OnuLastOfflineReasonOID + "." + onuID
-	result, err := u.getFromSNMPWithSingleflight(oid)
+	result, err := u.getFromSNMPWithSingleflight(ctx, oid)
 	if err != nil {
 		return "", err
 	}
@@ -1272,9 +1183,9 @@ func serialsMatch(cachedData []model.ONUInfoPerBoard, liveData []model.OnuSerial
 	return true
 }
 
-func (u *onuUsecase) getOnuGponOpticalDistance(OnuGponOpticalDistanceOID, onuID string) (string, error) {
+func (u *onuUsecase) getOnuGponOpticalDistance(ctx context.Context, OnuGponOpticalDistanceOID, onuID string) (string, error) {
 	oid := u.cfg.OltCfg.BaseOID1 + OnuGponOpticalDistanceOID + "." + onuID
-	result, err := u.getFromSNMPWithSingleflight(oid)
+	result, err := u.getFromSNMPWithSingleflight(ctx, oid)
 	if err != nil {
 		return "", err
 	}
@@ -1282,6 +1193,287 @@ func (u *onuUsecase) getOnuGponOpticalDistance(OnuGponOpticalDistanceOID, onuID
 	return utils.ExtractGponOpticalDistance(result.Variables[0].Value), nil
 }
 
+func (u *onuUsecase) getCounter(ctx context.Context, OnuCounterOID, onuID string) (string, error) {
+	oid := u.cfg.OltCfg.BaseOID1 + OnuCounterOID + "." + onuID
+	result, err := u.getFromSNMPWithSingleflight(ctx, oid)
+	if err != nil {
+		return "", err
+	}
+
+	return utils.ExtractCounterValue(result.Variables[0].Value)
+}
+
+func (u *onuUsecase) getMACAddress(ctx context.Context, OnuMACAddressOID, onuID string) (string, error) {
+	oid := u.cfg.OltCfg.BaseOID1 + OnuMACAddressOID + "." + onuID
+	result, err := u.getFromSNMPWithSingleflight(ctx, oid)
+	if err != nil {
+		return "", err
+	}
+
+	return utils.ExtractMACAddress(result.Variables[0].Value), nil
+}
+
+func (u *onuUsecase) getEquipmentID(ctx context.Context, OnuEquipmentIDOID, onuID string) (string, error) {
+	oid := u.cfg.OltCfg.BaseOID1 + OnuEquipmentIDOID + "." + onuID
+	result, err := u.getFromSNMPWithSingleflight(ctx, oid)
+	if err != nil {
+		return "", err
+	}
+
+	return utils.ExtractEquipmentID(result.Variables[0].Value), nil
+}
+
+func (u *onuUsecase) getTransceiverType(ctx context.Context, OnuTransceiverTypeOID, onuID string) (string, error) {
+	oid := u.cfg.OltCfg.BaseOID1 + OnuTransceiverTypeOID + "." + onuID
+	result, err := u.getFromSNMPWithSingleflight(ctx, oid)
+	if err != nil {
+		return "", err
+	}
+
+	return utils.ExtractTransceiverType(result.Variables[0].Value), nil
+}
+
+func (u *onuUsecase) getProfileTemplate(ctx context.Context, OnuProfileTemplateOID, onuID string) (string, error) {
+	oid := u.cfg.OltCfg.BaseOID1 + OnuProfileTemplateOID + "." + onuID
+	result, err := u.getFromSNMPWithSingleflight(ctx, oid)
+	if err != nil {
+		return "", err
+	}
+
+	return utils.ExtractProfileTemplate(result.Variables[0].Value), nil
+}
+
+func (u *onuUsecase) getQosProfile(ctx context.Context, OnuQosProfileOID, onuID string) (string, error) {
+	oid := u.cfg.OltCfg.BaseOID1 + OnuQosProfileOID + "." + onuID
+	result, err := u.getFromSNMPWithSingleflight(ctx, oid)
+	if err != nil {
+		return "", err
+	}
+
+	return utils.ExtractQosProfile(result.Variables[0].Value), nil
+}
+
+func (u *onuUsecase) getSignalQuality(ctx context.Context, OnuSignalQualityOID, onuID string) (string, error) {
+	oid := u.cfg.OltCfg.BaseOID1 + OnuSignalQualityOID + "." + onuID
+	result, err := u.getFromSNMPWithSingleflight(ctx, oid)
+	if err != nil {
+		return "", err
+	}
+
+	return utils.ExtractSignalQuality(result.Variables[0].Value)
+}
+
+// getTransceiverReading reads a diagnostic of the ONU transceiver and converts it with convert
+func (u *onuUsecase) getTransceiverReading(ctx context.Context, readingOID, onuID string, convert func(interface{}) (string, error)) (string, error) {
+	oid := u.cfg.OltCfg.BaseOID1 + readingOID + "." + onuID
+	result, err := u.getFromSNMPWithSingleflight(ctx, oid)
+	if err != nil {
+		return "", err
+	}
+
+	return convert(result.Variables[0].Value)
+}
+
+func (u *onuUsecase) getMulticastEnabled(ctx context.Context, OnuMulticastEnabledOID, onuID string) (string, error) {
+	oid := u.cfg.OltCfg.BaseOID1 + OnuMulticastEnabledOID + "." + onuID
+	result, err := u.getFromSNMPWithSingleflight(ctx, oid)
+	if err != nil {
+		return "", err
+	}
+
+	return utils.ExtractMulticastEnabled(result.Variables[0].Value)
+}
+
+func (u *onuUsecase) getMulticastGroups(ctx context.Context, OnuMulticastGroupsOID, onuID string) (string, error) {
+	oid := u.cfg.OltCfg.BaseOID1 + OnuMulticastGroupsOID + "." + onuID
+	result, err := u.getFromSNMPWithSingleflight(ctx, oid)
+	if err != nil {
+		return "", err
+	}
+
+	return utils.ExtractMulticastGroups(result.Variables[0].Value)
+}
+
+func (u *onuUsecase) getCatvStatus(ctx context.Context, OnuCatvStatusOID, onuID string) (string, error) {
+	oid := u.cfg.OltCfg.BaseOID1 + OnuCatvStatusOID + "." + onuID
+	result, err := u.getFromSNMPWithSingleflight(ctx, oid)
+	if err != nil {
+		return "", err
+	}
+
+	return utils.ExtractCatvStatus(result.Variables[0].Value)
+}
+
+func (u *onuUsecase) getCatvLevel(ctx context.Context, OnuCatvLevelOID, onuID string) (string, error) {
+	oid := u.cfg.OltCfg.BaseOID1 + OnuCatvLevelOID + "." + onuID
+	result, err := u.getFromSNMPWithSingleflight(ctx, oid)
+	if err != nil {
+		return "", err
+	}
+
+	return utils.ExtractCatvLevel(result.Variables[0].Value)
+}
+
+// getUniPorts walks the speed, and the duplex mode when its OID is set, of every Ethernet (UNI) port
+// of the ONU, indexed by port number under the ONU ID. Ports that are down or report an unknown
+// value are listed without it.
+func (u *onuUsecase) getUniPorts(ctx context.Context, OnuUniSpeedOID, OnuUniDuplexOID, onuID string) ([]model.UniPort, error) {
+	ports := make(map[int]*model.UniPort)
+	port := func(pdu gosnmp.SnmpPDU) *model.UniPort {
+		index := utils.ExtractIDOnuID(pdu.Name)
+		if ports[index] == nil {
+			ports[index] = &model.UniPort{Port: index}
+		}
+		return ports[index]
+	}
+
+	err := u.snmpRepository.Walk(ctx, u.cfg.OltCfg.BaseOID1+OnuUniSpeedOID+"."+onuID, func(pdu gosnmp.SnmpPDU) error {
+		uniPort := port(pdu)
+		if speed, err := utils.ExtractUniSpeed(pdu.Value); err == nil {
+			uniPort.SpeedMbps = speed
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if OnuUniDuplexOID != "" {
+		err := u.snmpRepository.Walk(ctx, u.cfg.OltCfg.BaseOID1+OnuUniDuplexOID+"."+onuID, func(pdu gosnmp.SnmpPDU) error {
+			if duplex, err := utils.ExtractUniDuplex(pdu.Value); err == nil {
+				port(pdu).Duplex = duplex
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	uniPorts := make([]model.UniPort, 0, len(ports))
+	for _, uniPort := range ports {
+		uniPorts = append(uniPorts, *uniPort)
+	}
+	sort.Slice(uniPorts, func(i, j int) bool {
+		return uniPorts[i].Port < uniPorts[j].Port
+	})
+	return uniPorts, nil
+}
+
+func (u *onuUsecase) getTcontAllocation(ctx context.Context, OnuTcontAllocationOID, onuID string) ([]model.TcontAllocation, error) {
+	oid := u.cfg.OltCfg.BaseOID1 + OnuTcontAllocationOID + "." + onuID
+	result, err := u.getFromSNMPWithSingleflight(ctx, oid)
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.ExtractTcontAllocation(result.Variables[0].Value)
+}
+
+func (u *onuUsecase) getLineRate(ctx context.Context, OnuLineRateOID, onuID string) (string, error) {
+	oid := u.cfg.OltCfg.BaseOID1 + OnuLineRateOID + "." + onuID
+	result, err := u.getFromSNMPWithSingleflight(ctx, oid)
+	if err != nil {
+		return "", err
+	}
+
+	return utils.ExtractLineRate(result.Variables[0].Value)
+}
+
+func (u *onuUsecase) getAlarmCount(ctx context.Context, OnuAlarmCountOID, onuID string) (string, error) {
+	oid := u.cfg.OltCfg.BaseOID1 + OnuAlarmCountOID + "." + onuID
+	result, err := u.getFromSNMPWithSingleflight(ctx, oid)
+	if err != nil {
+		return "", err
+	}
+
+	return utils.ExtractAlarmCount(result.Variables[0].Value)
+}
+
+func (u *onuUsecase) getLoopDetected(ctx context.Context, OnuLoopDetectedOID, onuID string) (string, error) {
+	oid := u.cfg.OltCfg.BaseOID1 + OnuLoopDetectedOID + "." + onuID
+	result, err := u.getFromSNMPWithSingleflight(ctx, oid)
+	if err != nil {
+		return "", err
+	}
+
+	return utils.ExtractLoopDetected(result.Variables[0].Value)
+}
+
+func (u *onuUsecase) getEncryptionEnabled(ctx context.Context, OnuEncryptionOID, onuID string) (string, error) {
+	oid := u.cfg.OltCfg.BaseOID1 + OnuEncryptionOID + "." + onuID
+	result, err := u.getFromSNMPWithSingleflight(ctx, oid)
+	if err != nil {
+		return "", err
+	}
+
+	return utils.ExtractEncryptionEnabled(result.Variables[0].Value)
+}
+
+// reconcileLosFlag downgrades an Online status to LOS while the LOS flag of the ONU is raised, since
+// some firmware keep reporting Online during a transient loss of signal. The status is kept as is
+// when the LOS flag OID is not configured or cannot be read.
+func (u *onuUsecase) reconcileLosFlag(ctx context.Context, oltConfig *model.OltConfig, onuID, status string) string {
+	if status != "Online" || oltConfig.OnuLosFlagOID == "" {
+		return status
+	}
+
+	if losFlag, err := u.getLosFlag(ctx, oltConfig.OnuLosFlagOID, onuID); err == nil && losFlag == "true" {
+		return "LOS"
+	}
+	return status
+}
+
+func (u *onuUsecase) getLosFlag(ctx context.Context, OnuLosFlagOID, onuID string) (string, error) {
+	oid := u.cfg.OltCfg.BaseOID1 + OnuLosFlagOID + "." + onuID
+	result, err := u.getFromSNMPWithSingleflight(ctx, oid)
+	if err != nil {
+		return "", err
+	}
+
+	return utils.ExtractLosFlag(result.Variables[0].Value)
+}
+
+func (u *onuUsecase) getUpgradeState(ctx context.Context, OnuUpgradeStateOID, onuID string) (string, error) {
+	oid := u.cfg.OltCfg.BaseOID1 + OnuUpgradeStateOID + "." + onuID
+	result, err := u.getFromSNMPWithSingleflight(ctx, oid)
+	if err != nil {
+		return "", err
+	}
+
+	return utils.ExtractUpgradeState(result.Variables[0].Value), nil
+}
+
+func (u *onuUsecase) getBatteryStatus(ctx context.Context, OnuBatteryStatusOID, onuID string) (string, error) {
+	oid := u.cfg.OltCfg.BaseOID1 + OnuBatteryStatusOID + "." + onuID
+	result, err := u.getFromSNMPWithSingleflight(ctx, oid)
+	if err != nil {
+		return "", err
+	}
+
+	return utils.ExtractBatteryStatus(result.Variables[0].Value), nil
+}
+
+func (u *onuUsecase) getRegistrationFailReason(ctx context.Context, OnuRegistrationFailReasonOID, onuID string) (int, string, error) {
+	oid := u.cfg.OltCfg.BaseOID1 + OnuRegistrationFailReasonOID + "." + onuID
+	result, err := u.getFromSNMPWithSingleflight(ctx, oid)
+	if err != nil {
+		return 0, "", err
+	}
+
+	return utils.ExtractRegistrationFailReason(result.Variables[0].Value)
+}
+
+func (u *onuUsecase) getRegisteredTime(ctx context.Context, OnuRegisteredTimeOID, onuID string) (string, error) {
+	oid := u.cfg.OltCfg.BaseOID1 + OnuRegisteredTimeOID + "." + onuID
+	result, err := u.getFromSNMPWithSingleflight(ctx, oid)
+	if err != nil {
+		return "", err
+	}
+
+	return utils.ExtractDateTime(result.Variables[0].Value)
+}
+
 func (u *onuUsecase) getUptimeDuration(lastOnline string) (string, error) {
 	currentTime := time.Now()
 
@@ -1313,20 +1505,78 @@ func (u *onuUsecase) getLastDownDuration(lastOffline, lastOnline string) (string
 	return utils.ConvertDurationToString(duration), nil
 }
 
-func (u *onuUsecase) getFromSNMPWithSingleflight(oid string) (*gosnmp.SnmpPacket, error) {
-	result, err, _ := u.sg.Do(oid, func() (interface{}, error) {
-		return u.snmpRepository.Get([]string{oid})
+// matchVariable returns the packet with the variable of the requested OID first. The packet is
+// shared by the singleflight callers, so it is copied instead of reordered in place. When no
+// variable name matches, the first variable is kept.
+func matchVariable(packet *gosnmp.SnmpPacket, oid string) *gosnmp.SnmpPacket {
+	requested := utils.NormalizeOID(oid)
+	for i, variable := range packet.Variables {
+		if utils.NormalizeOID(variable.Name) != requested {
+			continue
+		}
+		if i == 0 {
+			return packet
+		}
+		matched := *packet
+		matched.Variables = []gosnmp.SnmpPDU{variable}
+		return &matched
+	}
+	return packet
+}
+
+// errAttributeTimeout is returned when an attribute Get exceeds cfg.OltCfg.AttributeTimeout
+var errAttributeTimeout = errors.New("attribute timeout")
+
+// getFromSNMPWithSingleflight reads a single OID, sharing the Get with the concurrent callers of
+// the same OID. The shared Get is not canceled along with the caller that started it, it is only
+// bounded by the timeout of the repository, while every caller stops waiting once its own ctx is
+// done.
+func (u *onuUsecase) getFromSNMPWithSingleflight(ctx context.Context, oid string) (*gosnmp.SnmpPacket, error) {
+	resultCh := u.sg.DoChan(oid, func() (interface{}, error) {
+		return u.snmpRepository.Get(context.WithoutCancel(ctx), []string{oid})
 	})
+
+	// Without an attribute timeout, wait for the SNMP timeout of the repository
+	var timeout <-chan time.Time
+	if u.cfg.OltCfg.AttributeTimeout > 0 {
+		timer := time.NewTimer(u.cfg.OltCfg.AttributeTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	var result interface{}
+	var err error
+	select {
+	case res := <-resultCh:
+		result, err = res.Val, res.Err
+	case <-timeout:
+		// The Get goes on in the background and is still shared with later callers of the same OID
+		err = errAttributeTimeout
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
 	if err != nil {
 		log.Error().Msg("Failed to perform SNMP Get for OID " + oid + ": " + err.Error())
-		return nil, errors.New("failed to perform SNMP Get")
+		return nil, fmt.Errorf("failed to perform SNMP Get of %s: %w", oid, err)
 	}
 
-	packet := result.(*gosnmp.SnmpPacket)
-	if len(packet.Variables) == 0 {
+	// A truncated response (e.g. tooBig) may come back without any variable
+	packet, ok := result.(*gosnmp.SnmpPacket)
+	if !ok || packet == nil || len(packet.Variables) == 0 {
 		log.Error().Msg("No variables returned for OID " + oid)
 		return nil, errors.New("no variables in the response")
 	}
 
+	// Some firmware return the variables in a different order than requested, or with a trailing
+	// dot appended to their name, so the variable is matched by its normalized OID
+	packet = matchVariable(packet, oid)
+
+	// The agent answers unknown OIDs with an exception instead of an error
+	switch packet.Variables[0].Type {
+	case gosnmp.NoSuchObject, gosnmp.NoSuchInstance, gosnmp.EndOfMibView:
+		log.Warn().Msg("No such instance for OID " + oid)
+		return nil, errors.New("no such instance")
+	}
+
 	return packet, nil
 }
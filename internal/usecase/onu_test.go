@@ -0,0 +1,1171 @@
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/config"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSnmpRepository serves ONU IDs from Walk and serial numbers from Get, recording how many
+// Get requests were in flight at the same time. Gets of ONUs without a serial number fail.
+type fakeSnmpRepository struct {
+	onuIDs      []int
+	serials     map[string]string // keyed by ONU ID
+	getDelay    time.Duration
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight int
+}
+
+func (f *fakeSnmpRepository) Get(_ context.Context, oids []string) (*gosnmp.SnmpPacket, error) {
+	f.mu.Lock()
+	f.inFlight++
+	if f.inFlight > f.maxInFlight {
+		f.maxInFlight = f.inFlight
+	}
+	f.mu.Unlock()
+
+	time.Sleep(f.getDelay)
+
+	f.mu.Lock()
+	f.inFlight--
+	f.mu.Unlock()
+
+	onuID := oids[0][strings.LastIndex(oids[0], ".")+1:]
+	if _, ok := f.serials[onuID]; !ok {
+		return nil, errors.New("request timeout")
+	}
+	return &gosnmp.SnmpPacket{Variables: []gosnmp.SnmpPDU{
+		{Name: oids[0], Type: gosnmp.OctetString, Value: []byte("1," + f.serials[onuID])},
+	}}, nil
+}
+
+func (f *fakeSnmpRepository) Walk(_ context.Context, oid string, walkFunc func(pdu gosnmp.SnmpPDU) error) error {
+	for _, id := range f.onuIDs {
+		if err := walkFunc(gosnmp.SnmpPDU{Name: oid + "." + strconv.Itoa(id), Type: gosnmp.OctetString, Value: []byte("onu")}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestGetOnuIDAndSerialNumberBoundedConcurrency(t *testing.T) {
+	tests := []struct {
+		name        string
+		concurrency int
+		expectedMax int
+	}{
+		{name: "Sequential when unset", concurrency: 0, expectedMax: 1},
+		{name: "Sequential", concurrency: 1, expectedMax: 1},
+		{name: "Bounded", concurrency: 3, expectedMax: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeSnmpRepository{
+				onuIDs:   []int{12, 3, 7, 1, 9, 5, 10, 2},
+				serials:  make(map[string]string),
+				getDelay: 10 * time.Millisecond,
+			}
+			for _, id := range repo.onuIDs {
+				repo.serials[strconv.Itoa(id)] = "ZTEGC" + strconv.Itoa(1000+id)
+			}
+
+			cfg := &config.Config{
+				OltCfg: config.OltConfig{BaseOID1: ".1.3.6.1.4.1.3902.1082", SerialNumberConcurrency: tt.concurrency},
+				Pons:   map[int]map[int]model.OltConfig{1: {1: {OnuIDNameOID: ".500.10.2.3.3.1.2.285278465", OnuSerialNumberOID: ".500.10.2.3.3.1.18.285278465"}}},
+			}
+
+			result, err := NewOnuUsecase(repo, nil, cfg).GetOnuIDAndSerialNumber(1, 1)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedMax, repo.maxInFlight)
+
+			require.Len(t, result, len(repo.onuIDs))
+			for i, onu := range result {
+				if i > 0 {
+					assert.Less(t, result[i-1].ID, onu.ID, "result is sorted by ONU ID")
+				}
+				assert.Equal(t, 1, onu.Board)
+				assert.Equal(t, 1, onu.PON)
+				assert.Equal(t, "ZTEGC"+strconv.Itoa(1000+onu.ID), onu.SerialNumber)
+			}
+		})
+	}
+}
+
+func TestGetOnuIDAndSerialNumberParallel(t *testing.T) {
+	list := func(concurrency int) ([]model.OnuSerialNumber, time.Duration) {
+		repo := &fakeSnmpRepository{
+			onuIDs:   []int{1, 2, 3, 4, 5, 6, 7, 8},
+			serials:  map[string]string{"1": "ZTEGC0000001", "2": "ZTEGC0000002", "3": "ZTEGC0000003", "5": "ZTEGC0000005", "6": "ZTEGC0000006", "7": "ZTEGC0000007", "8": "ZTEGC0000008"},
+			getDelay: 20 * time.Millisecond,
+		}
+		cfg := &config.Config{
+			OltCfg: config.OltConfig{BaseOID1: ".1.3.6.1.4.1.3902.1082", SerialNumberConcurrency: concurrency},
+			Pons:   map[int]map[int]model.OltConfig{1: {1: {OnuIDNameOID: ".500.10.2.3.3.1.2.285278465", OnuSerialNumberOID: ".500.10.2.3.3.1.18.285278465"}}},
+		}
+
+		start := time.Now()
+		result, err := NewOnuUsecase(repo, nil, cfg).GetOnuIDAndSerialNumber(1, 1)
+		require.NoError(t, err, "a failed ONU does not fail the list")
+		return result, time.Since(start)
+	}
+
+	serial, serialDuration := list(1)
+	parallel, parallelDuration := list(4)
+
+	assert.Equal(t, serial, parallel)
+	var ids []int
+	for _, onu := range parallel {
+		ids = append(ids, onu.ID)
+	}
+	assert.Equal(t, []int{1, 2, 3, 5, 6, 7, 8}, ids, "sorted by ONU ID, without the ONU whose serial number cannot be read")
+	assert.Less(t, parallelDuration, serialDuration/2, "8 reads of 20ms take about 160ms one by one and 40ms 4 at a time")
+}
+
+// trailingDotRepository walks ONU 1 and answers every Get with a decoy variable first, then the
+// requested one, both named with a trailing dot.
+type trailingDotRepository struct{}
+
+func (r *trailingDotRepository) Get(_ context.Context, oids []string) (*gosnmp.SnmpPacket, error) {
+	return &gosnmp.SnmpPacket{Variables: []gosnmp.SnmpPDU{
+		{Name: ".1.3.6.1.2.1.1.5.0.", Type: gosnmp.OctetString, Value: []byte("decoy")},
+		{Name: strings.TrimPrefix(oids[0], ".") + ".", Type: gosnmp.OctetString, Value: []byte("1,ZTEGC0000001")},
+	}}, nil
+}
+
+func (r *trailingDotRepository) Walk(_ context.Context, oid string, walkFunc func(pdu gosnmp.SnmpPDU) error) error {
+	return walkFunc(gosnmp.SnmpPDU{Name: oid + ".1.", Type: gosnmp.OctetString, Value: []byte("customer-1")})
+}
+
+func TestGetByBoardIDPonIDAndOnuIDTrailingDot(t *testing.T) {
+	cfg := &config.Config{
+		OltCfg: config.OltConfig{BaseOID1: ".1.3.6.1.4.1.3902.1082", BaseOID2: ".1.3.6.1.4.1.3902.1012"},
+		Pons: map[int]map[int]model.OltConfig{1: {1: {
+			OnuIDNameOID:       ".500.10.2.3.3.1.2.285278465",
+			OnuSerialNumberOID: ".500.10.2.3.3.1.18.285278465",
+		}}},
+	}
+
+	onu, err := NewOnuUsecase(&trailingDotRepository{}, nil, cfg).GetByBoardIDPonIDAndOnuID(context.Background(), 1, 1, 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, onu.ID, "the ONU ID is parsed from a name with a trailing dot")
+	assert.Equal(t, "ZTEGC0000001", onu.SerialNumber, "the variable of the requested OID is used, not the first one")
+}
+
+// slowAttributeRepository walks ONU 1 and answers every Get at once, except for the slow OID.
+type slowAttributeRepository struct {
+	slowOID   string
+	slowDelay time.Duration
+}
+
+func (r *slowAttributeRepository) Get(_ context.Context, oids []string) (*gosnmp.SnmpPacket, error) {
+	if oids[0] == r.slowOID {
+		time.Sleep(r.slowDelay)
+	}
+	return &gosnmp.SnmpPacket{Variables: []gosnmp.SnmpPDU{
+		{Name: oids[0], Type: gosnmp.OctetString, Value: []byte("1,ZTEGC0000001")},
+	}}, nil
+}
+
+func (r *slowAttributeRepository) Walk(_ context.Context, oid string, walkFunc func(pdu gosnmp.SnmpPDU) error) error {
+	return walkFunc(gosnmp.SnmpPDU{Name: oid + ".1", Type: gosnmp.OctetString, Value: []byte("customer-1")})
+}
+
+func TestGetByBoardIDPonIDAndOnuIDAttributeTimeout(t *testing.T) {
+	cfg := &config.Config{
+		OltCfg: config.OltConfig{BaseOID1: ".1.3.6.1.4.1.3902.1082", AttributeTimeout: 20 * time.Millisecond},
+		Pons: map[int]map[int]model.OltConfig{1: {1: {
+			OnuIDNameOID:       ".500.10.2.3.3.1.2.285278465",
+			OnuSerialNumberOID: ".500.10.2.3.3.1.18.285278465",
+			OnuDescriptionOID:  ".500.10.2.3.3.1.3.285278465",
+		}}},
+	}
+	repo := &slowAttributeRepository{
+		slowOID:   ".1.3.6.1.4.1.3902.1082.500.10.2.3.3.1.3.285278465.1",
+		slowDelay: 500 * time.Millisecond,
+	}
+
+	start := time.Now()
+	onu, err := NewOnuUsecase(repo, nil, cfg).GetByBoardIDPonIDAndOnuID(context.Background(), 1, 1, 1)
+	require.NoError(t, err)
+
+	assert.Less(t, time.Since(start), repo.slowDelay, "the slow attribute does not hold up the ONU")
+	assert.Empty(t, onu.Description, "the slow attribute is left empty")
+	assert.Equal(t, "ZTEGC0000001", onu.SerialNumber, "the other attributes are still populated")
+	assert.Equal(t, "customer-1", onu.Name)
+}
+
+// blockingGetRepository holds every Get until release is closed, unless its context is done first.
+type blockingGetRepository struct {
+	slowAttributeRepository
+	started chan context.Context // Receives the context of every Get
+	release chan struct{}
+}
+
+func (r *blockingGetRepository) Get(ctx context.Context, oids []string) (*gosnmp.SnmpPacket, error) {
+	r.started <- ctx
+	select {
+	case <-r.release:
+		return r.slowAttributeRepository.Get(ctx, oids)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func TestGetFromSNMPWithSingleflightOutlivesCanceledCaller(t *testing.T) {
+	repo := &blockingGetRepository{started: make(chan context.Context, 1), release: make(chan struct{})}
+	u := NewOnuUsecase(repo, nil, &config.Config{}).(*onuUsecase)
+	oid := ".1.3.6.1.4.1.3902.1082.500.10.2.3.3.1.18.285278465.1"
+	ctx, cancel := context.WithCancel(context.Background())
+
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := u.getFromSNMPWithSingleflight(ctx, oid)
+		errCh <- err
+	}()
+	sharedCtx := <-repo.started
+	cancel()
+
+	err := <-errCh
+	assert.ErrorIs(t, err, context.Canceled, "the canceled caller stops waiting")
+	assert.ErrorContains(t, err, oid)
+	assert.NoError(t, sharedCtx.Err(), "the shared Get is not canceled along with the caller that started it")
+
+	close(repo.release)
+	packet, err := u.getFromSNMPWithSingleflight(context.Background(), oid)
+	require.NoError(t, err)
+	assert.Equal(t, oid, packet.Variables[0].Name)
+}
+
+func TestGetByBoardIDPonIDAndOnuIDContextDeadline(t *testing.T) {
+	cfg := &config.Config{
+		OltCfg: config.OltConfig{BaseOID1: ".1.3.6.1.4.1.3902.1082"},
+		Pons: map[int]map[int]model.OltConfig{1: {1: {
+			OnuIDNameOID:       ".500.10.2.3.3.1.2.285278465",
+			OnuSerialNumberOID: ".500.10.2.3.3.1.18.285278465",
+			OnuDescriptionOID:  ".500.10.2.3.3.1.3.285278465",
+		}}},
+	}
+	repo := &slowAttributeRepository{
+		slowOID:   ".1.3.6.1.4.1.3902.1082.500.10.2.3.3.1.3.285278465.1",
+		slowDelay: 500 * time.Millisecond,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	onu, err := NewOnuUsecase(repo, nil, cfg).GetByBoardIDPonIDAndOnuID(ctx, 1, 1, 1)
+	require.NoError(t, err)
+
+	assert.Less(t, time.Since(start), repo.slowDelay, "the expired context aborts the slow Get")
+	assert.Empty(t, onu.Description)
+	assert.Equal(t, "ZTEGC0000001", onu.SerialNumber, "the attributes read before the deadline are kept")
+}
+
+// shortResponseRepository walks a single ONU and answers every Get with the configured packet.
+type shortResponseRepository struct {
+	packet *gosnmp.SnmpPacket
+}
+
+func (r *shortResponseRepository) Get(_ context.Context, _ []string) (*gosnmp.SnmpPacket, error) {
+	return r.packet, nil
+}
+
+func (r *shortResponseRepository) Walk(_ context.Context, oid string, walkFunc func(pdu gosnmp.SnmpPDU) error) error {
+	return walkFunc(gosnmp.SnmpPDU{Name: oid, Type: gosnmp.OctetString, Value: []byte("customer-1")})
+}
+
+func TestGetByBoardIDPonIDAndOnuIDShortResponse(t *testing.T) {
+	tests := []struct {
+		name   string
+		packet *gosnmp.SnmpPacket
+	}{
+		{name: "Nil packet", packet: nil},
+		{name: "Empty variables", packet: &gosnmp.SnmpPacket{}},
+		{name: "No such instance", packet: &gosnmp.SnmpPacket{Variables: []gosnmp.SnmpPDU{{Type: gosnmp.NoSuchInstance}}}},
+		{name: "Unexpected value type", packet: &gosnmp.SnmpPacket{Variables: []gosnmp.SnmpPDU{{Type: gosnmp.Integer, Value: 1}}}},
+	}
+
+	cfg := &config.Config{
+		OltCfg: config.OltConfig{BaseOID1: ".1.3.6.1.4.1.3902.1082", BaseOID2: ".1.3.6.1.4.1.3902.1012"},
+		Pons: map[int]map[int]model.OltConfig{1: {1: {
+			OnuIDNameOID:              ".500.10.2.3.3.1.2.285278465",
+			OnuTypeOID:                ".3.50.11.2.1.17.268501248",
+			OnuSerialNumberOID:        ".500.10.2.3.3.1.18.285278465",
+			OnuRxPowerOID:             ".500.20.2.2.2.1.10.285278465",
+			OnuTxPowerOID:             ".3.50.12.1.1.14.268501248",
+			OnuStatusOID:              ".500.10.2.3.8.1.4.285278465",
+			OnuIPAddressOID:           ".3.50.16.1.1.10.268501248",
+			OnuDescriptionOID:         ".500.10.2.3.3.1.3.285278465",
+			OnuLastOnlineOID:          ".500.10.2.3.8.1.5.285278465",
+			OnuLastOfflineOID:         ".500.10.2.3.8.1.6.285278465",
+			OnuLastOfflineReasonOID:   ".500.10.2.3.8.1.7.285278465",
+			OnuGponOpticalDistanceOID: ".500.10.2.3.10.1.2.285278465",
+			OnuDownstreamOctetsOID:    ".500.10.2.3.11.1.1.285278465",
+			OnuUpstreamOctetsOID:      ".500.10.2.3.11.1.2.285278465",
+			OnuRegisteredTimeOID:      ".500.10.2.3.8.1.8.285278465",
+			OnuMACAddressOID:          ".500.10.2.3.3.1.9.285278465",
+			OnuRxDroppedOID:           ".500.10.2.3.11.1.3.285278465",
+			OnuTxDroppedOID:           ".500.10.2.3.11.1.4.285278465",
+		}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			onuUsecase := NewOnuUsecase(&shortResponseRepository{packet: tt.packet}, nil, cfg)
+
+			var onu model.ONUCustomerInfo
+			var err error
+			require.NotPanics(t, func() {
+				onu, err = onuUsecase.GetByBoardIDPonIDAndOnuID(context.Background(), 1, 1, 1)
+			})
+			require.NoError(t, err)
+
+			// The ONU is still reported, only the unreadable attributes stay empty.
+			assert.Equal(t, 1, onu.ID)
+			assert.Equal(t, "customer-1", onu.Name)
+			assert.Empty(t, onu.LastOnline)
+			assert.Empty(t, onu.LastOffline)
+			assert.Empty(t, onu.RegisteredAt)
+		})
+	}
+}
+
+// cannedGetRepository walks ONU 1 and answers every Get with the value of the requested OID, or an
+// exception when it is unknown.
+type cannedGetRepository struct {
+	values map[string]interface{}
+}
+
+func (r *cannedGetRepository) Get(_ context.Context, oids []string) (*gosnmp.SnmpPacket, error) {
+	value, ok := r.values[oids[0]]
+	if !ok {
+		return &gosnmp.SnmpPacket{Variables: []gosnmp.SnmpPDU{{Name: oids[0], Type: gosnmp.NoSuchInstance}}}, nil
+	}
+	return &gosnmp.SnmpPacket{Variables: []gosnmp.SnmpPDU{{Name: oids[0], Type: gosnmp.Integer, Value: value}}}, nil
+}
+
+func (r *cannedGetRepository) Walk(_ context.Context, oid string, walkFunc func(pdu gosnmp.SnmpPDU) error) error {
+	return walkFunc(gosnmp.SnmpPDU{Name: oid + ".1", Type: gosnmp.OctetString, Value: []byte("customer-1")})
+}
+
+func TestGetByBoardIDPonIDAndOnuIDLosFlag(t *testing.T) {
+	baseOID := ".1.3.6.1.4.1.3902.1082"
+	statusOID := ".500.10.2.3.8.1.4.285278465"
+	losFlagOID := ".500.10.2.3.8.1.30.285278465"
+
+	tests := []struct {
+		name       string
+		losFlagOID string
+		status     int
+		losFlag    interface{}
+		expected   string
+	}{
+		{name: "Online with LOS flag raised", losFlagOID: losFlagOID, status: 4, losFlag: 1, expected: "LOS"},
+		{name: "Online with LOS flag cleared", losFlagOID: losFlagOID, status: 4, losFlag: 2, expected: "Online"},
+		{name: "Online with unreadable LOS flag", losFlagOID: losFlagOID, status: 4, expected: "Online"},
+		{name: "LOS flag OID not configured", status: 4, losFlag: 1, expected: "Online"},
+		{name: "Other status is kept", losFlagOID: losFlagOID, status: 5, losFlag: 1, expected: "Dying Gasp"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				OltCfg: config.OltConfig{BaseOID1: baseOID},
+				Pons: map[int]map[int]model.OltConfig{1: {1: {
+					OnuIDNameOID:  ".500.10.2.3.3.1.2.285278465",
+					OnuStatusOID:  statusOID,
+					OnuLosFlagOID: tt.losFlagOID,
+				}}},
+			}
+			values := map[string]interface{}{baseOID + statusOID + ".1": tt.status}
+			if tt.losFlag != nil {
+				values[baseOID+losFlagOID+".1"] = tt.losFlag
+			}
+
+			onu, err := NewOnuUsecase(&cannedGetRepository{values: values}, nil, cfg).GetByBoardIDPonIDAndOnuID(context.Background(), 1, 1, 1)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, onu.Status)
+		})
+	}
+}
+
+func TestGetByBoardIDPonIDAndOnuIDTransceiverDiagnostics(t *testing.T) {
+	baseOID := ".1.3.6.1.4.1.3902.1082"
+	temperatureOID := ".500.20.2.2.2.1.20.285278465"
+	voltageOID := ".500.20.2.2.2.1.21.285278465"
+	biasOID := ".500.20.2.2.2.1.22.285278465"
+	cfg := &config.Config{
+		OltCfg: config.OltConfig{BaseOID1: baseOID},
+		Pons: map[int]map[int]model.OltConfig{1: {1: {
+			OnuIDNameOID:      ".500.10.2.3.3.1.2.285278465",
+			OnuTemperatureOID: temperatureOID,
+			OnuVoltageOID:     voltageOID,
+			OnuBiasCurrentOID: biasOID,
+		}}},
+	}
+	values := map[string]interface{}{
+		baseOID + temperatureOID + ".1": 11520,
+		baseOID + voltageOID + ".1":     65535, // Placeholder of a transceiver without diagnostics
+		baseOID + biasOID + ".1":        7500,
+	}
+
+	onu, err := NewOnuUsecase(&cannedGetRepository{values: values}, nil, cfg).GetByBoardIDPonIDAndOnuID(context.Background(), 1, 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "45.00", onu.Temperature)
+	assert.Empty(t, onu.Voltage, "out of range readings are left empty")
+	assert.Equal(t, "15.000", onu.BiasCurrent)
+}
+
+// uniPortRepository walks ONU 1 under the ONU name OID, the given per-port values under their OID,
+// and answers every Get with an exception.
+type uniPortRepository struct {
+	ports map[string]map[int]interface{} // keyed by walked OID, then by port
+}
+
+func (r *uniPortRepository) Get(_ context.Context, oids []string) (*gosnmp.SnmpPacket, error) {
+	return &gosnmp.SnmpPacket{Variables: []gosnmp.SnmpPDU{{Name: oids[0], Type: gosnmp.NoSuchInstance}}}, nil
+}
+
+func (r *uniPortRepository) Walk(_ context.Context, oid string, walkFunc func(pdu gosnmp.SnmpPDU) error) error {
+	values, ok := r.ports[oid]
+	if !ok {
+		return walkFunc(gosnmp.SnmpPDU{Name: oid + ".1", Type: gosnmp.OctetString, Value: []byte("customer-1")})
+	}
+	for port, value := range values {
+		if err := walkFunc(gosnmp.SnmpPDU{Name: oid + "." + strconv.Itoa(port), Type: gosnmp.Integer, Value: value}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestGetByBoardIDPonIDAndOnuIDUniPorts(t *testing.T) {
+	baseOID := ".1.3.6.1.4.1.3902.1082"
+	speedOID := ".500.10.2.3.20.1.3.285278465"
+	duplexOID := ".500.10.2.3.20.1.4.285278465"
+	repo := &uniPortRepository{ports: map[string]map[int]interface{}{
+		baseOID + speedOID + ".1":  {2: 2, 1: 3, 3: 0},
+		baseOID + duplexOID + ".1": {1: 2, 2: 1, 3: 0},
+	}}
+
+	tests := []struct {
+		name      string
+		collect   bool
+		duplexOID string
+		expected  []model.UniPort
+	}{
+		{name: "Disabled", collect: false, duplexOID: duplexOID},
+		{name: "Speed and duplex", collect: true, duplexOID: duplexOID, expected: []model.UniPort{
+			{Port: 1, SpeedMbps: 1000, Duplex: "full"},
+			{Port: 2, SpeedMbps: 100, Duplex: "half"},
+			{Port: 3},
+		}},
+		{name: "Speed only", collect: true, expected: []model.UniPort{
+			{Port: 1, SpeedMbps: 1000},
+			{Port: 2, SpeedMbps: 100},
+			{Port: 3},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				OltCfg: config.OltConfig{BaseOID1: baseOID, CollectUniPorts: tt.collect},
+				Pons: map[int]map[int]model.OltConfig{1: {1: {
+					OnuIDNameOID:    ".500.10.2.3.3.1.2.285278465",
+					OnuUniSpeedOID:  speedOID,
+					OnuUniDuplexOID: tt.duplexOID,
+				}}},
+			}
+
+			onu, err := NewOnuUsecase(repo, nil, cfg).GetByBoardIDPonIDAndOnuID(context.Background(), 1, 1, 1)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, onu.UniPorts)
+		})
+	}
+}
+
+// statusTableRepository walks the given ONU statuses under the status OID and ONU names under any
+// other OID, recording every walked and requested OID.
+type statusTableRepository struct {
+	statusOID string
+	statuses  map[int]int // keyed by ONU ID
+	mu        sync.Mutex
+	walks     []string
+	gets      []string
+}
+
+func (r *statusTableRepository) Get(_ context.Context, oids []string) (*gosnmp.SnmpPacket, error) {
+	r.mu.Lock()
+	r.gets = append(r.gets, oids[0])
+	r.mu.Unlock()
+	return &gosnmp.SnmpPacket{Variables: []gosnmp.SnmpPDU{{Name: oids[0], Type: gosnmp.NoSuchInstance}}}, nil
+}
+
+func (r *statusTableRepository) Walk(_ context.Context, oid string, walkFunc func(pdu gosnmp.SnmpPDU) error) error {
+	r.mu.Lock()
+	r.walks = append(r.walks, oid)
+	r.mu.Unlock()
+
+	for id, status := range r.statuses {
+		pdu := gosnmp.SnmpPDU{Name: oid + "." + strconv.Itoa(id), Type: gosnmp.OctetString, Value: []byte("onu")}
+		if oid == r.statusOID {
+			pdu.Type, pdu.Value = gosnmp.Integer, status
+		}
+		if err := walkFunc(pdu); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestGetStatusesByBoardPon(t *testing.T) {
+	baseOID := ".1.3.6.1.4.1.3902.1082"
+	statusOID := ".500.10.2.3.8.1.4.285278465"
+	repo := &statusTableRepository{statusOID: baseOID + statusOID, statuses: map[int]int{1: 4, 2: 2, 7: 5, 9: 42}}
+	cfg := &config.Config{
+		OltCfg: config.OltConfig{BaseOID1: baseOID},
+		Pons:   map[int]map[int]model.OltConfig{1: {1: {OnuIDNameOID: ".500.10.2.3.3.1.2.285278465", OnuStatusOID: statusOID}}},
+	}
+
+	statuses, err := NewOnuUsecase(repo, nil, cfg).GetStatusesByBoardPon(context.Background(), 1, 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[int]string{1: "Online", 2: "LOS", 7: "Dying Gasp", 9: "Unknown"}, statuses)
+	assert.Equal(t, []string{baseOID + statusOID}, repo.walks, "the status OID is walked once")
+	assert.Empty(t, repo.gets, "no per-ONU requests are made")
+}
+
+func TestGetByBoardIDAndPonIDStatusWalk(t *testing.T) {
+	baseOID := ".1.3.6.1.4.1.3902.1082"
+	statusOID := ".500.10.2.3.8.1.4.285278465"
+
+	for _, statusWalk := range []bool{false, true} {
+		t.Run("status_walk="+strconv.FormatBool(statusWalk), func(t *testing.T) {
+			repo := &statusTableRepository{statusOID: baseOID + statusOID, statuses: map[int]int{1: 4, 2: 2}}
+			cfg := &config.Config{
+				OltCfg: config.OltConfig{BaseOID1: baseOID, StatusWalk: statusWalk},
+				Pons:   map[int]map[int]model.OltConfig{1: {1: {OnuIDNameOID: ".500.10.2.3.3.1.2.285278465", OnuStatusOID: statusOID}}},
+			}
+
+			onus, err := NewOnuUsecase(repo, nil, cfg).GetByBoardIDAndPonID(context.Background(), 1, 1)
+			require.NoError(t, err)
+			require.Len(t, onus, 2)
+
+			statusGets := 0
+			for _, oid := range repo.gets {
+				if strings.HasPrefix(oid, baseOID+statusOID+".") {
+					statusGets++
+				}
+			}
+			if statusWalk {
+				assert.Equal(t, "Online", onus[0].Status)
+				assert.Equal(t, "LOS", onus[1].Status)
+				assert.Zero(t, statusGets, "statuses come from the walk")
+			} else {
+				assert.Equal(t, 2, statusGets, "one status request per ONU")
+			}
+		})
+	}
+}
+
+func TestGetBoardConfigMatchesGolden(t *testing.T) {
+	// The golden file holds the output of the former per-board switch for the same config file
+	data, err := os.ReadFile("testdata/olt_configs.golden.json")
+	require.NoError(t, err)
+	var golden map[string]model.OltConfig
+	require.NoError(t, json.Unmarshal(data, &golden))
+
+	cfg, err := config.LoadConfig("testdata/olt_configs")
+	require.NoError(t, err)
+	u := &onuUsecase{cfg: cfg}
+
+	for boardID := 1; boardID <= 2; boardID++ {
+		for ponID := 1; ponID <= 16; ponID++ {
+			key := fmt.Sprintf("%d/%d", boardID, ponID)
+			t.Run(key, func(t *testing.T) {
+				oltConfig, err := u.getBoardConfig(boardID, ponID)
+				require.NoError(t, err)
+				assert.Equal(t, golden[key], *oltConfig)
+			})
+		}
+	}
+
+	_, err = u.getBoardConfig(3, 1)
+	assert.Error(t, err, "unknown board")
+	_, err = u.getBoardConfig(1, 17)
+	assert.Error(t, err, "unknown PON")
+}
+
+func TestInvalidPonIDReturnsError(t *testing.T) {
+	cfg := &config.Config{
+		OltCfg: config.OltConfig{BaseOID1: ".1.3.6.1.4.1.3902.1082"},
+		Pons:   make(map[int]map[int]model.OltConfig),
+	}
+	for boardID := 1; boardID <= 2; boardID++ {
+		cfg.Pons[boardID] = make(map[int]model.OltConfig)
+		for ponID := 1; ponID <= 16; ponID++ {
+			cfg.Pons[boardID][ponID] = model.OltConfig{OnuIDNameOID: ".500.10.2.3.3.1.2.285278465", OnuStatusOID: ".500.10.2.3.8.1.4.285278465"}
+		}
+	}
+
+	for boardID := 1; boardID <= 2; boardID++ {
+		for _, ponID := range []int{0, 17, -1} {
+			t.Run(fmt.Sprintf("board %d pon %d", boardID, ponID), func(t *testing.T) {
+				u := NewOnuUsecase(&fakeSnmpRepository{}, nil, cfg)
+
+				_, err := u.GetByBoardIDAndPonID(context.Background(), boardID, ponID)
+				assert.ErrorContains(t, err, "invalid PON ID")
+				_, err = u.GetByBoardIDPonIDAndOnuID(context.Background(), boardID, ponID, 1)
+				assert.ErrorContains(t, err, "invalid PON ID")
+				_, err = u.GetStatusesByBoardPon(context.Background(), boardID, ponID)
+				assert.ErrorContains(t, err, "invalid PON ID")
+				_, err = u.GetEmptyOnuID(context.Background(), boardID, ponID)
+				assert.ErrorContains(t, err, "invalid PON ID")
+				_, err = u.GetOnuIDAndSerialNumber(boardID, ponID)
+				assert.ErrorContains(t, err, "invalid PON ID")
+				onus, count := u.GetByBoardIDAndPonIDWithPagination(boardID, ponID, 1, 10)
+				assert.Empty(t, onus)
+				assert.Zero(t, count)
+			})
+		}
+	}
+}
+
+func TestGetEmptyOnuIDMaxOnuPerPon(t *testing.T) {
+	tests := []struct {
+		name          string
+		maxOnuPerPon  int
+		expectedCount int
+		expectedLast  int
+	}{
+		{name: "64 ONUs per PON", maxOnuPerPon: 64, expectedCount: 61, expectedLast: 63},
+		{name: "128 ONUs per PON when unset", maxOnuPerPon: 0, expectedCount: 124, expectedLast: 128},
+		{name: "256 ONUs per PON", maxOnuPerPon: 256, expectedCount: 251, expectedLast: 256},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				OltCfg: config.OltConfig{BaseOID1: ".1.3.6.1.4.1.3902.1082", MaxOnuPerPon: tt.maxOnuPerPon},
+				Pons:   map[int]map[int]model.OltConfig{1: {1: {OnuIDNameOID: ".500.10.2.3.3.1.2.285278465"}}},
+			}
+			u := NewOnuUsecase(&fakeSnmpRepository{onuIDs: []int{1, 2, 64, 100, 200}}, nil, cfg)
+
+			emptyOnuIDs, err := u.GetEmptyOnuID(context.Background(), 1, 1)
+			require.NoError(t, err)
+			require.Len(t, emptyOnuIDs, tt.expectedCount)
+			assert.Equal(t, 3, emptyOnuIDs[0].ID)
+			assert.Equal(t, tt.expectedLast, emptyOnuIDs[len(emptyOnuIDs)-1].ID)
+			for _, onuID := range emptyOnuIDs {
+				assert.NotContains(t, []int{1, 2, 64, 100, 200}, onuID.ID)
+			}
+
+			assert.NoError(t, u.UpdateEmptyOnuID(context.Background(), 1, 1))
+		})
+	}
+}
+
+// fakeCacheRepository keeps ONU lists and empty ONU IDs in memory, counting the lists it was given.
+// Caching empty ONU IDs fails with setErr, when set.
+type fakeCacheRepository struct {
+	lists    map[string][]model.ONUInfoPerBoard
+	emptyIDs map[string][]model.OnuID
+	sets     int
+	ttl      time.Duration
+	setErr   error
+}
+
+func (f *fakeCacheRepository) GetONUList(boardID, ponID int) ([]model.ONUInfoPerBoard, error) {
+	onuList, ok := f.lists[fmt.Sprintf("%d/%d", boardID, ponID)]
+	if !ok {
+		return nil, repository.ErrCacheMiss
+	}
+	return onuList, nil
+}
+
+func (f *fakeCacheRepository) SetONUList(boardID, ponID int, onuList []model.ONUInfoPerBoard, ttl time.Duration) error {
+	f.lists[fmt.Sprintf("%d/%d", boardID, ponID)] = onuList
+	f.sets++
+	f.ttl = ttl
+	return nil
+}
+
+func (f *fakeCacheRepository) GetEmptyOnuIDList(boardID, ponID int) ([]model.OnuID, error) {
+	emptyOnuIDList, ok := f.emptyIDs[fmt.Sprintf("%d/%d", boardID, ponID)]
+	if !ok {
+		return nil, repository.ErrCacheMiss
+	}
+	return emptyOnuIDList, nil
+}
+
+func (f *fakeCacheRepository) SetEmptyOnuIDList(boardID, ponID int, emptyOnuIDList []model.OnuID, ttl time.Duration) error {
+	if f.setErr != nil {
+		return f.setErr
+	}
+	f.emptyIDs[fmt.Sprintf("%d/%d", boardID, ponID)] = emptyOnuIDList
+	f.sets++
+	f.ttl = ttl
+	return nil
+}
+
+func TestUpdateEmptyOnuIDCache(t *testing.T) {
+	repo := &fakeSnmpRepository{onuIDs: []int{1, 3, 4}}
+	cache := &fakeCacheRepository{emptyIDs: make(map[string][]model.OnuID)}
+	cfg := &config.Config{
+		OltCfg:   config.OltConfig{BaseOID1: ".1.3.6.1.4.1.3902.1082", MaxOnuPerPon: 6},
+		RedisCfg: config.RedisConfig{Enabled: true, TTL: time.Minute},
+		Pons:     map[int]map[int]model.OltConfig{1: {1: {OnuIDNameOID: ".500.10.2.3.3.1.2.285278465"}}},
+	}
+	u := NewOnuUsecase(repo, cache, cfg)
+
+	require.NoError(t, u.UpdateEmptyOnuID(context.Background(), 1, 1))
+	assert.Equal(t, []model.OnuID{{Board: 1, PON: 1, ID: 2}, {Board: 1, PON: 1, ID: 5}, {Board: 1, PON: 1, ID: 6}}, cache.emptyIDs["1/1"])
+	assert.Equal(t, time.Minute, cache.ttl)
+
+	// The cached IDs are served until the next update, even once an ONU took one of them.
+	repo.onuIDs = append(repo.onuIDs, 5)
+	emptyOnuIDs, err := u.GetEmptyOnuID(context.Background(), 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 5, 6}, onuIDs(emptyOnuIDs))
+
+	require.NoError(t, u.UpdateEmptyOnuID(context.Background(), 1, 1))
+	emptyOnuIDs, err = u.GetEmptyOnuID(context.Background(), 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 6}, onuIDs(emptyOnuIDs))
+	assert.Equal(t, 2, cache.sets)
+
+	cache.setErr = errors.New("connection refused")
+	assert.ErrorContains(t, u.UpdateEmptyOnuID(context.Background(), 1, 1), "connection refused", "a failed update is reported")
+}
+
+func TestGetEmptyOnuIDFillsCache(t *testing.T) {
+	cache := &fakeCacheRepository{emptyIDs: make(map[string][]model.OnuID)}
+	cfg := &config.Config{
+		OltCfg:   config.OltConfig{BaseOID1: ".1.3.6.1.4.1.3902.1082", MaxOnuPerPon: 4},
+		RedisCfg: config.RedisConfig{Enabled: true, TTL: time.Minute},
+		Pons:     map[int]map[int]model.OltConfig{1: {1: {OnuIDNameOID: ".500.10.2.3.3.1.2.285278465"}}},
+	}
+
+	emptyOnuIDs, err := NewOnuUsecase(&fakeSnmpRepository{onuIDs: []int{2}}, cache, cfg).GetEmptyOnuID(context.Background(), 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 3, 4}, onuIDs(emptyOnuIDs))
+	assert.Equal(t, emptyOnuIDs, cache.emptyIDs["1/1"], "a cache miss is filled from the OLT")
+}
+
+func TestUpdateEmptyOnuIDWithoutCache(t *testing.T) {
+	u := NewOnuUsecase(&fakeSnmpRepository{}, nil, &config.Config{})
+
+	assert.NoError(t, u.UpdateEmptyOnuID(context.Background(), 1, 1), "without a cache the OLT, here unconfigured, is not read")
+}
+
+// onuIDs returns the IDs of the given ONUs.
+func onuIDs(onus []model.OnuID) []int {
+	ids := make([]int, 0, len(onus))
+	for _, onu := range onus {
+		ids = append(ids, onu.ID)
+	}
+	return ids
+}
+
+func TestGetByBoardIDAndPonIDWithPaginationCache(t *testing.T) {
+	repo := &fakeSnmpRepository{
+		onuIDs:  []int{1, 2, 3},
+		serials: map[string]string{"1": "ZTEGC0000001", "2": "ZTEGC0000002", "3": "ZTEGC0000003"},
+	}
+	cache := &fakeCacheRepository{lists: make(map[string][]model.ONUInfoPerBoard)}
+	cfg := &config.Config{
+		OltCfg:   config.OltConfig{BaseOID1: ".1.3.6.1.4.1.3902.1082", BaseOID2: ".1.3.6.1.4.1.3902.1012"},
+		RedisCfg: config.RedisConfig{Enabled: true, TTL: time.Minute},
+		Pons: map[int]map[int]model.OltConfig{1: {1: {
+			OnuIDNameOID:       ".500.10.2.3.3.1.2.285278465",
+			OnuSerialNumberOID: ".500.10.2.3.3.1.18.285278465",
+		}}},
+	}
+	u := NewOnuUsecase(repo, cache, cfg)
+
+	// A cold cache is filled with the whole PON, the page is served from it.
+	onus, count := u.GetByBoardIDAndPonIDWithPagination(1, 1, 1, 2)
+	assert.Equal(t, 3, count)
+	require.Len(t, onus, 2)
+	assert.Equal(t, "ZTEGC0000002", onus[1].SerialNumber)
+	assert.Equal(t, 1, cache.sets)
+	assert.Equal(t, time.Minute, cache.ttl)
+	require.Len(t, cache.lists["1/1"], 3)
+
+	// A warm cache whose serial numbers match the OLT is served as is.
+	cache.lists["1/1"][2].Name = "cached"
+	onus, count = u.GetByBoardIDAndPonIDWithPagination(1, 1, 2, 2)
+	assert.Equal(t, 3, count)
+	require.Len(t, onus, 1)
+	assert.Equal(t, "cached", onus[0].Name)
+	assert.Equal(t, 1, cache.sets, "a valid cache is not written again")
+
+	// Once an ONU was replaced on the OLT the cached list is read again.
+	repo.serials["3"] = "ZTEGC0000042"
+	onus, _ = u.GetByBoardIDAndPonIDWithPagination(1, 1, 2, 2)
+	require.Len(t, onus, 1)
+	assert.Equal(t, "ZTEGC0000042", onus[0].SerialNumber)
+	assert.NotEqual(t, "cached", onus[0].Name)
+	assert.Equal(t, 2, cache.sets)
+}
+
+// batchGetRepository walks the configured ONU IDs in the given order and answers every OID of a
+// Get, counting the Gets. Gets of more than failAbove OIDs fail, when set.
+type batchGetRepository struct {
+	onuIDs    []int
+	failAbove int
+	mu        sync.Mutex
+	gets      int
+}
+
+func (r *batchGetRepository) Get(_ context.Context, oids []string) (*gosnmp.SnmpPacket, error) {
+	r.mu.Lock()
+	r.gets++
+	r.mu.Unlock()
+	if len(oids) > gosnmp.MaxOids {
+		return nil, fmt.Errorf("too many OIDs: %d", len(oids))
+	}
+	if r.failAbove > 0 && len(oids) > r.failAbove {
+		return nil, fmt.Errorf("tooBig")
+	}
+
+	packet := &gosnmp.SnmpPacket{}
+	for _, oid := range oids {
+		onuID := oid
+		if strings.Contains(oid, ".500.20.2.2.2.1.10.") {
+			onuID = strings.TrimSuffix(onuID, ".1") // RX power is read from the first instance
+		}
+		onuID = onuID[strings.LastIndex(onuID, ".")+1:]
+		switch {
+		case strings.Contains(oid, ".500.10.2.3.3.1.18."):
+			packet.Variables = append(packet.Variables, gosnmp.SnmpPDU{Name: oid, Type: gosnmp.OctetString, Value: []byte("1,ZTEGC" + onuID)})
+		case strings.Contains(oid, ".500.10.2.3.8.1.4."):
+			packet.Variables = append(packet.Variables, gosnmp.SnmpPDU{Name: oid, Type: gosnmp.Integer, Value: 4})
+		default:
+			packet.Variables = append(packet.Variables, gosnmp.SnmpPDU{Name: oid, Type: gosnmp.OctetString, Value: []byte("onu-" + onuID)})
+		}
+	}
+	return packet, nil
+}
+
+func (r *batchGetRepository) Walk(_ context.Context, oid string, walkFunc func(pdu gosnmp.SnmpPDU) error) error {
+	for _, id := range r.onuIDs {
+		if err := walkFunc(gosnmp.SnmpPDU{Name: oid + "." + strconv.Itoa(id), Type: gosnmp.OctetString, Value: []byte("onu")}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newPaginationConfig() *config.Config {
+	return &config.Config{
+		OltCfg: config.OltConfig{BaseOID1: ".1.3.6.1.4.1.3902.1082", BaseOID2: ".1.3.6.1.4.1.3902.1012"},
+		Pons: map[int]map[int]model.OltConfig{1: {1: {
+			OnuIDNameOID:       ".500.10.2.3.3.1.2.285278465",
+			OnuTypeOID:         ".3.50.11.2.1.17.268501248",
+			OnuSerialNumberOID: ".500.10.2.3.3.1.18.285278465",
+			OnuRxPowerOID:      ".500.20.2.2.2.1.10.285278465",
+			OnuStatusOID:       ".500.10.2.3.8.1.4.285278465",
+		}}},
+	}
+}
+
+func TestGetByBoardIDAndPonIDWithPaginationBatchedGets(t *testing.T) {
+	tests := []struct {
+		name         string
+		failAbove    int
+		expectedGets int
+	}{
+		// 30 ONUs of 5 attributes each took 150 single-OID Gets, they now fit in 3 Gets of at most 60 OIDs.
+		{name: "Batched", expectedGets: 3},
+		// Every batch the OLT rejects costs one more Get, then one Get per OID like before.
+		{name: "Batches rejected", failAbove: 1, expectedGets: 3 + 150},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &batchGetRepository{failAbove: tt.failAbove}
+			for id := 30; id >= 1; id-- {
+				repo.onuIDs = append(repo.onuIDs, id)
+			}
+
+			onus, count := NewOnuUsecase(repo, nil, newPaginationConfig()).GetByBoardIDAndPonIDWithPagination(1, 1, 1, 30)
+
+			assert.Equal(t, tt.expectedGets, repo.gets)
+			assert.Equal(t, 30, count)
+			require.Len(t, onus, 30)
+			for i, onu := range onus {
+				assert.Equal(t, i+1, onu.ID, "the page is sorted by ONU ID")
+				assert.Equal(t, "onu-"+strconv.Itoa(onu.ID), onu.Name)
+				assert.Equal(t, "ZTEGC"+strconv.Itoa(onu.ID), onu.SerialNumber)
+				assert.Equal(t, "Online", onu.Status)
+			}
+		})
+	}
+}
+
+func BenchmarkGetByBoardIDAndPonIDWithPagination(b *testing.B) {
+	repo := &batchGetRepository{}
+	for id := 1; id <= 128; id++ {
+		repo.onuIDs = append(repo.onuIDs, id)
+	}
+	u := NewOnuUsecase(repo, nil, newPaginationConfig())
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		u.GetByBoardIDAndPonIDWithPagination(1, 1, 1, 100)
+	}
+	b.ReportMetric(float64(repo.gets)/float64(b.N), "gets/op")
+}
+
+// tableRepository answers Gets and walks from a fixed table of OIDs, counting the requests. A walk
+// of a leaf returns the leaf, like gosnmp does. Walks of failingWalk fail.
+type tableRepository struct {
+	pdus        []gosnmp.SnmpPDU
+	failingWalk string
+	mu          sync.Mutex
+	gets        int
+	walks       int
+}
+
+func (r *tableRepository) add(oid string, valueType gosnmp.Asn1BER, value interface{}) {
+	r.pdus = append(r.pdus, gosnmp.SnmpPDU{Name: oid, Type: valueType, Value: value})
+}
+
+func (r *tableRepository) Get(_ context.Context, oids []string) (*gosnmp.SnmpPacket, error) {
+	r.mu.Lock()
+	r.gets++
+	r.mu.Unlock()
+	packet := &gosnmp.SnmpPacket{}
+	for _, oid := range oids {
+		pdu := gosnmp.SnmpPDU{Name: oid, Type: gosnmp.NoSuchInstance}
+		for _, candidate := range r.pdus {
+			if candidate.Name == oid {
+				pdu = candidate
+			}
+		}
+		packet.Variables = append(packet.Variables, pdu)
+	}
+	return packet, nil
+}
+
+func (r *tableRepository) Walk(_ context.Context, oid string, walkFunc func(pdu gosnmp.SnmpPDU) error) error {
+	r.mu.Lock()
+	r.walks++
+	r.mu.Unlock()
+	if oid == r.failingWalk {
+		return errors.New("request timeout")
+	}
+	for _, pdu := range r.pdus {
+		if pdu.Name == oid || strings.HasPrefix(pdu.Name, oid+".") {
+			if err := walkFunc(pdu); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// newPonTable returns the config of a PON with most attributes configured, and a table of its
+// ONUs 1 to 8, some of them missing some attributes. No ONU reports its last online and offline
+// time, which would make the uptime depend on the time of the request.
+func newPonTable() (*config.Config, *tableRepository) {
+	baseOID1 := ".1.3.6.1.4.1.3902.1082"
+	baseOID2 := ".1.3.6.1.4.1.3902.1012"
+	oltConfig := model.OltConfig{
+		OnuIDNameOID:              ".500.10.2.3.3.1.2.285278465",
+		OnuTypeOID:                ".3.50.11.2.1.17.268501248",
+		OnuSerialNumberOID:        ".500.10.2.3.3.1.18.285278465",
+		OnuRxPowerOID:             ".500.20.2.2.2.1.10.285278465",
+		OnuTxPowerOID:             ".3.50.12.1.1.14.268501248",
+		OnuStatusOID:              ".500.10.2.3.8.1.4.285278465",
+		OnuIPAddressOID:           ".3.50.16.1.1.10.268501248",
+		OnuDescriptionOID:         ".500.10.2.3.3.1.3.285278465",
+		OnuLastOnlineOID:          ".500.10.2.3.8.1.5.285278465",
+		OnuLastOfflineOID:         ".500.10.2.3.8.1.6.285278465",
+		OnuLastOfflineReasonOID:   ".500.10.2.3.8.1.7.285278465",
+		OnuGponOpticalDistanceOID: ".500.10.2.3.10.1.2.285278465",
+		OnuLosFlagOID:             ".500.10.2.3.8.1.30.285278465",
+		OnuDownstreamOctetsOID:    ".500.20.2.1.1.1.4.285278465",
+		OnuUniSpeedOID:            ".500.20.5.1.1.1.4.285278465",
+		OnuUniDuplexOID:           ".500.20.5.1.1.1.5.285278465",
+	}
+	cfg := &config.Config{
+		OltCfg: config.OltConfig{BaseOID1: baseOID1, BaseOID2: baseOID2, CollectUniPorts: true},
+		Pons:   map[int]map[int]model.OltConfig{2: {9: oltConfig}},
+	}
+
+	repo := &tableRepository{}
+	for id := 1; id <= 8; id++ {
+		onuID := strconv.Itoa(id)
+		repo.add(baseOID1+oltConfig.OnuIDNameOID+"."+onuID, gosnmp.OctetString, []byte("customer-"+onuID))
+		repo.add(baseOID2+oltConfig.OnuTypeOID+"."+onuID, gosnmp.OctetString, []byte("F660V6"))
+		repo.add(baseOID1+oltConfig.OnuSerialNumberOID+"."+onuID, gosnmp.OctetString, []byte("1,ZTEGC000000"+onuID))
+		repo.add(baseOID1+oltConfig.OnuStatusOID+"."+onuID, gosnmp.Integer, []int{4, 4, 2, 5}[id%4])
+		if id != 3 {
+			repo.add(baseOID1+oltConfig.OnuRxPowerOID+"."+onuID+".1", gosnmp.Integer, 18000+id*100)
+			repo.add(baseOID2+oltConfig.OnuTxPowerOID+"."+onuID+".1", gosnmp.Integer, 1200+id)
+		}
+		repo.add(baseOID2+oltConfig.OnuIPAddressOID+"."+onuID, gosnmp.IPAddress, "10.0.0."+onuID)
+		repo.add(baseOID1+oltConfig.OnuDescriptionOID+"."+onuID, gosnmp.OctetString, []byte("site "+onuID))
+		repo.add(baseOID1+oltConfig.OnuLastOfflineReasonOID+"."+onuID, gosnmp.Integer, id%10)
+		repo.add(baseOID1+oltConfig.OnuGponOpticalDistanceOID+"."+onuID, gosnmp.Integer, 1000+id)
+		repo.add(baseOID1+oltConfig.OnuLosFlagOID+"."+onuID, gosnmp.Integer, []int{1, 2}[id%2])
+		if id%2 == 0 {
+			repo.add(baseOID1+oltConfig.OnuDownstreamOctetsOID+"."+onuID, gosnmp.Counter64, uint64(id)*1000)
+		}
+		for port := 1; port <= id%3; port++ {
+			repo.add(baseOID1+oltConfig.OnuUniSpeedOID+"."+onuID+"."+strconv.Itoa(port), gosnmp.Integer, 4)
+			repo.add(baseOID1+oltConfig.OnuUniDuplexOID+"."+onuID+"."+strconv.Itoa(port), gosnmp.Integer, 1)
+		}
+	}
+	return cfg, repo
+}
+
+func TestGetAllDetailsByBoardPonMatchesSingleOnu(t *testing.T) {
+	cfg, repo := newPonTable()
+
+	var expected []model.ONUCustomerInfo
+	for id := 1; id <= 8; id++ {
+		onu, err := NewOnuUsecase(repo, nil, cfg).GetByBoardIDPonIDAndOnuID(context.Background(), 2, 9, id)
+		require.NoError(t, err)
+		expected = append(expected, onu)
+	}
+	repo.gets, repo.walks = 0, 0
+
+	onus, err := NewOnuUsecase(repo, nil, cfg).GetAllDetailsByBoardPon(context.Background(), 2, 9)
+
+	require.NoError(t, err)
+	assert.Equal(t, expected, onus)
+	assert.Equal(t, "LOS", onus[3].Status, "the LOS flag is reconciled like for a single ONU")
+	assert.Empty(t, onus[2].RXPower, "an attribute the OLT does not report is left empty")
+	assert.Len(t, onus[4].UniPorts, 2)
+	assert.Zero(t, repo.gets, "every attribute is read from its walked column")
+	assert.Equal(t, 16, repo.walks, "every configured attribute OID is walked once")
+}
+
+func TestGetAllDetailsByBoardPonWalkErrors(t *testing.T) {
+	t.Run("Attribute column", func(t *testing.T) {
+		cfg, repo := newPonTable()
+		repo.failingWalk = cfg.OltCfg.BaseOID1 + cfg.Pons[2][9].OnuDescriptionOID
+
+		onus, err := NewOnuUsecase(repo, nil, cfg).GetAllDetailsByBoardPon(context.Background(), 2, 9)
+
+		require.NoError(t, err)
+		require.Len(t, onus, 8)
+		for _, onu := range onus {
+			assert.Empty(t, onu.Description, "the attribute of a failed walk is left empty")
+			assert.NotEmpty(t, onu.SerialNumber)
+		}
+	})
+
+	t.Run("ONU name column", func(t *testing.T) {
+		cfg, repo := newPonTable()
+		repo.failingWalk = cfg.OltCfg.BaseOID1 + cfg.Pons[2][9].OnuIDNameOID
+
+		_, err := NewOnuUsecase(repo, nil, cfg).GetAllDetailsByBoardPon(context.Background(), 2, 9)
+
+		assert.EqualError(t, err, "failed to walk OID")
+	})
+}
+
+// flakyWalkRepository answers from its table, except for the first failures walks of failingWalk,
+// which time out, or fail with err when set.
+type flakyWalkRepository struct {
+	*tableRepository
+	failingWalk string
+	failures    int
+	err         error
+}
+
+func (r *flakyWalkRepository) Walk(ctx context.Context, oid string, walkFunc func(pdu gosnmp.SnmpPDU) error) error {
+	if oid == r.failingWalk && r.failures > 0 {
+		r.failures--
+		// The walk fails halfway, after handing over the first ONU
+		if err := r.tableRepository.Walk(ctx, oid+".1", walkFunc); err != nil {
+			return err
+		}
+		return cmp.Or(r.err, errors.New("request timeout (after 3 retries)"))
+	}
+	return r.tableRepository.Walk(ctx, oid, walkFunc)
+}
+
+func TestGetByBoardIDAndPonIDDiscoveryRetry(t *testing.T) {
+	tests := []struct {
+		name          string
+		retries       int
+		failures      int
+		err           error
+		expectedErr   string
+		expectedWalks int
+	}{
+		{name: "Walk failing once", retries: 2, failures: 1, expectedWalks: 2},
+		{name: "Walk failing on every retry", retries: 2, failures: 3, expectedErr: "request timeout (after 3 retries)", expectedWalks: 3},
+		{name: "Not retried by default", failures: 1, expectedErr: "request timeout (after 3 retries)", expectedWalks: 1},
+		{name: "Permanent error", retries: 2, failures: 1, err: gosnmp.ErrUnknownUsername, expectedErr: gosnmp.ErrUnknownUsername.Error(), expectedWalks: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, table := newPonTable()
+			cfg.OltCfg.DiscoveryRetries = tt.retries
+			cfg.OltCfg.DiscoveryRetryBackoff = time.Millisecond
+			repo := &flakyWalkRepository{
+				tableRepository: table,
+				failingWalk:     cfg.OltCfg.BaseOID1 + cfg.Pons[2][9].OnuIDNameOID,
+				failures:        tt.failures,
+				err:             tt.err,
+			}
+
+			onus, err := NewOnuUsecase(repo, nil, cfg).GetByBoardIDAndPonID(context.Background(), 2, 9)
+
+			assert.Equal(t, tt.expectedWalks, table.walks)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, onus, 8, "the ONUs of the PON are all discovered")
+			for i, onu := range onus {
+				assert.Equal(t, i+1, onu.ID)
+				assert.Equal(t, "ZTEGC000000"+strconv.Itoa(onu.ID), onu.SerialNumber)
+			}
+		})
+	}
+}
+
+func TestGetByBoardIDAndPonIDDiscoveryRetryStopsWithContext(t *testing.T) {
+	cfg, table := newPonTable()
+	cfg.OltCfg.DiscoveryRetries = 3
+	cfg.OltCfg.DiscoveryRetryBackoff = time.Minute
+	repo := &flakyWalkRepository{tableRepository: table, failingWalk: cfg.OltCfg.BaseOID1 + cfg.Pons[2][9].OnuIDNameOID, failures: 1}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := NewOnuUsecase(repo, nil, cfg).GetByBoardIDAndPonID(ctx, 2, 9)
+
+	assert.EqualError(t, err, "request timeout (after 3 retries)")
+	assert.Less(t, time.Since(start), time.Minute, "the backoff is cut short by the context")
+	assert.Equal(t, 1, table.walks)
+}
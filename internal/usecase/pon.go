@@ -0,0 +1,172 @@
+package usecase
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/config"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/repository"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/utils"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/singleflight"
+)
+
+// PonUseCaseInterface is an interface that represent the PON's usecase contract
+type PonUseCaseInterface interface {
+	GetPonTxPowers(ctx context.Context) ([]model.PonTxPower, error)
+	GetPonTransceivers(ctx context.Context) ([]model.PonTransceiver, error)
+	GetPonPort(ctx context.Context, boardID, ponID int) (model.PonPort, error)
+}
+
+// ponUsecase represent the PON's usecase
+type ponUsecase struct {
+	snmpRepository repository.SnmpRepositoryInterface
+	cfg            *config.Config
+	oltConfigs     *onuUsecase // Resolves the per-PON OIDs of a board and PON
+	sg             singleflight.Group
+}
+
+// NewPonUsecase will create an object that represent the PON usecase
+func NewPonUsecase(
+	snmpRepository repository.SnmpRepositoryInterface,
+	cfg *config.Config,
+) PonUseCaseInterface {
+	return &ponUsecase{
+		snmpRepository: snmpRepository,
+		cfg:            cfg,
+		oltConfigs:     &onuUsecase{snmpRepository: snmpRepository, cfg: cfg},
+		sg:             singleflight.Group{},
+	}
+}
+
+// GetPonTxPowers returns the downstream transmit power of every PON port whose pon_tx_power OID is
+// configured, sorted by board and PON. PON ports that cannot be read, e.g. without an optical
+// module, are left out.
+func (u *ponUsecase) GetPonTxPowers(ctx context.Context) ([]model.PonTxPower, error) {
+	result, err, _ := u.sg.Do("pon_tx_power", func() (interface{}, error) {
+		txPowers := make([]model.PonTxPower, 0)
+		err := u.forEachPon(ctx, func(boardID, ponID int, oltConfig model.OltConfig) {
+			if oltConfig.PonTxPowerOID == "" {
+				return
+			}
+
+			txPower, err := u.getPonTxPower(ctx, oltConfig.PonTxPowerOID)
+			if err != nil {
+				log.Debug().Err(err).Int("board", boardID).Int("pon", ponID).Msg("Failed to get PON tx power")
+				return
+			}
+			txPowers = append(txPowers, model.PonTxPower{Board: boardID, PON: ponID, TxPower: txPower})
+		})
+		return txPowers, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.([]model.PonTxPower), nil
+}
+
+func (u *ponUsecase) getPonTxPower(ctx context.Context, PonTxPowerOID string) (string, error) {
+	return u.getPonReading(ctx, PonTxPowerOID, utils.ExtractPonTxPower)
+}
+
+// GetPonPort returns the transmit power and the operational status of a PON port, read from its
+// pon_port_tx_power and pon_port_status OIDs. A reading whose OID is not configured or that cannot
+// be read is left empty, an error is only returned for a board and PON without config.
+func (u *ponUsecase) GetPonPort(ctx context.Context, boardID, ponID int) (model.PonPort, error) {
+	oltConfig, err := u.oltConfigs.getBoardConfig(boardID, ponID)
+	if err != nil {
+		return model.PonPort{}, err
+	}
+
+	ponPort := model.PonPort{Board: boardID, PON: ponID}
+	if oltConfig.PonPortTxPowerOID != "" {
+		txPower, err := u.getPonReading(ctx, oltConfig.PonPortTxPowerOID, utils.ConvertAndMultiply)
+		if err != nil {
+			log.Debug().Err(err).Int("board", boardID).Int("pon", ponID).Msg("Failed to get PON port tx power")
+		}
+		ponPort.TxPower = txPower
+	}
+	if oltConfig.PonPortStatusOID != "" {
+		result, err := u.oltConfigs.getFromSNMPWithSingleflight(ctx, u.cfg.OltCfg.BaseOID1+oltConfig.PonPortStatusOID)
+		if err != nil {
+			log.Debug().Err(err).Int("board", boardID).Int("pon", ponID).Msg("Failed to get PON port status")
+		} else {
+			ponPort.Status = utils.ExtractIfStatus(result.Variables[0].Value) // 0 when not an IF-MIB status, e.g. no such instance
+		}
+	}
+	return ponPort, nil
+}
+
+// GetPonTransceivers returns the digital diagnostics of the optical module of every PON port with
+// at least one pon_transceiver_* OID configured, sorted by board and PON. PON ports none of whose
+// readings can be read, e.g. without an optical module, are left out.
+func (u *ponUsecase) GetPonTransceivers(ctx context.Context) ([]model.PonTransceiver, error) {
+	result, err, _ := u.sg.Do("pon_transceiver", func() (interface{}, error) {
+		transceivers := make([]model.PonTransceiver, 0)
+		err := u.forEachPon(ctx, func(boardID, ponID int, oltConfig model.OltConfig) {
+			transceiver := model.PonTransceiver{Board: boardID, PON: ponID}
+			readings := []struct {
+				oid     string
+				extract func(oidValue interface{}) (string, error)
+				value   *string
+			}{
+				{oltConfig.PonTransceiverTemperatureOID, utils.ExtractTransceiverTemperature, &transceiver.Temperature},
+				{oltConfig.PonTransceiverTxPowerOID, utils.ExtractPonTxPower, &transceiver.TxPower},
+				{oltConfig.PonTransceiverRxPowerOID, utils.ExtractTransceiverRxPower, &transceiver.RxPower},
+				{oltConfig.PonTransceiverBiasOID, utils.ExtractTransceiverBias, &transceiver.Bias},
+				{oltConfig.PonTransceiverVoltageOID, utils.ExtractTransceiverVoltage, &transceiver.Voltage},
+			}
+
+			found := false
+			for _, reading := range readings {
+				if reading.oid == "" {
+					continue
+				}
+				value, err := u.getPonReading(ctx, reading.oid, reading.extract)
+				if err != nil {
+					log.Debug().Err(err).Int("board", boardID).Int("pon", ponID).Str("oid", reading.oid).Msg("Failed to get PON transceiver reading")
+					continue
+				}
+				*reading.value = value
+				found = true
+			}
+			if found {
+				transceivers = append(transceivers, transceiver)
+			}
+		})
+		return transceivers, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.([]model.PonTransceiver), nil
+}
+
+// forEachPon calls fn with the OIDs of every BoardXPonY section of the config, sorted by board and
+// PON, and returns the error of ctx once it is done.
+func (u *ponUsecase) forEachPon(ctx context.Context, fn func(boardID, ponID int, oltConfig model.OltConfig)) error {
+	for _, boardID := range slices.Sorted(maps.Keys(u.cfg.Pons)) {
+		for _, ponID := range slices.Sorted(maps.Keys(u.cfg.Pons[boardID])) {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			fn(boardID, ponID, u.cfg.Pons[boardID][ponID])
+		}
+	}
+	return nil
+}
+
+// getPonReading reads a PON port OID, which already includes the index of the port, and extracts
+// its value.
+func (u *ponUsecase) getPonReading(ctx context.Context, oid string, extract func(oidValue interface{}) (string, error)) (string, error) {
+	result, err := u.oltConfigs.getFromSNMPWithSingleflight(ctx, u.cfg.OltCfg.BaseOID1+oid)
+	if err != nil {
+		return "", err
+	}
+
+	return extract(result.Variables[0].Value)
+}
@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/config"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// getOnlyRepository serves canned Get values keyed by the requested OID.
+type getOnlyRepository struct {
+	values map[string]interface{}
+	gets   []string
+}
+
+func (r *getOnlyRepository) Get(_ context.Context, oids []string) (*gosnmp.SnmpPacket, error) {
+	r.gets = append(r.gets, oids[0])
+	value, ok := r.values[oids[0]]
+	if !ok {
+		return &gosnmp.SnmpPacket{Variables: []gosnmp.SnmpPDU{{Name: oids[0], Type: gosnmp.NoSuchInstance}}}, nil
+	}
+	return &gosnmp.SnmpPacket{Variables: []gosnmp.SnmpPDU{{Name: oids[0], Type: gosnmp.Integer, Value: value}}}, nil
+}
+
+func (r *getOnlyRepository) Walk(_ context.Context, _ string, _ func(pdu gosnmp.SnmpPDU) error) error {
+	return nil
+}
+
+func TestGetPonTxPowers(t *testing.T) {
+	cfg := &config.Config{
+		OltCfg: config.OltConfig{BaseOID1: ".1.3.6.1.4.1.3902.1082"},
+		Pons: map[int]map[int]model.OltConfig{
+			1: {
+				1: {PonTxPowerOID: ".30.40.2.1.4.268501248"},
+				2: {PonTxPowerOID: ".30.40.2.1.4.268501504"},
+			},
+			2: {16: {PonTxPowerOID: ".30.40.2.1.4.268570368"}},
+			3: {20: {PonTxPowerOID: ".30.40.2.1.4.268636160"}}, // Beyond the 2 boards of 16 PONs of a C320
+		},
+	}
+	repo := &getOnlyRepository{values: map[string]interface{}{
+		".1.3.6.1.4.1.3902.1082.30.40.2.1.4.268501248": 3125,
+		".1.3.6.1.4.1.3902.1082.30.40.2.1.4.268570368": 4500,
+		".1.3.6.1.4.1.3902.1082.30.40.2.1.4.268636160": 2750,
+		// PON 1/2 has no optical module and answers with no such instance
+	}}
+
+	txPowers, err := NewPonUsecase(repo, cfg).GetPonTxPowers(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []model.PonTxPower{
+		{Board: 1, PON: 1, TxPower: "3.12"},
+		{Board: 2, PON: 16, TxPower: "4.50"},
+		{Board: 3, PON: 20, TxPower: "2.75"},
+	}, txPowers, "every configured PON, sorted")
+	assert.Len(t, repo.gets, 4, "only PONs with a configured OID are read")
+}
+
+func TestGetPonTransceivers(t *testing.T) {
+	cfg := &config.Config{
+		OltCfg: config.OltConfig{BaseOID1: ".1.3.6.1.4.1.3902.1082"},
+		Pons: map[int]map[int]model.OltConfig{
+			1: {
+				1: {
+					PonTransceiverTemperatureOID: ".30.40.2.1.6.268501248",
+					PonTransceiverTxPowerOID:     ".30.40.2.1.4.268501248",
+					PonTransceiverRxPowerOID:     ".30.40.2.1.5.268501248",
+					PonTransceiverBiasOID:        ".30.40.2.1.7.268501248",
+					PonTransceiverVoltageOID:     ".30.40.2.1.8.268501248",
+				},
+				2: {PonTransceiverTemperatureOID: ".30.40.2.1.6.268501504"},
+				3: {PonTxPowerOID: ".30.40.2.1.4.268501760"},
+			},
+		},
+	}
+	repo := &getOnlyRepository{values: map[string]interface{}{
+		".1.3.6.1.4.1.3902.1082.30.40.2.1.6.268501248": 42500,
+		".1.3.6.1.4.1.3902.1082.30.40.2.1.4.268501248": 3125,
+		".1.3.6.1.4.1.3902.1082.30.40.2.1.5.268501248": -18250,
+		".1.3.6.1.4.1.3902.1082.30.40.2.1.7.268501248": 12345,
+		".1.3.6.1.4.1.3902.1082.30.40.2.1.8.268501248": 65535, // Not available
+		// PON 1/2 has no optical module and answers with no such instance
+	}}
+
+	transceivers, err := NewPonUsecase(repo, cfg).GetPonTransceivers(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []model.PonTransceiver{
+		{Board: 1, PON: 1, Temperature: "42.50", TxPower: "3.12", RxPower: "-18.25", Bias: "12.345"},
+	}, transceivers)
+	assert.Len(t, repo.gets, 6, "only the configured transceiver OIDs are read")
+}
+
+func TestGetPonPort(t *testing.T) {
+	cfg := &config.Config{
+		OltCfg: config.OltConfig{BaseOID1: ".1.3.6.1.4.1.3902.1082"},
+		Pons: map[int]map[int]model.OltConfig{
+			1: {
+				1: {PonPortTxPowerOID: ".30.40.2.1.9.268501248", PonPortStatusOID: ".30.40.2.1.2.268501248"},
+				2: {PonPortTxPowerOID: ".30.40.2.1.9.268501504", PonPortStatusOID: ".30.40.2.1.2.268501504"},
+				3: {PonTxPowerOID: ".30.40.2.1.4.268501760"},
+			},
+		},
+	}
+	repo := &getOnlyRepository{values: map[string]interface{}{
+		".1.3.6.1.4.1.3902.1082.30.40.2.1.9.268501248": 16060,
+		".1.3.6.1.4.1.3902.1082.30.40.2.1.2.268501248": 1,
+		".1.3.6.1.4.1.3902.1082.30.40.2.1.2.268501504": 2,
+		// The tx power of PON 1/2 answers with no such instance
+	}}
+	ponUsecase := NewPonUsecase(repo, cfg)
+
+	ponPort, err := ponUsecase.GetPonPort(context.Background(), 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, model.PonPort{Board: 1, PON: 1, TxPower: "2.12", Status: 1}, ponPort)
+
+	ponPort, err = ponUsecase.GetPonPort(context.Background(), 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, model.PonPort{Board: 1, PON: 2, Status: 2}, ponPort, "an unreadable reading is left empty")
+
+	repo.gets = nil
+	ponPort, err = ponUsecase.GetPonPort(context.Background(), 1, 3)
+	require.NoError(t, err)
+	assert.Equal(t, model.PonPort{Board: 1, PON: 3}, ponPort)
+	assert.Empty(t, repo.gets, "only the configured OIDs are read")
+
+	_, err = ponUsecase.GetPonPort(context.Background(), 2, 1)
+	assert.Error(t, err, "a PON without config")
+}
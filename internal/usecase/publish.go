@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/repository"
+)
+
+// PublishUseCaseInterface is an interface that represent the publish's usecase contract
+type PublishUseCaseInterface interface {
+	PublishOnus(ctx context.Context, onus []model.ONUCustomerInfo) error
+}
+
+// publishUsecase represent the publish's usecase
+type publishUsecase struct {
+	producer repository.ProducerRepository
+}
+
+// NewPublishUsecase will create an object that represent the publish usecase
+func NewPublishUsecase(producer repository.ProducerRepository) PublishUseCaseInterface {
+	return &publishUsecase{
+		producer: producer,
+	}
+}
+
+// PublishOnus produces the details of every ONU as a JSON message keyed by its serial number, in
+// a single call so a collection is published as a whole or reported as failed.
+func (u *publishUsecase) PublishOnus(ctx context.Context, onus []model.ONUCustomerInfo) error {
+	messages := make([]repository.ProducerMessage, 0, len(onus))
+	for _, onu := range onus {
+		value, err := json.Marshal(onu)
+		if err != nil {
+			return fmt.Errorf("encode ONU %s: %w", onu.SerialNumber, err)
+		}
+		messages = append(messages, repository.ProducerMessage{Key: []byte(onu.SerialNumber), Value: value})
+	}
+	return u.producer.Produce(ctx, messages)
+}
+
+// inventoryUsecase represent the usecase publishing the ONUs to the inventory database
+type inventoryUsecase struct {
+	inventory repository.InventoryRepository
+}
+
+// NewInventoryUsecase will create a publish usecase keeping the inventory of the ONUs up to date
+func NewInventoryUsecase(inventory repository.InventoryRepository) PublishUseCaseInterface {
+	return &inventoryUsecase{
+		inventory: inventory,
+	}
+}
+
+// PublishOnus upserts the details of every ONU into the inventory, all seen now.
+func (u *inventoryUsecase) PublishOnus(ctx context.Context, onus []model.ONUCustomerInfo) error {
+	return u.inventory.UpsertOnus(ctx, onus, time.Now())
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeProducer records the messages of every Produce call.
+type fakeProducer struct {
+	calls [][]repository.ProducerMessage
+	err   error
+}
+
+func (p *fakeProducer) Produce(_ context.Context, messages []repository.ProducerMessage) error {
+	p.calls = append(p.calls, messages)
+	return p.err
+}
+
+func TestPublishOnus(t *testing.T) {
+	producer := &fakeProducer{}
+	onus := []model.ONUCustomerInfo{
+		{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001", Status: "Online", RXPower: "-20.5"},
+		{Board: 1, PON: 2, ID: 7, SerialNumber: "ZTEGC0000002", Status: "LOS"},
+	}
+
+	require.NoError(t, NewPublishUsecase(producer).PublishOnus(context.Background(), onus))
+
+	require.Len(t, producer.calls, 1, "a collection is produced in one call")
+	messages := producer.calls[0]
+	require.Len(t, messages, len(onus), "one message per ONU")
+	for i, message := range messages {
+		assert.Equal(t, onus[i].SerialNumber, string(message.Key))
+		var decoded model.ONUCustomerInfo
+		require.NoError(t, json.Unmarshal(message.Value, &decoded))
+		assert.Equal(t, onus[i], decoded)
+	}
+}
+
+func TestPublishOnusError(t *testing.T) {
+	producer := &fakeProducer{err: errors.New("broker down")}
+	err := NewPublishUsecase(producer).PublishOnus(context.Background(), []model.ONUCustomerInfo{{SerialNumber: "ZTEGC0000001"}})
+	assert.EqualError(t, err, "broker down")
+}
+
+// fakeInventory records the ONUs of every UpsertOnus call.
+type fakeInventory struct {
+	onus   [][]model.ONUCustomerInfo
+	seenAt []time.Time
+}
+
+func (i *fakeInventory) UpsertOnus(_ context.Context, onus []model.ONUCustomerInfo, seenAt time.Time) error {
+	i.onus = append(i.onus, onus)
+	i.seenAt = append(i.seenAt, seenAt)
+	return nil
+}
+
+func TestInventoryPublishOnus(t *testing.T) {
+	inventory := &fakeInventory{}
+	onus := []model.ONUCustomerInfo{{Board: 1, PON: 1, ID: 1, SerialNumber: "ZTEGC0000001"}}
+
+	before := time.Now()
+	require.NoError(t, NewInventoryUsecase(inventory).PublishOnus(context.Background(), onus))
+
+	require.Len(t, inventory.onus, 1)
+	assert.Equal(t, onus, inventory.onus[0])
+	assert.WithinRange(t, inventory.seenAt[0], before, time.Now())
+}
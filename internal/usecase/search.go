@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
+	"github.com/rs/zerolog/log"
+)
+
+// searchConcurrency is the number of PONs walked at the same time by a search
+const searchConcurrency = 4
+
+// ErrOnuNotFound is returned by a search that walked every PON without finding the ONU
+var ErrOnuNotFound = errors.New("onu not found")
+
+// SearchUseCaseInterface is an interface that represent the search's usecase contract
+type SearchUseCaseInterface interface {
+	FindBySerialNumber(ctx context.Context, serial string) (model.ONUCustomerInfo, error)
+}
+
+// searchUsecase represent the search's usecase
+type searchUsecase struct {
+	onuUsecase OnuUseCaseInterface
+	scanRange  ScanRangeSource
+}
+
+// NewSearchUsecase will create an object that represent the search usecase, searching the boards
+// and PONs scanned by the Prometheus collector
+func NewSearchUsecase(onuUsecase OnuUseCaseInterface, scanRange ScanRangeSource) SearchUseCaseInterface {
+	return &searchUsecase{
+		onuUsecase: onuUsecase,
+		scanRange:  scanRange,
+	}
+}
+
+// FindBySerialNumber returns the details of the ONU with the given serial number, compared case
+// insensitively. The PONs of the scan range are walked concurrently, and the walks still running
+// are cancelled once the ONU is found. ErrOnuNotFound is only returned when every PON was walked,
+// a search missing the ONU with a PON that could not be walked returns the error of that PON.
+func (u *searchUsecase) FindBySerialNumber(ctx context.Context, serial string) (model.ONUCustomerInfo, error) {
+	serial = strings.TrimSpace(serial)
+
+	// searchCtx is cancelled once the ONU is found, stopping the walks of the other PONs
+	searchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		found   *model.ONUInfoPerBoard
+		walkErr error
+	)
+	pons := make(chan [2]int)
+	for range searchConcurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for pon := range pons {
+				onus, err := u.onuUsecase.GetByBoardIDAndPonID(searchCtx, pon[0], pon[1])
+				if searchCtx.Err() != nil {
+					continue // Found on another PON, or the request was cancelled
+				}
+				if err != nil {
+					log.Warn().Err(err).Int("board", pon[0]).Int("pon", pon[1]).Msg("Failed to search ONUs")
+					mu.Lock()
+					walkErr = err
+					mu.Unlock()
+					continue
+				}
+				for _, onu := range onus {
+					if strings.EqualFold(onu.SerialNumber, serial) {
+						mu.Lock()
+						if found == nil {
+							found = &onu
+						}
+						mu.Unlock()
+						cancel()
+						break
+					}
+				}
+			}
+		}()
+	}
+
+	scanRange := u.scanRange.ScanRange()
+feed:
+	for boardID := scanRange.BoardMin; boardID <= scanRange.BoardMax; boardID++ {
+		for ponID := scanRange.PonMin; ponID <= scanRange.PonMax; ponID++ {
+			select {
+			case pons <- [2]int{boardID, ponID}:
+			case <-searchCtx.Done():
+				break feed
+			}
+		}
+	}
+	close(pons)
+	wg.Wait()
+
+	switch {
+	case found != nil:
+		return u.onuUsecase.GetByBoardIDPonIDAndOnuID(ctx, found.Board, found.PON, found.ID)
+	case ctx.Err() != nil:
+		return model.ONUCustomerInfo{}, ctx.Err()
+	case walkErr != nil:
+		return model.ONUCustomerInfo{}, walkErr
+	default:
+		return model.ONUCustomerInfo{}, ErrOnuNotFound
+	}
+}
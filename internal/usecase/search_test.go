@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// searchOnuUsecase places a single ONU on board 2 PON 9, recording the PONs walked. The walks
+// of the other PONs take walkDelay, or are cut short when their context is cancelled.
+type searchOnuUsecase struct {
+	OnuUseCaseInterface
+	walkDelay time.Duration
+	failing   map[[2]int]bool // PONs whose walk fails
+	mu        sync.Mutex
+	walked    [][2]int
+}
+
+func (f *searchOnuUsecase) GetByBoardIDAndPonID(ctx context.Context, boardID, ponID int) ([]model.ONUInfoPerBoard, error) {
+	f.mu.Lock()
+	f.walked = append(f.walked, [2]int{boardID, ponID})
+	f.mu.Unlock()
+
+	switch {
+	case f.failing[[2]int{boardID, ponID}]:
+		return nil, errors.New("request timeout")
+	case boardID == 2 && ponID == 9:
+		return []model.ONUInfoPerBoard{
+			{Board: 2, PON: 9, ID: 3, SerialNumber: "ZTEGC0000003"},
+			{Board: 2, PON: 9, ID: 12, SerialNumber: "ZTEGC0000012"},
+		}, nil
+	}
+
+	select {
+	case <-time.After(f.walkDelay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return []model.ONUInfoPerBoard{{Board: boardID, PON: ponID, ID: 1, SerialNumber: fmt.Sprintf("ZTEGC%02d%02d0001", boardID, ponID)}}, nil
+}
+
+func (f *searchOnuUsecase) GetByBoardIDPonIDAndOnuID(_ context.Context, boardID, ponID, onuID int) (model.ONUCustomerInfo, error) {
+	return model.ONUCustomerInfo{Board: boardID, PON: ponID, ID: onuID, Name: "customer", Status: "Online"}, nil
+}
+
+// fixedScanRange reports a fixed scan range of the collector.
+type fixedScanRange model.ScanRange
+
+func (r fixedScanRange) ScanRange() model.ScanRange {
+	return model.ScanRange(r)
+}
+
+var defaultScanRange = fixedScanRange{BoardMin: 1, BoardMax: 2, PonMin: 1, PonMax: 16}
+
+func TestFindBySerialNumber(t *testing.T) {
+	onuUsecase := &searchOnuUsecase{}
+	searchUsecase := NewSearchUsecase(onuUsecase, defaultScanRange)
+
+	onuInfo, err := searchUsecase.FindBySerialNumber(context.Background(), " ztegc0000012 ")
+
+	require.NoError(t, err)
+	assert.Equal(t, model.ONUCustomerInfo{Board: 2, PON: 9, ID: 12, Name: "customer", Status: "Online"}, onuInfo)
+}
+
+func TestFindBySerialNumberStopsOnFirstMatch(t *testing.T) {
+	// The other PONs would take far longer than the test to walk, unless their walks are cancelled.
+	// PON 9 is handed out while the first PONs are still walked.
+	onuUsecase := &searchOnuUsecase{walkDelay: time.Minute}
+	searchUsecase := NewSearchUsecase(onuUsecase, fixedScanRange{BoardMin: 2, BoardMax: 2, PonMin: 7, PonMax: 16})
+
+	start := time.Now()
+	onuInfo, err := searchUsecase.FindBySerialNumber(context.Background(), "ZTEGC0000003")
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, onuInfo.ID)
+	assert.Less(t, time.Since(start), 10*time.Second)
+	assert.Less(t, len(onuUsecase.walked), 10, "the PONs after the match are not walked")
+}
+
+func TestFindBySerialNumberNotFound(t *testing.T) {
+	onuUsecase := &searchOnuUsecase{}
+	searchUsecase := NewSearchUsecase(onuUsecase, defaultScanRange)
+
+	_, err := searchUsecase.FindBySerialNumber(context.Background(), "ZTEGC9999999")
+
+	assert.ErrorIs(t, err, ErrOnuNotFound)
+	assert.Len(t, onuUsecase.walked, 32, "every PON of the scan range is walked")
+}
+
+func TestFindBySerialNumberRespectsScanRange(t *testing.T) {
+	onuUsecase := &searchOnuUsecase{}
+	searchUsecase := NewSearchUsecase(onuUsecase, fixedScanRange{BoardMin: 1, BoardMax: 2, PonMin: 1, PonMax: 8})
+
+	_, err := searchUsecase.FindBySerialNumber(context.Background(), "ZTEGC0000003")
+
+	assert.ErrorIs(t, err, ErrOnuNotFound, "board 2 PON 9 is outside the scan range")
+	assert.Len(t, onuUsecase.walked, 16)
+	for _, pon := range onuUsecase.walked {
+		assert.LessOrEqual(t, pon[1], 8)
+	}
+}
+
+func TestFindBySerialNumberWalkErrors(t *testing.T) {
+	t.Run("Not found with a failed PON", func(t *testing.T) {
+		onuUsecase := &searchOnuUsecase{failing: map[[2]int]bool{{1, 4}: true}}
+		searchUsecase := NewSearchUsecase(onuUsecase, defaultScanRange)
+
+		_, err := searchUsecase.FindBySerialNumber(context.Background(), "ZTEGC9999999")
+
+		assert.EqualError(t, err, "request timeout")
+		assert.NotErrorIs(t, err, ErrOnuNotFound, "the ONU may be on the PON that failed")
+	})
+
+	t.Run("Found despite a failed PON", func(t *testing.T) {
+		onuUsecase := &searchOnuUsecase{failing: map[[2]int]bool{{1, 4}: true}}
+		searchUsecase := NewSearchUsecase(onuUsecase, defaultScanRange)
+
+		onuInfo, err := searchUsecase.FindBySerialNumber(context.Background(), "ZTEGC0000003")
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, onuInfo.ID)
+	})
+
+	t.Run("Cancelled request", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		searchUsecase := NewSearchUsecase(&searchOnuUsecase{walkDelay: time.Minute}, fixedScanRange{BoardMin: 1, BoardMax: 1, PonMin: 1, PonMax: 16})
+
+		_, err := searchUsecase.FindBySerialNumber(ctx, "ZTEGC0000003")
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
@@ -0,0 +1,252 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/config"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/utils"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/pkg/pagination"
+)
+
+// Layout of the synthetic OLT, matching the boards and PONs of the config file
+const (
+	syntheticBoards    = 2
+	syntheticPons      = 16
+	syntheticOnusByPon = 128
+)
+
+var (
+	syntheticStatuses       = []string{"Online", "Online", "Online", "Online", "Online", "Online", "LOS", "Dying Gasp", "Offline"}
+	syntheticOnuTypes       = []string{"F660V5.2", "F670L", "F609V5.3", "F601"}
+	syntheticOfflineReasons = []string{"LOS", "PowerOff", "Reboot", "deactiveSucc"}
+)
+
+// syntheticOnuUsecase serves generated ONUs instead of querying the OLT
+type syntheticOnuUsecase struct {
+	onus map[int][]model.ONUCustomerInfo // keyed by board and PON, sorted by ONU ID
+}
+
+// NewSyntheticOnuUsecase will create an OnuUseCaseInterface serving cfg.SyntheticCfg.OnuCount
+// generated ONUs spread across the boards and PONs. The same seed always generates the same ONUs,
+// so their labels and readings stay stable between scrapes.
+func NewSyntheticOnuUsecase(cfg *config.Config) OnuUseCaseInterface {
+	return &syntheticOnuUsecase{
+		onus: generateSyntheticOnus(cfg.SyntheticCfg.OnuCount, cfg.SyntheticCfg.Seed, time.Now()),
+	}
+}
+
+// generateSyntheticOnus generates count ONUs, assigning them round-robin to the PONs so every
+// board and PON gets traffic. Timestamps are relative to now.
+func generateSyntheticOnus(count int, seed int64, now time.Time) map[int][]model.ONUCustomerInfo {
+	if maxCount := syntheticBoards * syntheticPons * syntheticOnusByPon; count > maxCount {
+		count = maxCount
+	}
+
+	onus := make(map[int][]model.ONUCustomerInfo)
+	for i := 0; i < count; i++ {
+		slot := i % (syntheticBoards * syntheticPons)
+		boardID := slot/syntheticPons + 1
+		ponID := slot%syntheticPons + 1
+		onuID := i/(syntheticBoards*syntheticPons) + 1
+
+		// Every ONU gets its own source, so its readings do not depend on the total count.
+		rng := rand.New(rand.NewSource(seed*1_000_003 + int64(i)))
+		lastOnline := now.Add(-time.Duration(rng.Intn(30*24*3600)) * time.Second)
+		lastOffline := lastOnline.Add(-time.Duration(60+rng.Intn(24*3600)) * time.Second)
+
+		onu := model.ONUCustomerInfo{
+			Board:                boardID,
+			PON:                  ponID,
+			ID:                   onuID,
+			Name:                 fmt.Sprintf("synthetic-%d-%d-%d", boardID, ponID, onuID),
+			Description:          "Synthetic ONU",
+			OnuType:              syntheticOnuTypes[rng.Intn(len(syntheticOnuTypes))],
+			SerialNumber:         fmt.Sprintf("ZTEG%08X", boardID<<16|ponID<<8|onuID),
+			RXPower:              strconv.FormatFloat(-15-rng.Float64()*12, 'f', 2, 64),
+			TXPower:              strconv.FormatFloat(1.5+rng.Float64()*2, 'f', 2, 64),
+			Status:               syntheticStatuses[rng.Intn(len(syntheticStatuses))],
+			IPAddress:            fmt.Sprintf("10.%d.%d.%d", boardID, ponID, onuID),
+			MACAddress:           fmt.Sprintf("02:00:00:%02x:%02x:%02x", boardID, ponID, onuID),
+			LastOnline:           lastOnline.Format("2006-01-02 15:04:05"),
+			LastOffline:          lastOffline.Format("2006-01-02 15:04:05"),
+			LastDownTimeDuration: utils.ConvertDurationToString(lastOnline.Sub(lastOffline)),
+			LastOfflineReason:    syntheticOfflineReasons[rng.Intn(len(syntheticOfflineReasons))],
+			GponOpticalDistance:  strconv.Itoa(100 + rng.Intn(15000)),
+		}
+
+		key := syntheticKey(boardID, ponID)
+		onus[key] = append(onus[key], onu)
+	}
+	return onus
+}
+
+func syntheticKey(boardID, ponID int) int {
+	return boardID*100 + ponID
+}
+
+// getPon returns the ONUs of a PON, validating the board and PON like the SNMP usecase does
+func (u *syntheticOnuUsecase) getPon(boardID, ponID int) ([]model.ONUCustomerInfo, error) {
+	if boardID < 1 || boardID > syntheticBoards {
+		return nil, errors.New("invalid Board ID")
+	}
+	if ponID < 1 || ponID > syntheticPons {
+		return nil, errors.New("invalid PON ID")
+	}
+	return u.onus[syntheticKey(boardID, ponID)], nil
+}
+
+func (u *syntheticOnuUsecase) GetByBoardIDAndPonID(_ context.Context, boardID, ponID int) ([]model.ONUInfoPerBoard, error) {
+	onus, err := u.getPon(boardID, ponID)
+	if err != nil {
+		return nil, err
+	}
+
+	onuInformationList := make([]model.ONUInfoPerBoard, 0, len(onus))
+	for _, onu := range onus {
+		onuInformationList = append(onuInformationList, model.ONUInfoPerBoard{
+			Board:        onu.Board,
+			PON:          onu.PON,
+			ID:           onu.ID,
+			Name:         onu.Name,
+			OnuType:      onu.OnuType,
+			SerialNumber: onu.SerialNumber,
+			RXPower:      onu.RXPower,
+			Status:       onu.Status,
+			MACAddress:   onu.MACAddress,
+		})
+	}
+	return onuInformationList, nil
+}
+
+func (u *syntheticOnuUsecase) GetByBoardIDPonIDAndOnuID(_ context.Context, boardID, ponID, onuID int) (model.ONUCustomerInfo, error) {
+	onus, err := u.getPon(boardID, ponID)
+	if err != nil {
+		return model.ONUCustomerInfo{}, err
+	}
+
+	for _, onu := range onus {
+		if onu.ID == onuID {
+			return withUptime(onu), nil
+		}
+	}
+	return model.ONUCustomerInfo{}, errors.New("onu not found")
+}
+
+func (u *syntheticOnuUsecase) GetAllDetailsByBoardPon(_ context.Context, boardID, ponID int) ([]model.ONUCustomerInfo, error) {
+	onus, err := u.getPon(boardID, ponID)
+	if err != nil {
+		return nil, err
+	}
+
+	onuInformationList := make([]model.ONUCustomerInfo, 0, len(onus))
+	for _, onu := range onus {
+		onuInformationList = append(onuInformationList, withUptime(onu))
+	}
+	return onuInformationList, nil
+}
+
+// withUptime returns the ONU with its uptime as of now, so it keeps growing between scrapes like on
+// a real OLT.
+func withUptime(onu model.ONUCustomerInfo) model.ONUCustomerInfo {
+	lastOnline, err := time.ParseInLocation("2006-01-02 15:04:05", onu.LastOnline, time.Local)
+	if err == nil {
+		onu.Uptime = utils.ConvertDurationToString(time.Since(lastOnline))
+	}
+	return onu
+}
+
+func (u *syntheticOnuUsecase) GetStatusesByBoardPon(_ context.Context, boardID, ponID int) (map[int]string, error) {
+	onus, err := u.getPon(boardID, ponID)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make(map[int]string, len(onus))
+	for _, onu := range onus {
+		statuses[onu.ID] = onu.Status
+	}
+	return statuses, nil
+}
+
+func (u *syntheticOnuUsecase) GetEmptyOnuID(_ context.Context, boardID, ponID int) ([]model.OnuID, error) {
+	onus, err := u.getPon(boardID, ponID)
+	if err != nil {
+		return nil, err
+	}
+
+	used := make(map[int]bool, len(onus))
+	for _, onu := range onus {
+		used[onu.ID] = true
+	}
+
+	var emptyOnuIDList []model.OnuID
+	for id := 1; id <= syntheticOnusByPon; id++ {
+		if !used[id] {
+			emptyOnuIDList = append(emptyOnuIDList, model.OnuID{Board: boardID, PON: ponID, ID: id})
+		}
+	}
+	return emptyOnuIDList, nil
+}
+
+// MaxOnuPerPon returns the number of ONU IDs a synthetic PON holds
+func (u *syntheticOnuUsecase) MaxOnuPerPon() int {
+	return syntheticOnusByPon
+}
+
+func (u *syntheticOnuUsecase) GetOnuIDAndSerialNumber(boardID, ponID int) ([]model.OnuSerialNumber, error) {
+	onus, err := u.getPon(boardID, ponID)
+	if err != nil {
+		return nil, err
+	}
+
+	onuSerialNumberList := make([]model.OnuSerialNumber, 0, len(onus))
+	for _, onu := range onus {
+		onuSerialNumberList = append(onuSerialNumberList, model.OnuSerialNumber{
+			Board:        onu.Board,
+			PON:          onu.PON,
+			ID:           onu.ID,
+			SerialNumber: onu.SerialNumber,
+		})
+	}
+	sort.Slice(onuSerialNumberList, func(i, j int) bool {
+		return onuSerialNumberList[i].ID < onuSerialNumberList[j].ID
+	})
+	return onuSerialNumberList, nil
+}
+
+func (u *syntheticOnuUsecase) UpdateEmptyOnuID(_ context.Context, boardID, ponID int) error {
+	_, err := u.getPon(boardID, ponID)
+	return err // Nothing is cached, the empty ONU IDs are always computed on request
+}
+
+func (u *syntheticOnuUsecase) GetByBoardIDAndPonIDWithPagination(
+	boardID, ponID, pageIndex, pageSize int,
+) ([]model.ONUInfoPerBoard, int) {
+	pageSize = pagination.ClampPageSize(pageSize)
+	if pageIndex < 1 {
+		pageIndex = 1
+	}
+
+	onuInformationList, err := u.GetByBoardIDAndPonID(context.Background(), boardID, ponID)
+	if err != nil {
+		return nil, 0
+	}
+
+	count := len(onuInformationList)
+	start := (pageIndex - 1) * pageSize
+	if start >= count {
+		return []model.ONUInfoPerBoard{}, count
+	}
+	end := start + pageSize
+	if end > count {
+		end = count
+	}
+	return onuInformationList[start:end], count
+}
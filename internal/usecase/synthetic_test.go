@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyntheticOnuUsecaseGeneratesRequestedOnus(t *testing.T) {
+	tests := []struct {
+		name     string
+		count    int
+		expected int
+	}{
+		{name: "None", count: 0, expected: 0},
+		{name: "Fewer than PONs", count: 5, expected: 5},
+		{name: "Several per PON", count: 100, expected: 100},
+		{name: "Capped to the OLT capacity", count: 10000, expected: 2 * 16 * 128},
+	}
+
+	validStatuses := map[string]bool{"Online": true, "LOS": true, "Dying Gasp": true, "Offline": true}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{SyntheticCfg: config.SyntheticConfig{Enabled: true, OnuCount: tt.count, Seed: 42}}
+			onuUsecase := NewSyntheticOnuUsecase(cfg)
+
+			serials := make(map[string]bool)
+			for boardID := 1; boardID <= 2; boardID++ {
+				for ponID := 1; ponID <= 16; ponID++ {
+					onus, err := onuUsecase.GetByBoardIDAndPonID(context.Background(), boardID, ponID)
+					require.NoError(t, err)
+
+					for _, discovered := range onus {
+						onu, err := onuUsecase.GetByBoardIDPonIDAndOnuID(context.Background(), boardID, ponID, discovered.ID)
+						require.NoError(t, err)
+
+						assert.Equal(t, boardID, onu.Board)
+						assert.Equal(t, ponID, onu.PON)
+						assert.True(t, onu.ID >= 1 && onu.ID <= 128, "ONU ID %d is out of range", onu.ID)
+						assert.NotEmpty(t, onu.Name)
+						assert.NotEmpty(t, onu.OnuType)
+						assert.True(t, validStatuses[onu.Status], "unexpected status %q", onu.Status)
+						assert.NotEmpty(t, onu.Uptime)
+
+						rxPower, err := strconv.ParseFloat(onu.RXPower, 64)
+						require.NoError(t, err)
+						assert.True(t, rxPower < 0 && rxPower > -30, "unexpected rx power %v", rxPower)
+						_, err = time.Parse("2006-01-02 15:04:05", onu.LastOnline)
+						assert.NoError(t, err)
+
+						assert.False(t, serials[onu.SerialNumber], "duplicate serial %s", onu.SerialNumber)
+						serials[onu.SerialNumber] = true
+					}
+				}
+			}
+			assert.Len(t, serials, tt.expected)
+		})
+	}
+}
+
+func TestSyntheticOnuUsecaseIsStable(t *testing.T) {
+	now := time.Now()
+	first := generateSyntheticOnus(64, 7, now)
+	second := generateSyntheticOnus(64, 7, now)
+	assert.Equal(t, first, second)
+
+	// Adding ONUs does not change the readings of the existing ones.
+	more := generateSyntheticOnus(96, 7, now)
+	assert.Equal(t, first[syntheticKey(1, 1)], more[syntheticKey(1, 1)][:2])
+}
+
+func TestSyntheticOnuUsecaseInvalidLocation(t *testing.T) {
+	onuUsecase := NewSyntheticOnuUsecase(&config.Config{SyntheticCfg: config.SyntheticConfig{OnuCount: 10}})
+
+	_, err := onuUsecase.GetByBoardIDAndPonID(context.Background(), 3, 1)
+	assert.Error(t, err)
+	_, err = onuUsecase.GetByBoardIDAndPonID(context.Background(), 1, 17)
+	assert.Error(t, err)
+	_, err = onuUsecase.GetByBoardIDPonIDAndOnuID(context.Background(), 1, 1, 99)
+	assert.Error(t, err)
+}
@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/config"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/repository"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/utils"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/singleflight"
+)
+
+// UplinkUseCaseInterface is an interface that represent the uplink's usecase contract
+type UplinkUseCaseInterface interface {
+	GetUplinkPorts(ctx context.Context) ([]model.UplinkPort, error)
+}
+
+// uplinkUsecase represent the uplink's usecase
+type uplinkUsecase struct {
+	snmpRepository repository.SnmpRepositoryInterface
+	cfg            *config.Config
+	sg             singleflight.Group
+}
+
+// NewUplinkUsecase will create an object that represent the uplink usecase
+func NewUplinkUsecase(
+	snmpRepository repository.SnmpRepositoryInterface,
+	cfg *config.Config,
+) UplinkUseCaseInterface {
+	return &uplinkUsecase{
+		snmpRepository: snmpRepository,
+		cfg:            cfg,
+		sg:             singleflight.Group{},
+	}
+}
+
+// GetUplinkPorts returns the status and traffic counters of every uplink port, sorted by ifIndex
+func (u *uplinkUsecase) GetUplinkPorts(ctx context.Context) ([]model.UplinkPort, error) {
+	result, err, _ := u.sg.Do("uplink_ports", func() (interface{}, error) {
+		uplinkCfg := u.cfg.UplinkCfg
+
+		pattern, err := regexp.Compile(uplinkCfg.NamePattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid uplink name pattern: %w", err)
+		}
+
+		// Walk the interface names to find the uplink ports
+		ports := make(map[int]*model.UplinkPort)
+		err = u.snmpRepository.Walk(ctx, uplinkCfg.NameOID, func(pdu gosnmp.SnmpPDU) error {
+			name := utils.ExtractName(pdu.Value)
+			if pattern.MatchString(name) {
+				ifIndex := utils.ExtractIDOnuID(pdu.Name)
+				ports[ifIndex] = &model.UplinkPort{IfIndex: ifIndex, Name: name}
+			}
+			return nil
+		})
+		if err != nil {
+			log.Error().Msg("Failed to walk uplink interface names: " + err.Error())
+			return nil, err
+		}
+
+		// Walk every column, keeping only the rows of uplink ports
+		columns := []struct {
+			oid   string
+			apply func(port *model.UplinkPort, value interface{})
+		}{
+			{uplinkCfg.AdminStatusOID, func(port *model.UplinkPort, value interface{}) {
+				port.AdminStatus = utils.ExtractIfStatus(value)
+			}},
+			{uplinkCfg.OperStatusOID, func(port *model.UplinkPort, value interface{}) {
+				port.OperStatus = utils.ExtractIfStatus(value)
+			}},
+			{uplinkCfg.InOctetsOID, func(port *model.UplinkPort, value interface{}) {
+				port.InOctets, _ = utils.ExtractCounterValue(value)
+			}},
+			{uplinkCfg.OutOctetsOID, func(port *model.UplinkPort, value interface{}) {
+				port.OutOctets, _ = utils.ExtractCounterValue(value)
+			}},
+		}
+		for _, column := range columns {
+			if len(ports) == 0 || column.oid == "" {
+				continue
+			}
+			err = u.snmpRepository.Walk(ctx, column.oid, func(pdu gosnmp.SnmpPDU) error {
+				if port, ok := ports[utils.ExtractIDOnuID(pdu.Name)]; ok {
+					column.apply(port, pdu.Value)
+				}
+				return nil
+			})
+			if err != nil {
+				log.Error().Msg("Failed to walk uplink OID " + column.oid + ": " + err.Error())
+				return nil, err
+			}
+		}
+
+		uplinkPorts := make([]model.UplinkPort, 0, len(ports))
+		for _, port := range ports {
+			uplinkPorts = append(uplinkPorts, *port)
+		}
+
+		// Sort uplink ports based on ifIndex ascending
+		sort.Slice(uplinkPorts, func(i, j int) bool {
+			return uplinkPorts[i].IfIndex < uplinkPorts[j].IfIndex
+		})
+
+		return uplinkPorts, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.([]model.UplinkPort), nil
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/config"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// walkOnlyRepository serves canned Walk results keyed by the walked OID.
+type walkOnlyRepository struct {
+	walks map[string][]gosnmp.SnmpPDU
+	err   error
+}
+
+func (r *walkOnlyRepository) Get(_ context.Context, _ []string) (*gosnmp.SnmpPacket, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (r *walkOnlyRepository) Walk(_ context.Context, oid string, walkFunc func(pdu gosnmp.SnmpPDU) error) error {
+	if r.err != nil {
+		return r.err
+	}
+	for _, pdu := range r.walks[oid] {
+		if err := walkFunc(pdu); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newUplinkTestConfig() *config.Config {
+	return &config.Config{UplinkCfg: config.UplinkConfig{
+		NamePattern:    "^x?gei_",
+		NameOID:        ".1.3.6.1.2.1.31.1.1.1.1",
+		AdminStatusOID: ".1.3.6.1.2.1.2.2.1.7",
+		OperStatusOID:  ".1.3.6.1.2.1.2.2.1.8",
+		InOctetsOID:    ".1.3.6.1.2.1.31.1.1.1.6",
+		OutOctetsOID:   ".1.3.6.1.2.1.31.1.1.1.10",
+	}}
+}
+
+func TestGetUplinkPorts(t *testing.T) {
+	cfg := newUplinkTestConfig()
+	pdu := func(oid string, ifIndex string, value interface{}) gosnmp.SnmpPDU {
+		return gosnmp.SnmpPDU{Name: oid + "." + ifIndex, Value: value}
+	}
+	repo := &walkOnlyRepository{walks: map[string][]gosnmp.SnmpPDU{
+		cfg.UplinkCfg.NameOID: {
+			pdu(cfg.UplinkCfg.NameOID, "270", []byte("xgei_1/4/1")),
+			pdu(cfg.UplinkCfg.NameOID, "268", []byte("gei_1/3/1")),
+			pdu(cfg.UplinkCfg.NameOID, "300", []byte("gpon_1/1/1")),
+		},
+		cfg.UplinkCfg.AdminStatusOID: {
+			pdu(cfg.UplinkCfg.AdminStatusOID, "268", 1),
+			pdu(cfg.UplinkCfg.AdminStatusOID, "270", 1),
+			pdu(cfg.UplinkCfg.AdminStatusOID, "300", 1),
+		},
+		cfg.UplinkCfg.OperStatusOID: {
+			pdu(cfg.UplinkCfg.OperStatusOID, "268", 1),
+			pdu(cfg.UplinkCfg.OperStatusOID, "270", 2),
+		},
+		cfg.UplinkCfg.InOctetsOID: {
+			pdu(cfg.UplinkCfg.InOctetsOID, "268", uint64(18446744073709551615)), // Counter64
+			pdu(cfg.UplinkCfg.InOctetsOID, "270", uint(4294967295)),             // Counter32
+			pdu(cfg.UplinkCfg.InOctetsOID, "300", uint64(1)),
+		},
+		cfg.UplinkCfg.OutOctetsOID: {
+			pdu(cfg.UplinkCfg.OutOctetsOID, "268", uint64(1024)),
+			pdu(cfg.UplinkCfg.OutOctetsOID, "270", "invalid"),
+		},
+	}}
+
+	ports, err := NewUplinkUsecase(repo, cfg).GetUplinkPorts(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []model.UplinkPort{
+		{IfIndex: 268, Name: "gei_1/3/1", AdminStatus: 1, OperStatus: 1, InOctets: "18446744073709551615", OutOctets: "1024"},
+		{IfIndex: 270, Name: "xgei_1/4/1", AdminStatus: 1, OperStatus: 2, InOctets: "4294967295", OutOctets: ""},
+	}, ports)
+}
+
+func TestGetUplinkPortsErrors(t *testing.T) {
+	cfg := newUplinkTestConfig()
+	_, err := NewUplinkUsecase(&walkOnlyRepository{err: errors.New("request timeout")}, cfg).GetUplinkPorts(context.Background())
+	assert.Error(t, err)
+
+	cfg.UplinkCfg.NamePattern = "("
+	_, err = NewUplinkUsecase(&walkOnlyRepository{}, cfg).GetUplinkPorts(context.Background())
+	assert.Error(t, err)
+}
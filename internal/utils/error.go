@@ -35,6 +35,16 @@ func ErrorInternalServerError(w http.ResponseWriter, err error) {
 	SendJSONResponse(w, http.StatusInternalServerError, webResponse)
 }
 
+// ErrorUnauthorized is a helper function to send a 401 Unauthorized response
+func ErrorUnauthorized(w http.ResponseWriter, err error) {
+	webResponse := ErrorResponse{
+		Code:    http.StatusUnauthorized,
+		Status:  "Unauthorized",
+		Message: err.Error(),
+	}
+	SendJSONResponse(w, http.StatusUnauthorized, webResponse)
+}
+
 // ErrorNotFound is a helper function to send a 404 Not Found response
 func ErrorNotFound(w http.ResponseWriter, err error) {
 	webResponse := ErrorResponse{
@@ -44,3 +54,13 @@ func ErrorNotFound(w http.ResponseWriter, err error) {
 	}
 	SendJSONResponse(w, http.StatusNotFound, webResponse)
 }
+
+// ErrorServiceUnavailable is a helper function to send a 503 Service Unavailable response
+func ErrorServiceUnavailable(w http.ResponseWriter, err error) {
+	webResponse := ErrorResponse{
+		Code:    http.StatusServiceUnavailable,
+		Status:  "Service Unavailable",
+		Message: err.Error(),
+	}
+	SendJSONResponse(w, http.StatusServiceUnavailable, webResponse)
+}
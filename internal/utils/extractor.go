@@ -1,15 +1,20 @@
 package utils
 
 import (
+	"bytes"
+	"encoding/hex"
 	"fmt"
+	"net"
 	"strconv"
 	"strings"
+
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
 )
 
 // ExtractONUID function is used to extract ONU ID from OID string
 func ExtractONUID(oid string) string {
 	// Split the OID name and take the last component
-	parts := strings.Split(oid, ".")
+	parts := strings.Split(NormalizeOID(oid), ".")
 	if len(parts) > 0 {
 		// Check if the last component is a valid number
 		lastComponent := parts[len(parts)-1]
@@ -20,6 +25,16 @@ func ExtractONUID(oid string) string {
 	return "" // Return an empty string if the OID is invalid or empty (default value)
 }
 
+// NormalizeOID function is used to normalize an OID name for comparison. Depending on the firmware
+// the OLT reports OID names with or without the leading dot, or with a trailing dot appended.
+func NormalizeOID(oid string) string {
+	oid = strings.Trim(strings.TrimSpace(oid), ".")
+	if oid == "" {
+		return ""
+	}
+	return "." + oid
+}
+
 // ExtractIDOnuID function is used to extract ONU ID from OID interface{}
 func ExtractIDOnuID(oid interface{}) int {
 	if oid == nil {
@@ -28,7 +43,7 @@ func ExtractIDOnuID(oid interface{}) int {
 
 	switch v := oid.(type) {
 	case string:
-		parts := strings.Split(v, ".")
+		parts := strings.Split(NormalizeOID(v), ".")
 		if len(parts) > 0 {
 			lastPart := parts[len(parts)-1]
 			id, err := strconv.Atoi(lastPart)
@@ -45,6 +60,9 @@ func ExtractIDOnuID(oid interface{}) int {
 // ExtractName function is used to extract name from OID value
 func ExtractName(oidValue interface{}) string {
 	switch v := oidValue.(type) {
+	case nil:
+		// The OID is absent, there is no name
+		return ""
 	case string:
 		// Data is string, return it
 		return v
@@ -57,28 +75,115 @@ func ExtractName(oidValue interface{}) string {
 	}
 }
 
-// ExtractSerialNumber function is used to extract serial number from OID value
+// ExtractSerialNumber function is used to extract serial number from OID value and return it in
+// its canonical uppercase form. Both the plain ASCII serial (e.g. "ZTEGC0A1B2C3") and the raw 8-byte
+// GPON serial, a 4-character vendor ID followed by 4 binary bytes, are supported.
 func ExtractSerialNumber(oidValue interface{}) string {
+	var raw []byte
+	switch v := oidValue.(type) {
+	case string:
+		raw = []byte(v)
+	case []byte:
+		raw = v
+	default:
+		// Data type is not recognized, you can handle this case according to your needs.
+		return "" // Return empty string if the OID is invalid or empty (default value)
+	}
+
+	// Some firmware prefixes the serial with "1,", remove it
+	raw = bytes.TrimPrefix(raw, []byte("1,"))
+
+	// The binary form is rendered as vendor ID + hex of the vendor-specific part
+	if len(raw) == 8 && isPrintableASCII(raw[:4]) && !isPrintableASCII(raw[4:]) {
+		return strings.ToUpper(string(raw[:4]) + hex.EncodeToString(raw[4:]))
+	}
+
+	return strings.ToUpper(strings.Trim(string(raw), "\x00 "))
+}
+
+// ExtractEquipmentID function is used to extract the equipment ID (ESN) of the ONU from OID value,
+// dropping the NUL and space padding some firmware adds to the fixed-size field
+func ExtractEquipmentID(oidValue interface{}) string {
+	var value string
+	switch v := oidValue.(type) {
+	case string:
+		value = v
+	case []byte:
+		value = string(v)
+	default:
+		return ""
+	}
+	return strings.Trim(value, "\x00 ")
+}
+
+// ExtractTransceiverType function is used to extract the type of the optical module (transceiver)
+// of the ONU from OID value, e.g. "GPON Class B+", dropping the NUL and space padding and
+// collapsing the runs of spaces some firmware uses to align the field
+func ExtractTransceiverType(oidValue interface{}) string {
+	var value string
 	switch v := oidValue.(type) {
 	case string:
-		// If the string starts with "1,", remove it from the string
-		if strings.HasPrefix(v, "1,") {
-			return v[2:]
+		value = v
+	case []byte:
+		value = string(v)
+	default:
+		return ""
+	}
+	return strings.Join(strings.Fields(strings.ReplaceAll(value, "\x00", " ")), " ")
+}
+
+// ExtractProfileTemplate function is used to extract the ONU profile template applied by the OLT
+// from OID value. Depending on the firmware the OLT reports either the template name or its
+// numeric ID, where 0 means that no template was applied.
+func ExtractProfileTemplate(oidValue interface{}) string {
+	switch v := oidValue.(type) {
+	case string:
+		return strings.Trim(v, "\x00 ")
+	case []byte:
+		return strings.Trim(string(v), "\x00 ")
+	case int:
+		if v <= 0 {
+			return ""
 		}
-		return v
+		return strconv.Itoa(v)
+	case uint:
+		if v == 0 {
+			return ""
+		}
+		return strconv.FormatUint(uint64(v), 10)
+	default:
+		return ""
+	}
+}
+
+// ExtractQosProfile function is used to extract the QoS profile of the ONU from OID value. Depending
+// on the firmware the OLT reports either the profile name or the configured priority (0-7).
+func ExtractQosProfile(oidValue interface{}) string {
+	switch v := oidValue.(type) {
+	case string:
+		return strings.Trim(v, "\x00 ")
 	case []byte:
-		// Convert byte slice to string
-		strValue := string(v)
-		if strings.HasPrefix(strValue, "1,") {
-			return strValue[2:]
+		return strings.Trim(string(v), "\x00 ")
+	case int:
+		if v < 0 {
+			return ""
 		}
-		return strValue // Data is byte slice, convert to string
+		return strconv.Itoa(v)
 	default:
-		// Data type is not recognized, you can handle this case according to your needs.
-		return "" // Return 0 if the OID is invalid or empty (default value)
+		return ""
 	}
 }
 
+// isPrintableASCII reports whether every byte of b is a printable ASCII character
+func isPrintableASCII(b []byte) bool {
+	for _, c := range b {
+		if c < 0x20 || c > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
 // ConvertAndMultiply function is used to convert the PDU value to string after multiplying by 0.002 and subtracting 30
 func ConvertAndMultiply(pduValue interface{}) (string, error) {
 	// Type assert pduValue to an integer type
@@ -99,8 +204,47 @@ func ConvertAndMultiply(pduValue interface{}) (string, error) {
 	return resultStr, nil
 }
 
+// ConvertOnuTemperature function is used to convert the PDU value of the ONU transceiver
+// temperature, a signed count of 1/256 degree Celsius like the OMCI ANI-G, to degrees Celsius.
+// Values outside of -50 to 150 °C are placeholders of a transceiver without diagnostics.
+func ConvertOnuTemperature(pduValue interface{}) (string, error) {
+	return convertScaled(pduValue, "temperature", 1.0/256, -50, 150, 2)
+}
+
+// ConvertOnuVoltage function is used to convert the PDU value of the ONU supply voltage, a count
+// of 20 mV like the OMCI ANI-G, to volts. Values outside of 0 to 5 V are placeholders.
+func ConvertOnuVoltage(pduValue interface{}) (string, error) {
+	return convertScaled(pduValue, "supply voltage", 0.02, 0, 5, 2)
+}
+
+// ConvertOnuBiasCurrent function is used to convert the PDU value of the ONU laser bias current, a
+// count of 2 µA like the OMCI ANI-G, to milliamperes. Values outside of 0 to 200 mA are placeholders.
+func ConvertOnuBiasCurrent(pduValue interface{}) (string, error) {
+	return convertScaled(pduValue, "bias current", 0.002, 0, 200, 3)
+}
+
+// convertScaled multiplies an integer PDU value by scale, rejects results outside of
+// [minValue, maxValue] and renders them with the given decimals.
+func convertScaled(pduValue interface{}, name string, scale, minValue, maxValue float64, decimals int) (string, error) {
+	intValue, ok := pduValue.(int)
+	if !ok {
+		return "", fmt.Errorf("value is not an integer")
+	}
+
+	result := float64(intValue) * scale
+	if result < minValue || result > maxValue {
+		return "", fmt.Errorf("%s out of range: %d", name, intValue)
+	}
+	return strconv.FormatFloat(result, 'f', decimals, 64), nil
+}
+
 // ExtractAndGetStatus function is used to extract and get status from OID value
 func ExtractAndGetStatus(oidValue interface{}) string {
+	// An absent OID has no value, unlike an unknown one
+	if oidValue == nil {
+		return ""
+	}
+
 	// Check if oidValue is not an integer
 	intValue, ok := oidValue.(int)
 	if !ok {
@@ -129,6 +273,11 @@ func ExtractAndGetStatus(oidValue interface{}) string {
 
 // ExtractLastOfflineReason function is used to extract last offline reason from OID value
 func ExtractLastOfflineReason(oidValue interface{}) string {
+	// An absent OID has no value, unlike an unknown one
+	if oidValue == nil {
+		return ""
+	}
+
 	// Check if oidValue is not an integer
 	intValue, ok := oidValue.(int)
 	if !ok {
@@ -167,8 +316,140 @@ func ExtractLastOfflineReason(oidValue interface{}) string {
 	}
 }
 
+// offlineReasonCodes maps the reasons returned by ExtractLastOfflineReason back to the reason
+// codes of the OLT, which are stable across firmware and thus fit for alerting
+var offlineReasonCodes = map[string]int{
+	"Unknown":      1,
+	"LOS":          2,  // Loss of signal, e.g. a cut fiber
+	"LOSi":         3,  // Loss of signal of the ONU
+	"LOFi":         4,  // Loss of frame of the ONU
+	"sfi":          5,  // Signal fail of the ONU
+	"loai":         6,  // Loss of acknowledgement of the ONU
+	"loami":        7,  // Loss of PLOAM of the ONU
+	"AuthFail":     8,  // Authentication failure
+	"PowerOff":     9,  // Dying gasp, the ONU lost power
+	"deactiveSucc": 10, // Deactivated by the operator
+	"deactiveFail": 11, // Failed deactivation
+	"Reboot":       12,
+	"Shutdown":     13, // Administratively shut down
+}
+
+// ExtractOfflineReasonCode function is used to convert a last offline reason, as returned by
+// ExtractLastOfflineReason, to the reason code of the OLT. Reasons this exporter does not know
+// are coded 0, and ok is false when there is no reason, i.e. its OID is not configured.
+func ExtractOfflineReasonCode(reason string) (code int, ok bool) {
+	if reason == "" {
+		return 0, false
+	}
+	return offlineReasonCodes[reason], true
+}
+
+// ExtractUpgradeState function is used to extract the software upgrade state of the ONU from OID
+// value. An upgrade downloads the new image, activates it (reboots on it) and then commits it.
+func ExtractUpgradeState(oidValue interface{}) string {
+	// An absent OID has no value, unlike an unknown one
+	if oidValue == nil {
+		return ""
+	}
+
+	// Check if oidValue is not an integer
+	intValue, ok := oidValue.(int)
+	if !ok {
+		return "unknown"
+	}
+
+	switch intValue {
+	case 1:
+		return "idle"
+	case 2:
+		return "downloading"
+	case 3:
+		return "downloaded"
+	case 4:
+		return "activating"
+	case 5:
+		return "activated"
+	case 6:
+		return "committing"
+	case 7:
+		return "committed"
+	case 8:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// ExtractBatteryStatus function is used to extract the state of the backup battery of the ONU from
+// OID value. "on_battery" and "low" are reported while the ONU runs on its battery, i.e. it lost
+// mains power.
+func ExtractBatteryStatus(oidValue interface{}) string {
+	// An absent OID has no value, unlike an unknown one
+	if oidValue == nil {
+		return ""
+	}
+
+	// Check if oidValue is not an integer
+	intValue, ok := oidValue.(int)
+	if !ok {
+		return "unknown"
+	}
+
+	switch intValue {
+	case 1:
+		return "charged"
+	case 2:
+		return "charging"
+	case 3:
+		return "on_battery"
+	case 4:
+		return "low"
+	case 5:
+		return "missing"
+	case 6:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// ExtractRegistrationFailReason function is used to extract why the last registration of the ONU
+// failed from OID value. It returns the raw reason code with its name; codes this exporter does not
+// know are named "unknown" but keep their code.
+func ExtractRegistrationFailReason(oidValue interface{}) (int, string, error) {
+	// Check if oidValue is not an integer
+	intValue, ok := oidValue.(int)
+	if !ok {
+		return 0, "", fmt.Errorf("value is not a registration failure reason")
+	}
+
+	switch intValue {
+	case 1:
+		return intValue, "none", nil
+	case 2:
+		return intValue, "auth_failed", nil // Password or LOID mismatch
+	case 3:
+		return intValue, "sn_conflict", nil // Serial number already registered elsewhere
+	case 4:
+		return intValue, "profile_mismatch", nil
+	case 5:
+		return intValue, "type_mismatch", nil // ONU type differs from the provisioned one
+	case 6:
+		return intValue, "ranging_failed", nil
+	case 7:
+		return intValue, "omci_timeout", nil
+	default:
+		return intValue, "unknown", nil
+	}
+}
+
 // ExtractGponOpticalDistance function is used to extract GPON optical distance from OID value
 func ExtractGponOpticalDistance(oidValue interface{}) string {
+	// An absent OID has no value, unlike an unknown one
+	if oidValue == nil {
+		return ""
+	}
+
 	// Check if oidValue is not an integer
 	intValue, ok := oidValue.(int)
 	if !ok {
@@ -177,3 +458,591 @@ func ExtractGponOpticalDistance(oidValue interface{}) string {
 
 	return strconv.Itoa(intValue)
 }
+
+// ExtractCounterValue function is used to extract an unsigned counter (Counter32/Counter64) from OID value
+func ExtractCounterValue(oidValue interface{}) (string, error) {
+	switch v := oidValue.(type) {
+	case uint:
+		return strconv.FormatUint(uint64(v), 10), nil
+	case uint32:
+		return strconv.FormatUint(uint64(v), 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	case int:
+		if v < 0 {
+			return "", fmt.Errorf("negative counter value: %d", v)
+		}
+		return strconv.Itoa(v), nil
+	default:
+		return "", fmt.Errorf("value is not a counter")
+	}
+}
+
+// ExtractSignalQuality function is used to extract the signal quality index (0-100, higher is
+// better) of the ONU from OID value. Depending on the firmware it is reported as an INTEGER or as
+// its decimal OCTET STRING; 65535 and other out of range values mean the index is not available.
+func ExtractSignalQuality(oidValue interface{}) (string, error) {
+	var value int
+	switch v := oidValue.(type) {
+	case int:
+		value = v
+	case uint:
+		value = int(v)
+	case []byte:
+		parsed, err := strconv.Atoi(strings.Trim(string(v), "\x00 "))
+		if err != nil {
+			return "", fmt.Errorf("value is not a signal quality index: %w", err)
+		}
+		value = parsed
+	default:
+		return "", fmt.Errorf("value is not a signal quality index")
+	}
+
+	if value < 0 || value > 100 {
+		return "", fmt.Errorf("signal quality index out of range: %d", value)
+	}
+	return strconv.Itoa(value), nil
+}
+
+// ExtractMulticastEnabled function is used to extract the IGMP snooping state of the ONU from OID
+// value, reported as a TruthValue
+func ExtractMulticastEnabled(oidValue interface{}) (string, error) {
+	enabled, err := extractTruthValue(oidValue)
+	if err != nil {
+		return "", fmt.Errorf("value is not a multicast state: %w", err)
+	}
+	return enabled, nil
+}
+
+// ExtractLoopDetected function is used to extract the loop detection alarm of the ONU from OID
+// value, reported as a TruthValue: raised when a loop is detected behind the ONU
+func ExtractLoopDetected(oidValue interface{}) (string, error) {
+	detected, err := extractTruthValue(oidValue)
+	if err != nil {
+		return "", fmt.Errorf("value is not a loop detection alarm: %w", err)
+	}
+	return detected, nil
+}
+
+// ExtractEncryptionEnabled function is used to extract whether the downstream GPON (AES) encryption
+// of the ONU is active from OID value, reported as a TruthValue, or as text by some firmware
+func ExtractEncryptionEnabled(oidValue interface{}) (string, error) {
+	if text, ok := oidValue.([]byte); ok {
+		switch strings.ToLower(strings.Trim(string(text), "\x00 ")) {
+		case "enable", "enabled", "on", "aes", "aes128":
+			return "true", nil
+		case "disable", "disabled", "off", "none":
+			return "false", nil
+		}
+		return "", fmt.Errorf("value is not an encryption state: unexpected text %q", text)
+	}
+	enabled, err := extractTruthValue(oidValue)
+	if err != nil {
+		return "", fmt.Errorf("value is not an encryption state: %w", err)
+	}
+	return enabled, nil
+}
+
+// ExtractCatvStatus function is used to extract the state of the CATV (RF video) port of the ONU
+// from OID value, reported as enabled (1) or disabled (2)
+func ExtractCatvStatus(oidValue interface{}) (string, error) {
+	enabled, err := extractTruthValue(oidValue)
+	if err != nil {
+		return "", fmt.Errorf("value is not a CATV port state: %w", err)
+	}
+	return enabled, nil
+}
+
+// ExtractCatvLevel function is used to extract the RF output level of the CATV port of the ONU
+// from OID value, reported in tenths of a dBmV, or as a decimal dBmV string by some firmware.
+// Values outside of -40 to 100 dBmV are placeholders of a port without RF signal.
+func ExtractCatvLevel(oidValue interface{}) (string, error) {
+	var value float64
+	switch v := oidValue.(type) {
+	case int:
+		value = float64(v) / 10
+	case []byte:
+		parsed, err := strconv.ParseFloat(strings.Trim(string(v), "\x00 "), 64)
+		if err != nil {
+			return "", fmt.Errorf("value is not an RF level: %w", err)
+		}
+		value = parsed
+	default:
+		return "", fmt.Errorf("value is not an RF level")
+	}
+
+	if value < -40 || value > 100 {
+		return "", fmt.Errorf("RF level out of range: %v", value)
+	}
+	return strconv.FormatFloat(value, 'f', 1, 64), nil
+}
+
+// ExtractLineRate function is used to extract a GPON line rate negotiated by the ONU from OID value,
+// reported in kbit/s, and returns it in Mbit/s. Zero means the ONU has not negotiated a rate.
+func ExtractLineRate(oidValue interface{}) (string, error) {
+	var kbps uint64
+	switch v := oidValue.(type) {
+	case int:
+		if v < 0 {
+			return "", fmt.Errorf("negative line rate: %d", v)
+		}
+		kbps = uint64(v)
+	case uint:
+		kbps = uint64(v)
+	case uint64:
+		kbps = v
+	case []byte:
+		parsed, err := strconv.ParseUint(strings.Trim(string(v), "\x00 "), 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("value is not a line rate: %w", err)
+		}
+		kbps = parsed
+	default:
+		return "", fmt.Errorf("value is not a line rate")
+	}
+
+	if kbps == 0 {
+		return "", fmt.Errorf("line rate not negotiated")
+	}
+	return strconv.FormatFloat(float64(kbps)/1000, 'f', -1, 64), nil
+}
+
+// ExtractTcontAllocation function is used to extract the T-CONTs allocated to the ONU from OID value,
+// reported as "index,alloc_id,profile[,gem_ports]" entries separated by ";", e.g.
+// "1,1024,UP-100M,2;2,1025,VOIP,1". An empty value means no T-CONT is allocated.
+func ExtractTcontAllocation(oidValue interface{}) ([]model.TcontAllocation, error) {
+	var value string
+	switch v := oidValue.(type) {
+	case string:
+		value = v
+	case []byte:
+		value = string(v)
+	default:
+		return nil, fmt.Errorf("value is not a T-CONT allocation")
+	}
+
+	tconts := make([]model.TcontAllocation, 0)
+	for _, entry := range strings.Split(strings.Trim(value, "\x00 "), ";") {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+
+		fields := strings.Split(entry, ",")
+		if len(fields) != 3 && len(fields) != 4 {
+			return nil, fmt.Errorf("invalid T-CONT entry %q", entry)
+		}
+		index, err := strconv.Atoi(strings.TrimSpace(fields[0]))
+		if err != nil || index < 1 {
+			return nil, fmt.Errorf("invalid T-CONT index in %q", entry)
+		}
+		allocID, err := strconv.Atoi(strings.TrimSpace(fields[1]))
+		if err != nil || allocID < 0 {
+			return nil, fmt.Errorf("invalid T-CONT alloc-id in %q", entry)
+		}
+		tcont := model.TcontAllocation{Index: index, AllocID: allocID, Profile: strings.TrimSpace(fields[2])}
+		if len(fields) == 4 {
+			gemPorts, err := strconv.Atoi(strings.TrimSpace(fields[3]))
+			if err != nil || gemPorts < 0 {
+				return nil, fmt.Errorf("invalid T-CONT GEM port count in %q", entry)
+			}
+			tcont.GemPorts = gemPorts
+		}
+		tconts = append(tconts, tcont)
+	}
+
+	return tconts, nil
+}
+
+// uniSpeeds maps the speed codes of an Ethernet (UNI) port to Mbit/s
+var uniSpeeds = map[int]float64{
+	1: 10,
+	2: 100,
+	3: 1000,
+	4: 10000,
+	5: 2500,
+	6: 5000,
+}
+
+// ExtractUniSpeed function is used to extract the negotiated speed of an Ethernet (UNI) port in
+// Mbit/s from OID value, reported either as a speed code (1 10M, 2 100M, 3 1G, 4 10G, 5 2.5G, 6 5G)
+// or as text such as "100M" or "1G". A port that is down (code 0) has no speed.
+func ExtractUniSpeed(oidValue interface{}) (float64, error) {
+	var text string
+	switch v := oidValue.(type) {
+	case string:
+		text = v
+	case []byte:
+		text = string(v)
+	default:
+		code, ok := oidValue.(int)
+		if !ok {
+			return 0, fmt.Errorf("value is not a port speed")
+		}
+		speed, ok := uniSpeeds[code]
+		if !ok {
+			return 0, fmt.Errorf("unknown port speed code %d", code)
+		}
+		return speed, nil
+	}
+
+	text = strings.ToUpper(strings.TrimSpace(strings.Trim(text, "\x00")))
+	text = strings.TrimSuffix(strings.TrimSuffix(text, "BPS"), "B/S")
+	multiplier := 1.0
+	switch {
+	case strings.HasSuffix(text, "G"):
+		multiplier = 1000
+		text = strings.TrimSuffix(text, "G")
+	case strings.HasSuffix(text, "M"):
+		text = strings.TrimSuffix(text, "M")
+	default:
+		return 0, fmt.Errorf("value is not a port speed")
+	}
+	speed, err := strconv.ParseFloat(text, 64)
+	if err != nil || speed <= 0 {
+		return 0, fmt.Errorf("value is not a port speed")
+	}
+	return speed * multiplier, nil
+}
+
+// ExtractUniDuplex function is used to extract the negotiated duplex mode of an Ethernet (UNI) port
+// from OID value, reported either as a code (1 half, 2 full) or as text ("half", "full")
+func ExtractUniDuplex(oidValue interface{}) (string, error) {
+	var text string
+	switch v := oidValue.(type) {
+	case string:
+		text = v
+	case []byte:
+		text = string(v)
+	default:
+		code, ok := oidValue.(int)
+		if !ok {
+			return "", fmt.Errorf("value is not a duplex mode")
+		}
+		switch code {
+		case 1:
+			return "half", nil
+		case 2:
+			return "full", nil
+		default:
+			return "", fmt.Errorf("unknown duplex code %d", code)
+		}
+	}
+
+	switch duplex := strings.ToLower(strings.TrimSpace(strings.Trim(text, "\x00"))); duplex {
+	case "half", "full":
+		return duplex, nil
+	default:
+		return "", fmt.Errorf("unknown duplex mode %q", text)
+	}
+}
+
+// ExtractLosFlag function is used to extract the LOS flag of the ONU from OID value, reported as a
+// TruthValue: raised while the OLT detects a loss of signal from the ONU
+func ExtractLosFlag(oidValue interface{}) (string, error) {
+	los, err := extractTruthValue(oidValue)
+	if err != nil {
+		return "", fmt.Errorf("value is not a LOS flag: %w", err)
+	}
+	return los, nil
+}
+
+// extractTruthValue converts a TruthValue, true (1) or false (2), to "true" or "false". Some
+// firmware report 0 instead of false.
+func extractTruthValue(oidValue interface{}) (string, error) {
+	switch oidValue {
+	case 1, uint(1):
+		return "true", nil
+	case 0, 2, uint(0), uint(2):
+		return "false", nil
+	default:
+		return "", fmt.Errorf("unexpected value %v", oidValue)
+	}
+}
+
+// ExtractMulticastGroups function is used to extract the number of multicast groups joined by the
+// ONU from OID value
+func ExtractMulticastGroups(oidValue interface{}) (string, error) {
+	var value int
+	switch v := oidValue.(type) {
+	case int:
+		value = v
+	case uint:
+		value = int(v)
+	case []byte:
+		parsed, err := strconv.Atoi(strings.Trim(string(v), "\x00 "))
+		if err != nil {
+			return "", fmt.Errorf("value is not a multicast group count: %w", err)
+		}
+		value = parsed
+	default:
+		return "", fmt.Errorf("value is not a multicast group count")
+	}
+
+	if value < 0 {
+		return "", fmt.Errorf("multicast group count out of range: %d", value)
+	}
+	return strconv.Itoa(value), nil
+}
+
+// ExtractAlarmCount function is used to extract the number of alarms and events the OLT recorded
+// for the ONU from OID value, an Integer or Counter32, or a decimal string on some firmware
+func ExtractAlarmCount(oidValue interface{}) (string, error) {
+	var value int
+	switch v := oidValue.(type) {
+	case int:
+		value = v
+	case uint:
+		value = int(v)
+	case []byte:
+		parsed, err := strconv.Atoi(strings.Trim(string(v), "\x00 "))
+		if err != nil {
+			return "", fmt.Errorf("value is not an alarm count: %w", err)
+		}
+		value = parsed
+	default:
+		return "", fmt.Errorf("value is not an alarm count")
+	}
+
+	if value < 0 {
+		return "", fmt.Errorf("alarm count out of range: %d", value)
+	}
+	return strconv.Itoa(value), nil
+}
+
+// ExtractPonTxPower function is used to extract the downstream transmit power of an OLT PON port
+// from OID value, reported in thousandths of a dBm, or as a decimal dBm string by some firmware.
+// Values outside of -40 to 20 dBm are the "not available" placeholders of an absent module.
+func ExtractPonTxPower(oidValue interface{}) (string, error) {
+	return extractDdmValue(oidValue, "transmit power", -40, 20, 2)
+}
+
+// ExtractTransceiverRxPower function is used to extract the upstream receive power of an OLT PON
+// transceiver from OID value, reported in thousandths of a dBm, or as a decimal dBm string.
+// Values outside of -40 to 20 dBm are placeholders of a PON without received signal.
+func ExtractTransceiverRxPower(oidValue interface{}) (string, error) {
+	return extractDdmValue(oidValue, "receive power", -40, 20, 2)
+}
+
+// ExtractTransceiverTemperature function is used to extract the temperature of an OLT PON
+// transceiver from OID value, reported in thousandths of a degree Celsius, or as a decimal string.
+// Values outside of -50 to 150 °C are placeholders of an absent module.
+func ExtractTransceiverTemperature(oidValue interface{}) (string, error) {
+	return extractDdmValue(oidValue, "temperature", -50, 150, 2)
+}
+
+// ExtractTransceiverBias function is used to extract the laser bias current of an OLT PON
+// transceiver from OID value, reported in microamperes, or as a decimal milliampere string, and
+// returns it in milliamperes. Values outside of 0 to 200 mA are placeholders of an absent module.
+func ExtractTransceiverBias(oidValue interface{}) (string, error) {
+	return extractDdmValue(oidValue, "bias current", 0, 200, 3)
+}
+
+// ExtractTransceiverVoltage function is used to extract the supply voltage of an OLT PON
+// transceiver from OID value, reported in millivolts, or as a decimal volt string, and returns it
+// in volts. Values outside of 0 to 5 V are placeholders of an absent module.
+func ExtractTransceiverVoltage(oidValue interface{}) (string, error) {
+	return extractDdmValue(oidValue, "supply voltage", 0, 5, 3)
+}
+
+// extractDdmValue extracts a digital diagnostic monitoring reading of an optical module, reported
+// as an INTEGER in thousandths of its unit or as its decimal OCTET STRING. Readings outside of
+// [minValue, maxValue] are rejected and the result is rendered with the given decimals.
+func extractDdmValue(oidValue interface{}, name string, minValue, maxValue float64, decimals int) (string, error) {
+	var value float64
+	switch v := oidValue.(type) {
+	case int:
+		value = float64(v) / 1000
+	case []byte:
+		parsed, err := strconv.ParseFloat(strings.Trim(string(v), "\x00 "), 64)
+		if err != nil {
+			return "", fmt.Errorf("value is not a %s: %w", name, err)
+		}
+		value = parsed
+	default:
+		return "", fmt.Errorf("value is not a %s", name)
+	}
+
+	if value < minValue || value > maxValue {
+		return "", fmt.Errorf("%s out of range: %v", name, value)
+	}
+	return strconv.FormatFloat(value, 'f', decimals, 64), nil
+}
+
+// ExtractIPv4Address function is used to extract an IPv4 address, such as the management gateway
+// of the ONU, from OID value. The OLT reports it either as an IpAddress, as 4 raw bytes or as
+// dotted text. The unset address 0.0.0.0 and invalid values yield an empty string.
+func ExtractIPv4Address(oidValue interface{}) string {
+	var ip net.IP
+	switch v := oidValue.(type) {
+	case string:
+		ip = net.ParseIP(strings.Trim(v, "\x00 "))
+	case []byte:
+		if len(v) == net.IPv4len {
+			ip = net.IP(v)
+		} else {
+			ip = net.ParseIP(strings.Trim(string(v), "\x00 "))
+		}
+	}
+
+	ip = ip.To4()
+	if ip == nil || ip.IsUnspecified() {
+		return ""
+	}
+	return ip.String()
+}
+
+// ExtractSubnetMask function is used to extract the management subnet mask of the ONU from OID
+// value, either as an IPv4 address or as a prefix length (1-32), rendered as a dotted mask
+func ExtractSubnetMask(oidValue interface{}) string {
+	prefixLength := -1
+	switch v := oidValue.(type) {
+	case int:
+		prefixLength = v
+	case uint:
+		prefixLength = int(v)
+	default:
+		return ExtractIPv4Address(oidValue)
+	}
+
+	if prefixLength < 1 || prefixLength > 32 {
+		return ""
+	}
+	return net.IP(net.CIDRMask(prefixLength, 32)).String()
+}
+
+// ExtractDateTime function is used to extract a date time (OCTET STRING, 8 bytes) from OID value
+func ExtractDateTime(oidValue interface{}) (string, error) {
+	byteArray, ok := oidValue.([]byte)
+	if !ok {
+		return "", fmt.Errorf("value is not an octet string")
+	}
+
+	return ConvertByteArrayToDateTime(byteArray)
+}
+
+// ExtractMACAddress function is used to extract a MAC address from OID value, normalized to
+// lowercase colon-separated hex. Both a raw 6-byte OCTET STRING and the textual forms
+// "AA:BB:CC:DD:EE:FF", "AA-BB-CC-DD-EE-FF" and "AABB.CCDD.EEFF" are accepted.
+func ExtractMACAddress(oidValue interface{}) string {
+	var text string
+	switch v := oidValue.(type) {
+	case []byte:
+		if len(v) == 6 {
+			return net.HardwareAddr(v).String()
+		}
+		text = string(v)
+	case string:
+		text = v
+	default:
+		return ""
+	}
+
+	// Strip the separators and parse the remaining 12 hex digits
+	digits := strings.NewReplacer(":", "", "-", "", ".", "", " ", "").Replace(strings.TrimSpace(text))
+	raw, err := hex.DecodeString(digits)
+	if err != nil || len(raw) != 6 {
+		return ""
+	}
+	return net.HardwareAddr(raw).String()
+}
+
+// ExtractPsuStatus function is used to extract the status of a power supply unit from OID value
+// (1=normal, 2=fault, 3=not present). It returns "ok", "fault" or "absent", or "" when the value
+// is not a known status.
+func ExtractPsuStatus(oidValue interface{}) string {
+	intValue, ok := oidValue.(int)
+	if !ok {
+		return ""
+	}
+
+	switch intValue {
+	case 1:
+		return "ok"
+	case 2:
+		return "fault"
+	case 3:
+		return "absent"
+	default:
+		return ""
+	}
+}
+
+// ExtractSysUptime function is used to extract the uptime of the OLT from OID value, reported as
+// TimeTicks in hundredths of a second like sysUpTime, and returns it in seconds.
+func ExtractSysUptime(oidValue interface{}) (string, error) {
+	ticks, err := extractUnsigned(oidValue)
+	if err != nil {
+		return "", fmt.Errorf("value is not an uptime: %w", err)
+	}
+	return strconv.FormatFloat(float64(ticks)/100, 'f', 2, 64), nil
+}
+
+// ExtractUsagePercent function is used to extract a CPU or memory usage of the OLT from OID value,
+// reported as an integer percent or as its decimal OCTET STRING. Values outside of 0 to 100 are
+// rejected.
+func ExtractUsagePercent(oidValue interface{}) (string, error) {
+	return extractDecimal(oidValue, "usage", 0, 100)
+}
+
+// ExtractChassisTemperature function is used to extract the temperature of the OLT chassis from
+// OID value, reported in degrees Celsius as an integer or as its decimal OCTET STRING. Values
+// outside of -50 to 150 °C are placeholders of a missing sensor.
+func ExtractChassisTemperature(oidValue interface{}) (string, error) {
+	return extractDecimal(oidValue, "temperature", -50, 150)
+}
+
+// extractUnsigned extracts a non-negative integer, whatever its ASN.1 type, from OID value.
+func extractUnsigned(oidValue interface{}) (uint64, error) {
+	switch v := oidValue.(type) {
+	case uint:
+		return uint64(v), nil
+	case uint32:
+		return uint64(v), nil
+	case uint64:
+		return v, nil
+	case int:
+		if v < 0 {
+			return 0, fmt.Errorf("negative value: %d", v)
+		}
+		return uint64(v), nil
+	default:
+		return 0, fmt.Errorf("value is not an integer")
+	}
+}
+
+// extractDecimal extracts a reading reported in its unit as an integer or as its decimal OCTET
+// STRING, rejects readings outside of [minValue, maxValue] and renders it with two decimals.
+func extractDecimal(oidValue interface{}, name string, minValue, maxValue float64) (string, error) {
+	var value float64
+	switch v := oidValue.(type) {
+	case int:
+		value = float64(v)
+	case uint, uint32, uint64:
+		unsigned, _ := extractUnsigned(v)
+		value = float64(unsigned)
+	case []byte:
+		parsed, err := strconv.ParseFloat(strings.Trim(string(v), "\x00 %"), 64)
+		if err != nil {
+			return "", fmt.Errorf("value is not a %s: %w", name, err)
+		}
+		value = parsed
+	default:
+		return "", fmt.Errorf("value is not a %s", name)
+	}
+
+	if value < minValue || value > maxValue {
+		return "", fmt.Errorf("%s out of range: %v", name, value)
+	}
+	return strconv.FormatFloat(value, 'f', 2, 64), nil
+}
+
+// ExtractIfStatus function is used to extract an IF-MIB ifAdminStatus/ifOperStatus from OID value
+// (1=up, 2=down, 3=testing, ...). It returns 0 when the value is not an integer.
+func ExtractIfStatus(oidValue interface{}) int {
+	intValue, ok := oidValue.(int)
+	if !ok {
+		return 0
+	}
+
+	return intValue
+}
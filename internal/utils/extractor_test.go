@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/model"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -17,6 +18,7 @@ func TestExtractONUID(t *testing.T) {
 		{"1.2.3", "3"},
 		{"1", "1"},
 		{"", ""},
+		{".1.2.3.4.5.", "5"},
 
 		// Test with invalid OID values
 		{"invalid.oid", ""}, // Add test case for an invalid OID
@@ -42,6 +44,7 @@ func TestExtractIDOnuID(t *testing.T) {
 		{123, 0},
 		{"", 0},
 		{"1.2.3.4.invalid", 0},
+		{".1.2.3.4.5.", 5},
 	}
 
 	for _, tc := range testCases {
@@ -52,6 +55,27 @@ func TestExtractIDOnuID(t *testing.T) {
 	}
 }
 
+func TestNormalizeOID(t *testing.T) {
+	testCases := []struct {
+		oid      string
+		expected string
+	}{
+		{".1.3.6.1.2.1", ".1.3.6.1.2.1"},
+		{"1.3.6.1.2.1", ".1.3.6.1.2.1"},
+		{".1.3.6.1.2.1.", ".1.3.6.1.2.1"},
+		{"1.3.6.1.2.1..", ".1.3.6.1.2.1"},
+		{" .1.3.6 ", ".1.3.6"},
+		{".", ""},
+		{"", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("OID: %v", tc.oid), func(t *testing.T) {
+			assert.Equal(t, tc.expected, NormalizeOID(tc.oid))
+		})
+	}
+}
+
 func TestExtractName(t *testing.T) {
 	testCases := []struct {
 		oidValue interface{}
@@ -76,10 +100,22 @@ func TestExtractSerialNumber(t *testing.T) {
 		oidValue interface{}
 		expected string
 	}{
-		{"1,SerialNumber", "SerialNumber"},
-		{"SerialNumber", "SerialNumber"},
-		{[]byte("1,SerialNumber"), "SerialNumber"},
-		{[]byte("SerialNumber"), "SerialNumber"},
+		{"1,SerialNumber", "SERIALNUMBER"},
+		{"SerialNumber", "SERIALNUMBER"},
+		{[]byte("1,SerialNumber"), "SERIALNUMBER"},
+		{[]byte("SerialNumber"), "SERIALNUMBER"},
+		// Plain ASCII serials
+		{"ZTEGC0A1B2C3", "ZTEGC0A1B2C3"},
+		{"zteg c0a1b2c3", "ZTEG C0A1B2C3"},
+		{[]byte("ZTEGC0A1B2C3"), "ZTEGC0A1B2C3"},
+		{[]byte("hwtc1a2b3c4d"), "HWTC1A2B3C4D"},
+		{[]byte("ZTEGC0A1B2C3\x00\x00"), "ZTEGC0A1B2C3"},
+		{[]byte("ZTEG1234"), "ZTEG1234"},
+		// Raw 8-byte binary serials
+		{[]byte{'Z', 'T', 'E', 'G', 0xc0, 0x0a, 0x1b, 0x2c}, "ZTEGC00A1B2C"},
+		{[]byte{'h', 'w', 't', 'c', 0x1a, 0x2b, 0x3c, 0x4d}, "HWTC1A2B3C4D"},
+		{[]byte{'1', ',', 'Z', 'T', 'E', 'G', 0xc0, 0x0a, 0x1b, 0x2c}, "ZTEGC00A1B2C"},
+		{string([]byte{'Z', 'T', 'E', 'G', 0xc0, 0x0a, 0x1b, 0x2c}), "ZTEGC00A1B2C"},
 		{10, ""},
 	}
 
@@ -91,6 +127,95 @@ func TestExtractSerialNumber(t *testing.T) {
 	}
 }
 
+func TestExtractTransceiverType(t *testing.T) {
+	testCases := []struct {
+		oidValue interface{}
+		expected string
+	}{
+		{"GPON Class B+", "GPON Class B+"},
+		{[]byte("XGS-PON N1"), "XGS-PON N1"},
+		{[]byte("GPON Class C+\x00\x00\x00\x00"), "GPON Class C+"},
+		{[]byte("  GPON   Class  B+  "), "GPON Class B+"},
+		{[]byte{}, ""},
+		{[]byte("\x00\x00"), ""},
+		{3, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("OIDValue: %v", tc.oidValue), func(t *testing.T) {
+			result := ExtractTransceiverType(tc.oidValue)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestExtractEquipmentID(t *testing.T) {
+	testCases := []struct {
+		oidValue interface{}
+		expected string
+	}{
+		{"ZTE-F660V5.2", "ZTE-F660V5.2"},
+		{[]byte("ZTEGC8F12345"), "ZTEGC8F12345"},
+		{[]byte("ZTEGC8F12345\x00\x00\x00\x00"), "ZTEGC8F12345"},
+		{[]byte("  F660  "), "F660"},
+		{[]byte{}, ""},
+		{10, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("OIDValue: %v", tc.oidValue), func(t *testing.T) {
+			result := ExtractEquipmentID(tc.oidValue)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestExtractProfileTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		oidValue interface{}
+		expected string
+	}{
+		{name: "Template name", oidValue: []byte("F660-BRIDGE"), expected: "F660-BRIDGE"},
+		{name: "Null-padded template name", oidValue: []byte("F660-ROUTE\x00\x00"), expected: "F660-ROUTE"},
+		{name: "String template name", oidValue: " F609-HSI ", expected: "F609-HSI"},
+		{name: "Template ID", oidValue: 12, expected: "12"},
+		{name: "Unsigned template ID", oidValue: uint(3), expected: "3"},
+		{name: "No template applied", oidValue: 0, expected: ""},
+		{name: "Negative ID", oidValue: -1, expected: ""},
+		{name: "Unsupported type", oidValue: 1.5, expected: ""},
+		{name: "Nil value", oidValue: nil, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ExtractProfileTemplate(tt.oidValue))
+		})
+	}
+}
+
+func TestExtractQosProfile(t *testing.T) {
+	testCases := []struct {
+		oidValue interface{}
+		expected string
+	}{
+		{"QOS-100M", "QOS-100M"},
+		{[]byte("UP-50M-DOWN-100M"), "UP-50M-DOWN-100M"},
+		{[]byte("QOS-100M\x00\x00"), "QOS-100M"},
+		{5, "5"},
+		{0, "0"},
+		{-1, ""},
+		{nil, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("OIDValue: %v", tc.oidValue), func(t *testing.T) {
+			result := ExtractQosProfile(tc.oidValue)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
 func TestConvertAndMultiply(t *testing.T) {
 	testCases := []struct {
 		pduValue interface{}
@@ -115,6 +240,41 @@ func TestConvertAndMultiply(t *testing.T) {
 	}
 }
 
+func TestConvertOnuTransceiverReadings(t *testing.T) {
+	testCases := []struct {
+		name     string
+		convert  func(interface{}) (string, error)
+		pduValue interface{}
+		expected string
+		err      bool
+	}{
+		{"temperature", ConvertOnuTemperature, 11520, "45.00", false},
+		{"negative temperature", ConvertOnuTemperature, -1280, "-5.00", false},
+		{"temperature placeholder", ConvertOnuTemperature, 65535, "", true},
+		{"temperature below range", ConvertOnuTemperature, -32768, "", true},
+		{"voltage", ConvertOnuVoltage, 165, "3.30", false},
+		{"voltage placeholder", ConvertOnuVoltage, 65535, "", true},
+		{"negative voltage", ConvertOnuVoltage, -1, "", true},
+		{"bias current", ConvertOnuBiasCurrent, 7500, "15.000", false},
+		{"bias current placeholder", ConvertOnuBiasCurrent, 200001, "", true},
+		{"negative bias current", ConvertOnuBiasCurrent, -5, "", true},
+		{"not an integer", ConvertOnuTemperature, "45", "", true},
+		{"absent", ConvertOnuVoltage, nil, "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.convert(tc.pduValue)
+			if tc.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
 func TestExtractAndGetStatus(t *testing.T) {
 	testCases := []struct {
 		oidValue interface{}
@@ -143,6 +303,33 @@ func TestExtractAndGetStatus(t *testing.T) {
 }
 
 // TestExtractLastOfflineReason tests the ExtractLastOfflineReason function.
+func TestExtractUpgradeState(t *testing.T) {
+	tests := []struct {
+		name     string
+		oidValue interface{}
+		expected string
+	}{
+		{name: "Idle", oidValue: 1, expected: "idle"},
+		{name: "Downloading", oidValue: 2, expected: "downloading"},
+		{name: "Downloaded", oidValue: 3, expected: "downloaded"},
+		{name: "Activating", oidValue: 4, expected: "activating"},
+		{name: "Activated", oidValue: 5, expected: "activated"},
+		{name: "Committing", oidValue: 6, expected: "committing"},
+		{name: "Committed", oidValue: 7, expected: "committed"},
+		{name: "Failed", oidValue: 8, expected: "failed"},
+		{name: "Unknown code", oidValue: 9, expected: "unknown"},
+		{name: "Zero", oidValue: 0, expected: "unknown"},
+		{name: "Not an integer", oidValue: []byte("committed"), expected: "unknown"},
+		{name: "Nil value", oidValue: nil, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ExtractUpgradeState(tt.oidValue))
+		})
+	}
+}
+
 func TestExtractLastOfflineReason(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -227,7 +414,7 @@ func TestExtractLastOfflineReason(t *testing.T) {
 		{
 			name:     "Nil value",
 			oidValue: nil,
-			expected: "Unknown",
+			expected: "",
 		},
 	}
 
@@ -241,6 +428,106 @@ func TestExtractLastOfflineReason(t *testing.T) {
 	}
 }
 
+// TestExtractOfflineReasonCode tests the ExtractOfflineReasonCode function.
+func TestExtractOfflineReasonCode(t *testing.T) {
+	tests := []struct {
+		reason string
+		code   int
+		ok     bool
+	}{
+		{reason: "Unknown", code: 1, ok: true},
+		{reason: "LOS", code: 2, ok: true},
+		{reason: "LOSi", code: 3, ok: true},
+		{reason: "LOFi", code: 4, ok: true},
+		{reason: "sfi", code: 5, ok: true},
+		{reason: "loai", code: 6, ok: true},
+		{reason: "loami", code: 7, ok: true},
+		{reason: "AuthFail", code: 8, ok: true},
+		{reason: "PowerOff", code: 9, ok: true},
+		{reason: "deactiveSucc", code: 10, ok: true},
+		{reason: "deactiveFail", code: 11, ok: true},
+		{reason: "Reboot", code: 12, ok: true},
+		{reason: "Shutdown", code: 13, ok: true},
+		{reason: "Battery exhausted", code: 0, ok: true},
+		{reason: "", code: 0, ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.reason, func(t *testing.T) {
+			code, ok := ExtractOfflineReasonCode(tt.reason)
+			assert.Equal(t, tt.code, code)
+			assert.Equal(t, tt.ok, ok)
+		})
+	}
+
+	// Every reason decoded from the OLT round-trips to its code
+	for code := 1; code <= 13; code++ {
+		decoded, _ := ExtractOfflineReasonCode(ExtractLastOfflineReason(code))
+		assert.Equal(t, code, decoded)
+	}
+}
+
+// TestExtractBatteryStatus tests the ExtractBatteryStatus function.
+func TestExtractBatteryStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		oidValue interface{}
+		expected string
+	}{
+		{name: "Charged", oidValue: 1, expected: "charged"},
+		{name: "Charging", oidValue: 2, expected: "charging"},
+		{name: "On battery", oidValue: 3, expected: "on_battery"},
+		{name: "Low", oidValue: 4, expected: "low"},
+		{name: "Missing", oidValue: 5, expected: "missing"},
+		{name: "Failed", oidValue: 6, expected: "failed"},
+		{name: "Unknown code", oidValue: 7, expected: "unknown"},
+		{name: "Zero", oidValue: 0, expected: "unknown"},
+		{name: "Not an integer", oidValue: []byte("charged"), expected: "unknown"},
+		{name: "Nil value", oidValue: nil, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ExtractBatteryStatus(tt.oidValue))
+		})
+	}
+}
+
+// TestExtractRegistrationFailReason tests the ExtractRegistrationFailReason function.
+func TestExtractRegistrationFailReason(t *testing.T) {
+	tests := []struct {
+		name     string
+		oidValue interface{}
+		code     int
+		reason   string
+		err      bool
+	}{
+		{name: "None", oidValue: 1, code: 1, reason: "none"},
+		{name: "Auth failed", oidValue: 2, code: 2, reason: "auth_failed"},
+		{name: "SN conflict", oidValue: 3, code: 3, reason: "sn_conflict"},
+		{name: "Profile mismatch", oidValue: 4, code: 4, reason: "profile_mismatch"},
+		{name: "Type mismatch", oidValue: 5, code: 5, reason: "type_mismatch"},
+		{name: "Ranging failed", oidValue: 6, code: 6, reason: "ranging_failed"},
+		{name: "OMCI timeout", oidValue: 7, code: 7, reason: "omci_timeout"},
+		{name: "Unknown code keeps its value", oidValue: 42, code: 42, reason: "unknown"},
+		{name: "Not an integer", oidValue: []byte("auth_failed"), err: true},
+		{name: "Nil value", oidValue: nil, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, reason, err := ExtractRegistrationFailReason(tt.oidValue)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.code, code)
+			assert.Equal(t, tt.reason, reason)
+		})
+	}
+}
+
 // TestExtractGponOpticalDistance tests the ExtractGponOpticalDistance function.
 func TestExtractGponOpticalDistance(t *testing.T) {
 	tests := []struct {
@@ -271,7 +558,7 @@ func TestExtractGponOpticalDistance(t *testing.T) {
 		{
 			name:     "Nil value",
 			oidValue: nil,
-			expected: "Unknown",
+			expected: "",
 		},
 	}
 
@@ -284,3 +571,887 @@ func TestExtractGponOpticalDistance(t *testing.T) {
 		})
 	}
 }
+
+// TestExtractCounterValue tests the ExtractCounterValue function.
+func TestExtractCounterValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		oidValue interface{}
+		expected string
+		err      bool
+	}{
+		{name: "Counter32 value", oidValue: uint(4294967295), expected: "4294967295"},
+		{name: "Counter64 value", oidValue: uint64(18446744073709551615), expected: "18446744073709551615"},
+		{name: "uint32 value", oidValue: uint32(1024), expected: "1024"},
+		{name: "Integer value", oidValue: 2048, expected: "2048"},
+		{name: "Counter32 after wrap", oidValue: uint(0), expected: "0"},
+		{name: "Zero integer value", oidValue: 0, expected: "0"},
+		{name: "Octet string value", oidValue: []byte{0x01, 0x02}, err: true},
+		{name: "Negative integer value", oidValue: -1, err: true},
+		{name: "Non-integer value", oidValue: "string", err: true},
+		{name: "Nil value", oidValue: nil, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ExtractCounterValue(tt.oidValue)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+// TestExtractDateTime tests the ExtractDateTime function.
+func TestExtractSignalQuality(t *testing.T) {
+	tests := []struct {
+		name     string
+		oidValue interface{}
+		expected string
+		err      bool
+	}{
+		{name: "Integer", oidValue: 87, expected: "87"},
+		{name: "Lowest", oidValue: 0, expected: "0"},
+		{name: "Highest", oidValue: 100, expected: "100"},
+		{name: "Gauge32", oidValue: uint(42), expected: "42"},
+		{name: "Octet string", oidValue: []byte("73"), expected: "73"},
+		{name: "Padded octet string", oidValue: []byte("73\x00\x00"), expected: "73"},
+		{name: "Not available", oidValue: 65535, err: true},
+		{name: "Negative", oidValue: -1, err: true},
+		{name: "Non-numeric octet string", oidValue: []byte("N/A"), err: true},
+		{name: "Nil value", oidValue: nil, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ExtractSignalQuality(tt.oidValue)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestExtractMulticastEnabled(t *testing.T) {
+	tests := []struct {
+		name     string
+		oidValue interface{}
+		expected string
+		err      bool
+	}{
+		{name: "Enabled", oidValue: 1, expected: "true"},
+		{name: "Disabled", oidValue: 2, expected: "false"},
+		{name: "Disabled as zero", oidValue: 0, expected: "false"},
+		{name: "Gauge32 enabled", oidValue: uint(1), expected: "true"},
+		{name: "Unknown state", oidValue: 3, err: true},
+		{name: "Octet string", oidValue: []byte("1"), err: true},
+		{name: "Nil value", oidValue: nil, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ExtractMulticastEnabled(tt.oidValue)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestExtractLoopDetected(t *testing.T) {
+	tests := []struct {
+		name     string
+		oidValue interface{}
+		expected string
+		err      bool
+	}{
+		{name: "Loop detected", oidValue: 1, expected: "true"},
+		{name: "No loop", oidValue: 2, expected: "false"},
+		{name: "No loop as zero", oidValue: 0, expected: "false"},
+		{name: "Gauge32 loop detected", oidValue: uint(1), expected: "true"},
+		{name: "Unknown indicator", oidValue: 5, err: true},
+		{name: "Octet string", oidValue: []byte("loop"), err: true},
+		{name: "Nil value", oidValue: nil, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ExtractLoopDetected(tt.oidValue)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestExtractEncryptionEnabled(t *testing.T) {
+	tests := []struct {
+		name     string
+		oidValue interface{}
+		expected string
+		err      bool
+	}{
+		{name: "Enabled", oidValue: 1, expected: "true"},
+		{name: "Disabled", oidValue: 2, expected: "false"},
+		{name: "Disabled as zero", oidValue: 0, expected: "false"},
+		{name: "Gauge32 enabled", oidValue: uint(1), expected: "true"},
+		{name: "Enabled as text", oidValue: []byte("Enable\x00"), expected: "true"},
+		{name: "AES as text", oidValue: []byte(" aes128 "), expected: "true"},
+		{name: "Disabled as text", oidValue: []byte("disabled"), expected: "false"},
+		{name: "Unknown state", oidValue: 3, err: true},
+		{name: "Unknown text", oidValue: []byte("partial"), err: true},
+		{name: "Nil value", oidValue: nil, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ExtractEncryptionEnabled(tt.oidValue)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestExtractCatvStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		oidValue interface{}
+		expected string
+		err      bool
+	}{
+		{name: "Enabled", oidValue: 1, expected: "true"},
+		{name: "Disabled", oidValue: 2, expected: "false"},
+		{name: "Unknown state", oidValue: 4, err: true},
+		{name: "Octet string", oidValue: []byte("on"), err: true},
+		{name: "Nil value", oidValue: nil, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ExtractCatvStatus(tt.oidValue)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestExtractCatvLevel(t *testing.T) {
+	tests := []struct {
+		name     string
+		oidValue interface{}
+		expected string
+		err      bool
+	}{
+		{name: "Tenths of a dBmV", oidValue: 175, expected: "17.5"},
+		{name: "Negative level", oidValue: -32, expected: "-3.2"},
+		{name: "Decimal string", oidValue: []byte("18.0"), expected: "18.0"},
+		{name: "Null-padded string", oidValue: []byte("16.4\x00"), expected: "16.4"},
+		{name: "No RF signal placeholder", oidValue: -65535, err: true},
+		{name: "Invalid string", oidValue: []byte("N/A"), err: true},
+		{name: "Unsupported type", oidValue: uint(175), err: true},
+		{name: "Nil value", oidValue: nil, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ExtractCatvLevel(tt.oidValue)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestExtractLineRate(t *testing.T) {
+	tests := []struct {
+		name     string
+		oidValue interface{}
+		expected string
+		err      bool
+	}{
+		{name: "GPON downstream", oidValue: 2488320, expected: "2488.32"},
+		{name: "GPON upstream", oidValue: 1244160, expected: "1244.16"},
+		{name: "Unsigned", oidValue: uint(1000000), expected: "1000"},
+		{name: "Counter64", oidValue: uint64(9953280), expected: "9953.28"},
+		{name: "Null-padded string", oidValue: []byte("1244160\x00"), expected: "1244.16"},
+		{name: "Not negotiated", oidValue: 0, err: true},
+		{name: "Negative", oidValue: -1, err: true},
+		{name: "Invalid string", oidValue: []byte("N/A"), err: true},
+		{name: "Nil value", oidValue: nil, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ExtractLineRate(tt.oidValue)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestExtractTcontAllocation(t *testing.T) {
+	tests := []struct {
+		name     string
+		oidValue interface{}
+		expected []model.TcontAllocation
+		err      bool
+	}{
+		{
+			name:     "Entries with GEM ports",
+			oidValue: []byte("1,1024,UP-100M,2;2,1025,VOIP,1"),
+			expected: []model.TcontAllocation{
+				{Index: 1, AllocID: 1024, Profile: "UP-100M", GemPorts: 2},
+				{Index: 2, AllocID: 1025, Profile: "VOIP", GemPorts: 1},
+			},
+		},
+		{
+			name:     "Entry without GEM ports",
+			oidValue: "1, 1024, UP-100M",
+			expected: []model.TcontAllocation{{Index: 1, AllocID: 1024, Profile: "UP-100M"}},
+		},
+		{
+			name:     "Trailing separator and null padding",
+			oidValue: []byte("1,1024,UP-100M;\x00"),
+			expected: []model.TcontAllocation{{Index: 1, AllocID: 1024, Profile: "UP-100M"}},
+		},
+		{name: "No T-CONT allocated", oidValue: []byte(""), expected: []model.TcontAllocation{}},
+		{name: "Missing profile", oidValue: []byte("1,1024"), err: true},
+		{name: "Too many fields", oidValue: []byte("1,1024,UP-100M,2,9"), err: true},
+		{name: "Invalid index", oidValue: []byte("0,1024,UP-100M"), err: true},
+		{name: "Invalid alloc-id", oidValue: []byte("1,x,UP-100M"), err: true},
+		{name: "Invalid GEM port count", oidValue: []byte("1,1024,UP-100M,-1"), err: true},
+		{name: "Not a string", oidValue: 3, err: true},
+		{name: "Nil value", oidValue: nil, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ExtractTcontAllocation(tt.oidValue)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestExtractUniSpeed(t *testing.T) {
+	tests := []struct {
+		name     string
+		oidValue interface{}
+		expected float64
+		err      bool
+	}{
+		{name: "10M code", oidValue: 1, expected: 10},
+		{name: "100M code", oidValue: 2, expected: 100},
+		{name: "1G code", oidValue: 3, expected: 1000},
+		{name: "10G code", oidValue: 4, expected: 10000},
+		{name: "2.5G code", oidValue: 5, expected: 2500},
+		{name: "5G code", oidValue: 6, expected: 5000},
+		{name: "Link down", oidValue: 0, err: true},
+		{name: "Unknown code", oidValue: 9, err: true},
+		{name: "Megabit text", oidValue: []byte("100M"), expected: 100},
+		{name: "Gigabit text", oidValue: "1G", expected: 1000},
+		{name: "Fractional gigabit text", oidValue: []byte("2.5Gbps"), expected: 2500},
+		{name: "Lowercase with padding", oidValue: []byte(" 1000mbps\x00"), expected: 1000},
+		{name: "Text without unit", oidValue: []byte("1000"), err: true},
+		{name: "Zero speed text", oidValue: []byte("0M"), err: true},
+		{name: "Not a speed", oidValue: []byte("auto"), err: true},
+		{name: "Nil value", oidValue: nil, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ExtractUniSpeed(tt.oidValue)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestExtractUniDuplex(t *testing.T) {
+	tests := []struct {
+		name     string
+		oidValue interface{}
+		expected string
+		err      bool
+	}{
+		{name: "Half code", oidValue: 1, expected: "half"},
+		{name: "Full code", oidValue: 2, expected: "full"},
+		{name: "Unknown code", oidValue: 3, err: true},
+		{name: "Zero code", oidValue: 0, err: true},
+		{name: "Full text", oidValue: []byte("full"), expected: "full"},
+		{name: "Uppercase text with padding", oidValue: []byte("HALF\x00"), expected: "half"},
+		{name: "Unknown text", oidValue: "auto", err: true},
+		{name: "Nil value", oidValue: nil, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ExtractUniDuplex(tt.oidValue)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestExtractLosFlag(t *testing.T) {
+	tests := []struct {
+		name     string
+		oidValue interface{}
+		expected string
+		err      bool
+	}{
+		{name: "LOS raised", oidValue: 1, expected: "true"},
+		{name: "LOS cleared", oidValue: 2, expected: "false"},
+		{name: "LOS cleared as zero", oidValue: 0, expected: "false"},
+		{name: "Unknown indicator", oidValue: 3, err: true},
+		{name: "Nil value", oidValue: nil, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ExtractLosFlag(tt.oidValue)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestExtractMulticastGroups(t *testing.T) {
+	tests := []struct {
+		name     string
+		oidValue interface{}
+		expected string
+		err      bool
+	}{
+		{name: "Integer", oidValue: 3, expected: "3"},
+		{name: "No group", oidValue: 0, expected: "0"},
+		{name: "Gauge32", oidValue: uint(12), expected: "12"},
+		{name: "Octet string", oidValue: []byte("4"), expected: "4"},
+		{name: "Padded octet string", oidValue: []byte("4\x00"), expected: "4"},
+		{name: "Negative", oidValue: -1, err: true},
+		{name: "Non-numeric octet string", oidValue: []byte("N/A"), err: true},
+		{name: "Nil value", oidValue: nil, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ExtractMulticastGroups(tt.oidValue)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestExtractAlarmCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		oidValue interface{}
+		expected string
+		err      bool
+	}{
+		{name: "Integer", oidValue: 17, expected: "17"},
+		{name: "No alarm", oidValue: 0, expected: "0"},
+		{name: "Counter32", oidValue: uint(4294967295), expected: "4294967295"},
+		{name: "Octet string", oidValue: []byte("42"), expected: "42"},
+		{name: "Padded octet string", oidValue: []byte(" 42\x00"), expected: "42"},
+		{name: "Negative", oidValue: -3, err: true},
+		{name: "Non-numeric octet string", oidValue: []byte("N/A"), err: true},
+		{name: "Unexpected type", oidValue: "42", err: true},
+		{name: "Nil value", oidValue: nil, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ExtractAlarmCount(tt.oidValue)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestExtractPonTxPower(t *testing.T) {
+	tests := []struct {
+		name     string
+		oidValue interface{}
+		expected string
+		err      bool
+	}{
+		{name: "Thousandths of a dBm", oidValue: 3125, expected: "3.12"},
+		{name: "Negative", oidValue: -1500, expected: "-1.50"},
+		{name: "Zero", oidValue: 0, expected: "0.00"},
+		{name: "Decimal octet string", oidValue: []byte("4.5"), expected: "4.50"},
+		{name: "Padded octet string", oidValue: []byte("4.5\x00"), expected: "4.50"},
+		{name: "No optical module", oidValue: -2147483648, err: true},
+		{name: "Not available", oidValue: 65535000, err: true},
+		{name: "Non-numeric octet string", oidValue: []byte("N/A"), err: true},
+		{name: "Nil value", oidValue: nil, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ExtractPonTxPower(tt.oidValue)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestExtractTransceiverRxPower(t *testing.T) {
+	tests := []struct {
+		name     string
+		oidValue interface{}
+		expected string
+		err      bool
+	}{
+		{name: "Thousandths of a dBm", oidValue: -18250, expected: "-18.25"},
+		{name: "Decimal octet string", oidValue: []byte("-21.3"), expected: "-21.30"},
+		{name: "No received signal", oidValue: -2147483648, err: true},
+		{name: "Non-numeric octet string", oidValue: []byte("N/A"), err: true},
+		{name: "Nil value", oidValue: nil, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ExtractTransceiverRxPower(tt.oidValue)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestExtractTransceiverTemperature(t *testing.T) {
+	tests := []struct {
+		name     string
+		oidValue interface{}
+		expected string
+		err      bool
+	}{
+		{name: "Thousandths of a degree", oidValue: 42500, expected: "42.50"},
+		{name: "Below zero", oidValue: -5000, expected: "-5.00"},
+		{name: "Decimal octet string", oidValue: []byte("38.75\x00"), expected: "38.75"},
+		{name: "No optical module", oidValue: 65535000, err: true},
+		{name: "Non-numeric octet string", oidValue: []byte("--"), err: true},
+		{name: "Nil value", oidValue: nil, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ExtractTransceiverTemperature(tt.oidValue)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestExtractTransceiverBias(t *testing.T) {
+	tests := []struct {
+		name     string
+		oidValue interface{}
+		expected string
+		err      bool
+	}{
+		{name: "Microamperes", oidValue: 12345, expected: "12.345"},
+		{name: "Laser off", oidValue: 0, expected: "0.000"},
+		{name: "Decimal octet string", oidValue: []byte("8.2"), expected: "8.200"},
+		{name: "Negative placeholder", oidValue: -1, err: true},
+		{name: "No optical module", oidValue: 65535000, err: true},
+		{name: "Nil value", oidValue: nil, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ExtractTransceiverBias(tt.oidValue)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestExtractTransceiverVoltage(t *testing.T) {
+	tests := []struct {
+		name     string
+		oidValue interface{}
+		expected string
+		err      bool
+	}{
+		{name: "Millivolts", oidValue: 3287, expected: "3.287"},
+		{name: "Decimal octet string", oidValue: []byte("3.3"), expected: "3.300"},
+		{name: "No optical module", oidValue: 65535, err: true},
+		{name: "Negative placeholder", oidValue: -1, err: true},
+		{name: "Nil value", oidValue: nil, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ExtractTransceiverVoltage(tt.oidValue)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestExtractDateTime(t *testing.T) {
+	tests := []struct {
+		name     string
+		oidValue interface{}
+		expected string
+		err      bool
+	}{
+		{name: "Valid date time", oidValue: []byte{0x07, 0xE6, 0x03, 0x0F, 0x08, 0x1E, 0x2D, 0x00}, expected: "2022-03-15 08:30:45"},
+		{name: "Invalid month", oidValue: []byte{0x07, 0xE6, 0x0D, 0x0F, 0x08, 0x1E, 0x2D, 0x00}, err: true},
+		{name: "Short octet string", oidValue: []byte{0x07, 0xE6, 0x03}, err: true},
+		{name: "Non-octet string value", oidValue: 1647333045, err: true},
+		{name: "Nil value", oidValue: nil, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ExtractDateTime(tt.oidValue)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+// TestExtractMACAddress tests the ExtractMACAddress function.
+func TestExtractMACAddress(t *testing.T) {
+	tests := []struct {
+		name     string
+		oidValue interface{}
+		expected string
+	}{
+		{name: "Raw 6-byte octet string", oidValue: []byte{0x00, 0x1A, 0x2B, 0x3C, 0x4D, 0x5E}, expected: "00:1a:2b:3c:4d:5e"},
+		{name: "Colon-separated string", oidValue: "00:1A:2B:3C:4D:5E", expected: "00:1a:2b:3c:4d:5e"},
+		{name: "Hyphen-separated string", oidValue: "00-1A-2B-3C-4D-5E", expected: "00:1a:2b:3c:4d:5e"},
+		{name: "Dotted string", oidValue: "001a.2b3c.4d5e", expected: "00:1a:2b:3c:4d:5e"},
+		{name: "Hyphen-separated octet string", oidValue: []byte("00-1A-2B-3C-4D-5E"), expected: "00:1a:2b:3c:4d:5e"},
+		{name: "Too short", oidValue: "00:1A:2B", expected: ""},
+		{name: "Invalid hex", oidValue: "ZZ:1A:2B:3C:4D:5E", expected: ""},
+		{name: "Unsupported type", oidValue: 12345, expected: ""},
+		{name: "Nil value", oidValue: nil, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ExtractMACAddress(tt.oidValue))
+		})
+	}
+}
+
+func TestExtractIPv4Address(t *testing.T) {
+	tests := []struct {
+		name     string
+		oidValue interface{}
+		expected string
+	}{
+		{name: "Dotted string", oidValue: "192.168.1.1", expected: "192.168.1.1"},
+		{name: "Raw 4-byte octet string", oidValue: []byte{10, 0, 0, 1}, expected: "10.0.0.1"},
+		{name: "Dotted octet string", oidValue: []byte("172.16.0.254"), expected: "172.16.0.254"},
+		{name: "Unassigned address", oidValue: "0.0.0.0", expected: ""},
+		{name: "Invalid string", oidValue: "not-an-ip", expected: ""},
+		{name: "IPv6 string", oidValue: "fe80::1", expected: ""},
+		{name: "Unsupported type", oidValue: 12345, expected: ""},
+		{name: "Nil value", oidValue: nil, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ExtractIPv4Address(tt.oidValue))
+		})
+	}
+}
+
+func TestExtractSubnetMask(t *testing.T) {
+	tests := []struct {
+		name     string
+		oidValue interface{}
+		expected string
+	}{
+		{name: "Dotted string", oidValue: "255.255.255.0", expected: "255.255.255.0"},
+		{name: "Raw 4-byte octet string", oidValue: []byte{255, 255, 0, 0}, expected: "255.255.0.0"},
+		{name: "Prefix length", oidValue: 24, expected: "255.255.255.0"},
+		{name: "Unsigned prefix length", oidValue: uint(30), expected: "255.255.255.252"},
+		{name: "Prefix length out of range", oidValue: 33, expected: ""},
+		{name: "Zero prefix length", oidValue: 0, expected: ""},
+		{name: "Nil value", oidValue: nil, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ExtractSubnetMask(tt.oidValue))
+		})
+	}
+}
+
+// TestExtractPsuStatus tests the ExtractPsuStatus function.
+func TestExtractPsuStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		oidValue interface{}
+		expected string
+	}{
+		{name: "Normal", oidValue: 1, expected: "ok"},
+		{name: "Fault", oidValue: 2, expected: "fault"},
+		{name: "Not present", oidValue: 3, expected: "absent"},
+		{name: "Unknown status", oidValue: 9, expected: ""},
+		{name: "Non-integer value", oidValue: "normal", expected: ""},
+		{name: "Nil value", oidValue: nil, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ExtractPsuStatus(tt.oidValue))
+		})
+	}
+}
+
+func TestExtractSysUptime(t *testing.T) {
+	tests := []struct {
+		name     string
+		oidValue interface{}
+		expected string
+		err      bool
+	}{
+		{name: "TimeTicks", oidValue: uint32(864001234), expected: "8640012.34"},
+		{name: "Integer", oidValue: 100, expected: "1.00"},
+		{name: "Negative value", oidValue: -1, err: true},
+		{name: "Octet string", oidValue: []byte("100"), err: true},
+		{name: "Nil value", oidValue: nil, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ExtractSysUptime(tt.oidValue)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestExtractUsagePercent(t *testing.T) {
+	tests := []struct {
+		name     string
+		oidValue interface{}
+		expected string
+		err      bool
+	}{
+		{name: "Integer", oidValue: 27, expected: "27.00"},
+		{name: "Gauge", oidValue: uint(100), expected: "100.00"},
+		{name: "Decimal octet string", oidValue: []byte("64.5%\x00"), expected: "64.50"},
+		{name: "Above 100", oidValue: 255, err: true},
+		{name: "Non-numeric octet string", oidValue: []byte("--"), err: true},
+		{name: "Nil value", oidValue: nil, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ExtractUsagePercent(tt.oidValue)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestExtractChassisTemperature(t *testing.T) {
+	tests := []struct {
+		name     string
+		oidValue interface{}
+		expected string
+		err      bool
+	}{
+		{name: "Degrees", oidValue: 41, expected: "41.00"},
+		{name: "Below zero", oidValue: -5, expected: "-5.00"},
+		{name: "Decimal octet string", oidValue: []byte("38.5"), expected: "38.50"},
+		{name: "No sensor", oidValue: 65535, err: true},
+		{name: "Nil value", oidValue: nil, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ExtractChassisTemperature(tt.oidValue)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+// TestExtractIfStatus tests the ExtractIfStatus function.
+func TestExtractIfStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		oidValue interface{}
+		expected int
+	}{
+		{name: "Up", oidValue: 1, expected: 1},
+		{name: "Down", oidValue: 2, expected: 2},
+		{name: "Non-integer value", oidValue: "up", expected: 0},
+		{name: "Nil value", oidValue: nil, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ExtractIfStatus(tt.oidValue))
+		})
+	}
+}
+
+// TestExtractorsNilValue tests that every extractor reports the nil value of an absent OID as an
+// empty result or an error, never as a default reading such as "0" or "Unknown".
+func TestExtractorsNilValue(t *testing.T) {
+	withoutError := map[string]func(interface{}) string{
+		"ExtractName":                ExtractName,
+		"ExtractSerialNumber":        ExtractSerialNumber,
+		"ExtractEquipmentID":         ExtractEquipmentID,
+		"ExtractTransceiverType":     ExtractTransceiverType,
+		"ExtractProfileTemplate":     ExtractProfileTemplate,
+		"ExtractQosProfile":          ExtractQosProfile,
+		"ExtractAndGetStatus":        ExtractAndGetStatus,
+		"ExtractLastOfflineReason":   ExtractLastOfflineReason,
+		"ExtractUpgradeState":        ExtractUpgradeState,
+		"ExtractBatteryStatus":       ExtractBatteryStatus,
+		"ExtractGponOpticalDistance": ExtractGponOpticalDistance,
+		"ExtractIPv4Address":         ExtractIPv4Address,
+		"ExtractSubnetMask":          ExtractSubnetMask,
+		"ExtractMACAddress":          ExtractMACAddress,
+		"ExtractPsuStatus":           ExtractPsuStatus,
+	}
+	for name, extract := range withoutError {
+		t.Run(name, func(t *testing.T) {
+			assert.Empty(t, extract(nil))
+		})
+	}
+
+	withError := map[string]func(interface{}) (string, error){
+		"ConvertAndMultiply":            ConvertAndMultiply,
+		"ExtractCounterValue":           ExtractCounterValue,
+		"ExtractSignalQuality":          ExtractSignalQuality,
+		"ExtractMulticastEnabled":       ExtractMulticastEnabled,
+		"ExtractLoopDetected":           ExtractLoopDetected,
+		"ExtractEncryptionEnabled":      ExtractEncryptionEnabled,
+		"ExtractCatvStatus":             ExtractCatvStatus,
+		"ExtractCatvLevel":              ExtractCatvLevel,
+		"ExtractLineRate":               ExtractLineRate,
+		"ExtractUniDuplex":              ExtractUniDuplex,
+		"ExtractLosFlag":                ExtractLosFlag,
+		"ExtractMulticastGroups":        ExtractMulticastGroups,
+		"ExtractPonTxPower":             ExtractPonTxPower,
+		"ExtractTransceiverRxPower":     ExtractTransceiverRxPower,
+		"ExtractTransceiverTemperature": ExtractTransceiverTemperature,
+		"ExtractTransceiverBias":        ExtractTransceiverBias,
+		"ExtractTransceiverVoltage":     ExtractTransceiverVoltage,
+		"ExtractDateTime":               ExtractDateTime,
+	}
+	for name, extract := range withError {
+		t.Run(name, func(t *testing.T) {
+			value, err := extract(nil)
+			assert.Error(t, err)
+			assert.Empty(t, value)
+		})
+	}
+
+	t.Run("ExtractRegistrationFailReason", func(t *testing.T) {
+		_, reason, err := ExtractRegistrationFailReason(nil)
+		assert.Error(t, err)
+		assert.Empty(t, reason)
+	})
+	t.Run("ExtractTcontAllocation", func(t *testing.T) {
+		tconts, err := ExtractTcontAllocation(nil)
+		assert.Error(t, err)
+		assert.Nil(t, tconts)
+	})
+	t.Run("ExtractUniSpeed", func(t *testing.T) {
+		speed, err := ExtractUniSpeed(nil)
+		assert.Error(t, err)
+		assert.Zero(t, speed)
+	})
+	t.Run("ExtractIfStatus", func(t *testing.T) {
+		assert.Zero(t, ExtractIfStatus(nil))
+	})
+}
@@ -0,0 +1,61 @@
+package connlimit
+
+import (
+	"net"
+	"sync"
+)
+
+// Listener returns a listener that accepts at most max connections at the same time, so slow
+// clients cannot exhaust the connections of the server. Further connections wait in the accept
+// backlog until one is closed. A max below 1 returns l unchanged.
+func Listener(l net.Listener, max int) net.Listener {
+	if max < 1 {
+		return l
+	}
+	return &limitListener{Listener: l, sem: make(chan struct{}, max), done: make(chan struct{})}
+}
+
+// limitListener is a net.Listener bounding its open connections with a semaphore
+type limitListener struct {
+	net.Listener
+	sem       chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// Accept waits for a free slot, then for the next connection.
+func (l *limitListener) Accept() (net.Conn, error) {
+	select {
+	case l.sem <- struct{}{}:
+	case <-l.done:
+		return nil, net.ErrClosed
+	}
+
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		<-l.sem
+		return nil, err
+	}
+	return &limitConn{Conn: conn, release: func() { <-l.sem }}, nil
+}
+
+// Close closes the listener, unblocking the pending Accept calls.
+func (l *limitListener) Close() error {
+	err := l.Listener.Close()
+	l.closeOnce.Do(func() { close(l.done) })
+	return err
+}
+
+// limitConn frees its slot of the listener once closed.
+type limitConn struct {
+	net.Conn
+	releaseOnce sync.Once
+	release     func()
+}
+
+// Close closes the connection and frees its slot, even when called more than once.
+func (c *limitConn) Close() error {
+	err := c.Conn.Close()
+	c.releaseOnce.Do(c.release)
+	return err
+}
@@ -5,49 +5,41 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
-	"os"
-	"os/signal"
-	"syscall"
 	"time"
 )
 
-// Shutdown gracefully shuts down the HTTP server when the context is done or an OS signal is received.
-func Shutdown(ctx context.Context, server *http.Server) error {
+// Shutdown serves the HTTP server on listener until the context is done, then stops accepting
+// connections and waits up to gracePeriod (10 seconds when zero or less) for the in-flight requests
+// to complete, e.g. a scrape walking the OLT. It returns once the server is shut down.
+func Shutdown(ctx context.Context, server *http.Server, listener net.Listener, gracePeriod time.Duration) error {
+	if gracePeriod <= 0 {
+		gracePeriod = 10 * time.Second
+	}
+
 	ch := make(chan error, 1)
 
 	go func() {
-		err := server.ListenAndServe()
+		err := server.Serve(listener)
 		if err != nil && !errors.Is(err, http.ErrServerClosed) {
 			ch <- fmt.Errorf("failed to start server: %v", err)
 		}
 		close(ch)
 	}()
 
-	// Create a channel to capture OS signals (e.g., SIGINT or SIGTERM).
-	signalCh := make(chan os.Signal, 1)
-	signal.Notify(signalCh, os.Interrupt, syscall.SIGTERM)
-
 	select {
 	case err := <-ch:
 		return err
 	case <-ctx.Done():
-		timeoutCtx, cancel := context.WithTimeout(context.Background(), time.Second*10)
-		defer cancel()
-		if err := server.Shutdown(timeoutCtx); err != nil {
-			log.Printf("Failed to gracefully shut down the server: %v", err)
-		}
-	case sig := <-signalCh:
-		log.Printf("Received signal: %v. Shutting down gracefully...", sig)
+	}
 
-		// Inisialisasi konteks dengan timeout
-		shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Second*10)
-		defer cancel()
+	log.Printf("Shutting down gracefully, waiting up to %s for in-flight requests...", gracePeriod)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), gracePeriod)
+	defer cancel()
 
-		if err := server.Shutdown(shutdownCtx); err != nil {
-			log.Printf("Failed to gracefully shut down the server: %v", err)
-		}
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to gracefully shut down the server: %w", err)
 	}
-
 	return nil
 }
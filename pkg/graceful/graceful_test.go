@@ -0,0 +1,121 @@
+package graceful
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slowCollector signals started once a collection began, then completes it when release is closed.
+type slowCollector struct {
+	desc    *prometheus.Desc
+	started chan struct{}
+	release chan struct{}
+}
+
+func newSlowCollector() *slowCollector {
+	return &slowCollector{
+		desc:    prometheus.NewDesc("slow_up", "Whether the slow collection completed.", nil, nil),
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+}
+
+func (c *slowCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *slowCollector) Collect(ch chan<- prometheus.Metric) {
+	close(c.started)
+	<-c.release
+	ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, 1)
+}
+
+// serveSlowCollector serves collector on /metrics until the returned context is cancelled. The
+// channel receives the result of Shutdown.
+func serveSlowCollector(t *testing.T, collector *slowCollector, gracePeriod time.Duration) (string, context.CancelFunc, <-chan error) {
+	t.Helper()
+	registry := prometheus.NewRegistry()
+	require.NoError(t, registry.Register(collector))
+	server := httptest.NewUnstartedServer(promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	done := make(chan error, 1)
+	go func() {
+		done <- Shutdown(ctx, server.Config, server.Listener, gracePeriod)
+	}()
+	return "http://" + server.Listener.Addr().String() + "/metrics", cancel, done
+}
+
+func TestShutdownDrainsInFlightScrape(t *testing.T) {
+	collector := newSlowCollector()
+	url, cancel, done := serveSlowCollector(t, collector, 5*time.Second)
+
+	type scrape struct {
+		status int
+		body   string
+		err    error
+	}
+	scraped := make(chan scrape, 1)
+	go func() {
+		resp, err := http.Get(url)
+		if err != nil {
+			scraped <- scrape{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		scraped <- scrape{status: resp.StatusCode, body: string(body), err: err}
+	}()
+
+	<-collector.started
+	cancel()
+
+	select {
+	case err := <-done:
+		t.Fatalf("shut down before the scrape completed: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(collector.release)
+	result := <-scraped
+	require.NoError(t, result.err)
+	assert.Equal(t, http.StatusOK, result.status)
+	assert.Contains(t, result.body, "slow_up 1", "the in-flight scrape completes")
+	require.NoError(t, <-done)
+
+	_, err := http.Get(url)
+	assert.Error(t, err, "no new request is accepted once shut down")
+}
+
+func TestShutdownGracePeriodExpires(t *testing.T) {
+	collector := newSlowCollector()
+	defer close(collector.release)
+	url, cancel, done := serveSlowCollector(t, collector, 50*time.Millisecond)
+
+	go func() {
+		if resp, err := http.Get(url); err == nil {
+			resp.Body.Close()
+		}
+	}()
+	<-collector.started
+
+	start := time.Now()
+	cancel()
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), time.Second, "a stuck scrape does not hold the shutdown past the grace period")
+	case <-time.After(5 * time.Second):
+		t.Fatal("shutdown did not give up after the grace period")
+	}
+}
@@ -29,12 +29,7 @@ func New(page, pageSize, total int) *Pages {
 	if page <= 0 {
 		page = 0
 	}
-	if pageSize <= 0 {
-		pageSize = DefaultPageSize
-	}
-	if pageSize > MaxPageSize {
-		pageSize = MaxPageSize
-	}
+	pageSize = ClampPageSize(pageSize)
 	pageCount := -1
 	if total >= 0 {
 		pageCount = (total + pageSize - 1) / pageSize
@@ -49,13 +44,28 @@ func New(page, pageSize, total int) *Pages {
 	}
 }
 
-// GetPaginationParametersFromRequest extracts pagination parameters from the HTTP request
+// GetPaginationParametersFromRequest extracts pagination parameters from the HTTP request.
+// The page index starts at 1 and the page size is clamped with ClampPageSize.
 func GetPaginationParametersFromRequest(r *http.Request) (pageIndex, pageSize int) {
 	pageIndex = parseInt(r.URL.Query().Get(PageVar), 1)
-	pageSize = parseInt(r.URL.Query().Get(PageSizeVar), DefaultPageSize)
+	if pageIndex < 1 {
+		pageIndex = 1
+	}
+	pageSize = ClampPageSize(parseInt(r.URL.Query().Get(PageSizeVar), DefaultPageSize))
 	return pageIndex, pageSize
 }
 
+// ClampPageSize returns DefaultPageSize for a zero or negative page size and caps it at MaxPageSize
+func ClampPageSize(pageSize int) int {
+	if pageSize <= 0 {
+		return DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		return MaxPageSize
+	}
+	return pageSize
+}
+
 // parseInt is a helper function to parse string to int with a default value
 func parseInt(value string, defaultValue int) int {
 	if value == "" {
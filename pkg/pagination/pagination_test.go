@@ -0,0 +1,68 @@
+package pagination
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClampPageSize(t *testing.T) {
+	tests := []struct {
+		name     string
+		pageSize int
+		expected int
+	}{
+		{name: "Zero page size uses default", pageSize: 0, expected: DefaultPageSize},
+		{name: "Negative page size uses default", pageSize: -5, expected: DefaultPageSize},
+		{name: "Page size within range", pageSize: 25, expected: 25},
+		{name: "Page size at maximum", pageSize: MaxPageSize, expected: MaxPageSize},
+		{name: "Page size above maximum is clamped", pageSize: 100000, expected: MaxPageSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ClampPageSize(tt.pageSize))
+		})
+	}
+}
+
+func TestGetPaginationParametersFromRequest(t *testing.T) {
+	tests := []struct {
+		name             string
+		query            string
+		expectedPage     int
+		expectedPageSize int
+	}{
+		{name: "No parameters", query: "", expectedPage: 1, expectedPageSize: DefaultPageSize},
+		{name: "Valid parameters", query: "?page=2&limit=20", expectedPage: 2, expectedPageSize: 20},
+		{name: "Huge page size is clamped", query: "?page=1&limit=100000", expectedPage: 1, expectedPageSize: MaxPageSize},
+		{name: "Zero page size uses default", query: "?limit=0", expectedPage: 1, expectedPageSize: DefaultPageSize},
+		{name: "Negative page size uses default", query: "?limit=-10", expectedPage: 1, expectedPageSize: DefaultPageSize},
+		{name: "Invalid page size uses default", query: "?limit=abc", expectedPage: 1, expectedPageSize: DefaultPageSize},
+		{name: "Zero page uses first page", query: "?page=0", expectedPage: 1, expectedPageSize: DefaultPageSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/api/v1/paginate/board/1/pon/1"+tt.query, nil)
+			page, pageSize := GetPaginationParametersFromRequest(r)
+			assert.Equal(t, tt.expectedPage, page)
+			assert.Equal(t, tt.expectedPageSize, pageSize)
+		})
+	}
+}
+
+func TestNewReturnsEffectivePageSize(t *testing.T) {
+	originalMax := MaxPageSize
+	MaxPageSize = 200
+	defer func() { MaxPageSize = originalMax }()
+
+	pages := New(1, 500, 450)
+	assert.Equal(t, 200, pages.PageSize)
+	assert.Equal(t, 3, pages.PageCount)
+
+	pages = New(1, 0, 45)
+	assert.Equal(t, DefaultPageSize, pages.PageSize)
+	assert.Equal(t, 5, pages.PageCount)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/gosnmp/gosnmp"
 	"github.com/megadata-dev/go-snmp-olt-zte-c320/config"
+	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/repository"
 	"github.com/megadata-dev/go-snmp-olt-zte-c320/internal/utils"
 )
 
@@ -15,6 +16,8 @@ var (
 	snmpHost      string // SNMP host
 	snmpPort      uint16 // SNMP port
 	snmpCommunity string // SNMP community
+	snmpContext   string // SNMPv3 context name
+	snmpLocalAddr string // Source address of the SNMP requests
 	//logSnmp       gosnmp.Logger // Logger for SNMP
 )
 
@@ -27,29 +30,39 @@ func SetupSnmpConnection(config *config.Config) (*gosnmp.GoSNMP, error) {
 		snmpHost = os.Getenv("SNMP_HOST")
 		snmpPort = utils.ConvertStringToUint16(os.Getenv("SNMP_PORT"))
 		snmpCommunity = os.Getenv("SNMP_COMMUNITY")
+		snmpContext = os.Getenv("SNMP_CONTEXT_NAME")
+		snmpLocalAddr = os.Getenv("SNMP_LOCAL_ADDR")
 		logSnmp = gosnmp.Logger{}
 	} else {
 		snmpHost = config.SnmpCfg.IP
 		snmpPort = config.SnmpCfg.Port
 		snmpCommunity = config.SnmpCfg.Community
+		snmpContext = config.SnmpCfg.ContextName
+		snmpLocalAddr = config.SnmpCfg.LocalAddr
 		logSnmp = gosnmp.NewLogger(log.New(os.Stdout, "", 0))
 	}
 
-	// Check if SNMP configuration is valid
-	if snmpHost == "" || snmpPort == 0 || snmpCommunity == "" {
+	// Check if SNMP configuration is valid, SNMPv3 authenticates with a user instead of the community
+	version, v3 := Version(config)
+	if snmpHost == "" || snmpPort == 0 {
+		return nil, fmt.Errorf("konfigurasi SNMP tidak valid")
+	}
+	if version == repository.SnmpVersion3 && v3.Username == "" || version != repository.SnmpVersion3 && snmpCommunity == "" {
 		return nil, fmt.Errorf("konfigurasi SNMP tidak valid")
 	}
 
 	// Create a new SNMP target instance
 	target := &gosnmp.GoSNMP{
-		Target:    snmpHost,
-		Port:      snmpPort,
-		Community: snmpCommunity,
-		Version:   gosnmp.Version2c,
-		Timeout:   time.Duration(30) * time.Second,
-		Retries:   3,
-		Logger:    logSnmp,
+		Target:      snmpHost,
+		Port:        snmpPort,
+		Community:   snmpCommunity,
+		ContextName: snmpContext,
+		LocalAddr:   repository.NormalizeLocalAddr(snmpLocalAddr),
+		Timeout:     time.Duration(30) * time.Second,
+		Retries:     3,
+		Logger:      logSnmp,
 	}
+	repository.ConfigureVersion(target, version, v3)
 
 	// Connect to the SNMP target
 	err := target.Connect()
@@ -59,3 +72,58 @@ func SetupSnmpConnection(config *config.Config) (*gosnmp.GoSNMP, error) {
 
 	return target, nil
 }
+
+// WalkMethod returns the configured SNMP walk method, "next" or "bulk"
+func WalkMethod(config *config.Config) string {
+	if os.Getenv("APP_ENV") == "development" || os.Getenv("APP_ENV") == "production" {
+		return os.Getenv("SNMP_WALK_METHOD")
+	}
+	return config.SnmpCfg.WalkMethod
+}
+
+// Version returns the configured SNMP version, repository.SnmpVersion2c or
+// repository.SnmpVersion3, and the SNMPv3 credentials
+func Version(config *config.Config) (version string, v3 repository.SnmpV3Params) {
+	if os.Getenv("APP_ENV") == "development" || os.Getenv("APP_ENV") == "production" {
+		version = os.Getenv("SNMP_VERSION")
+		v3 = repository.SnmpV3Params{
+			SecurityLevel:  os.Getenv("SNMP_V3_SECURITY_LEVEL"),
+			Username:       os.Getenv("SNMP_V3_USERNAME"),
+			AuthProtocol:   os.Getenv("SNMP_V3_AUTH_PROTOCOL"),
+			AuthPassphrase: os.Getenv("SNMP_V3_AUTH_PASSPHRASE"),
+			PrivProtocol:   os.Getenv("SNMP_V3_PRIV_PROTOCOL"),
+			PrivPassphrase: os.Getenv("SNMP_V3_PRIV_PASSPHRASE"),
+		}
+	} else {
+		version = config.SnmpCfg.Version
+		v3 = V3Params(config.SnmpCfg.V3)
+	}
+	return repository.ParseSnmpVersion(version), v3
+}
+
+// Retry returns the configured retry policy of the SNMP requests failing with a transient error
+func Retry(config *config.Config) repository.RetryPolicy {
+	if os.Getenv("APP_ENV") == "development" || os.Getenv("APP_ENV") == "production" {
+		backoff, err := time.ParseDuration(os.Getenv("SNMP_RETRY_BACKOFF"))
+		if err != nil {
+			backoff = 500 * time.Millisecond
+		}
+		return repository.RetryPolicy{
+			Retries: utils.ConvertStringToInteger(os.Getenv("SNMP_RETRIES")),
+			Backoff: backoff,
+		}
+	}
+	return repository.RetryPolicy{Retries: config.SnmpCfg.Retries, Backoff: config.SnmpCfg.RetryBackoff}
+}
+
+// V3Params converts the SNMPv3 credentials of the config file to those of the repository
+func V3Params(v3 config.SnmpV3Config) repository.SnmpV3Params {
+	return repository.SnmpV3Params{
+		SecurityLevel:  v3.SecurityLevel,
+		Username:       v3.Username,
+		AuthProtocol:   v3.AuthProtocol,
+		AuthPassphrase: v3.AuthPassphrase,
+		PrivProtocol:   v3.PrivProtocol,
+		PrivPassphrase: v3.PrivPassphrase,
+	}
+}